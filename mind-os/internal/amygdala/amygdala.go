@@ -3,90 +3,198 @@ package amygdala
 
 import (
 	"log/slog"
+	"math"
 	"sort"
 
-	"github.com/ikawaha/kagome-dict/ipa"
-	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/umekku/mind-os/internal/language"
 	"github.com/umekku/mind-os/internal/models"
 )
 
 // Amygdala は扁桃体モジュール - 入力テキストから反射的な感情を生成
 type Amygdala struct {
-	tokenizer *tokenizer.Tokenizer
-	dict      map[string]models.EmotionValue
+	analyzers language.Analyzers // 言語ごとの形態素解析器
+	lexicon   *Lexicon           // 感情辞書 (複数の脳で共有し、再読み込みが全ての脳に反映される)
+	character string             // キャラクター別の辞書レイヤーの名前 (プロファイル名)
+}
+
+// Option は Amygdala の設定を変更する関数
+type Option func(*Amygdala)
+
+// WithLexicon は感情辞書を設定する (未指定の場合は組み込みの辞書)
+func WithLexicon(lexicon *Lexicon) Option {
+	return func(a *Amygdala) {
+		if lexicon != nil {
+			a.lexicon = lexicon
+		}
+	}
+}
+
+// WithCharacter はキャラクター別の辞書レイヤーの名前を設定する
+func WithCharacter(character string) Option {
+	return func(a *Amygdala) {
+		a.character = character
+	}
 }
 
 // New は新しい Amygdala インスタンスを作成
-func New() (*Amygdala, error) {
-	t, err := tokenizer.New(ipa.Dict(), tokenizer.OmitBosEos())
+func New(opts ...Option) (*Amygdala, error) {
+	analyzers, err := language.NewAnalyzers()
 	if err != nil {
 		return nil, err
 	}
 
-	return &Amygdala{
-		tokenizer: t,
-		dict:      initializeDictionary(),
-	}, nil
+	a := &Amygdala{analyzers: analyzers}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.lexicon == nil {
+		a.lexicon = NewLexicon()
+	}
+	return a, nil
+}
+
+// Assessment は扁桃体の評価結果
+// 感情コードごとの強度 (カテゴリー) と、入力全体の VAD (次元) の2つの表現で感情を表す
+type Assessment struct {
+	Emotions []models.EmotionValue   // 感情コードごとの強度 (強度の降順。何もヒットしない場合は Neutral)
+	Affect   models.Affect           // 入力全体の VAD (各語の VAD の合計を -1.0 〜 1.0 に収めたもの)
+	Language models.Language         // 評価に使った言語
+	Signals  []models.AffectSignal   // 語彙以外の感情の手がかり (絵文字・顔文字・記号の繰り返しなど)
+	Trace    *models.AssessmentTrace // 評価の根拠 (ExplainIn の場合のみ)
+}
+
+// Assess は入力テキストから反射的な感情を評価 (言語はテキストから判定する)
+func (a *Amygdala) Assess(text string) Assessment {
+	return a.AssessIn(text, language.Detect(text))
+}
+
+// AssessIn は指定した言語として入力テキストから反射的な感情を評価
+// 1. 形態素解析の前に、絵文字・顔文字・記号の繰り返しなどの手がかりを抽出する (extractSignals)
+// 2. トークン単位でその言語の辞書とマッチングを行い、否定・程度副詞・ぼかし表現による修飾を反映して感情値と VAD を累積させる
+// 3. 手がかりの感情・覚醒度を加え、強調表現 (！！！, すごーーい) で全体の強度を強める
+// 例: 「すごく嬉しい」→ Joy を強める、「嬉しくない」→ Grief に反転、「全然怖くない」→ Fear を打ち消す
+// (英語も同様に "so happy", "not happy", "not scared at all")
+func (a *Amygdala) AssessIn(text string, lang models.Language) Assessment {
+	return a.assess(text, lang, false)
+}
+
+// ExplainIn は AssessIn と同じ評価を行い、評価の根拠 (Trace) を付けて返す
+// 【役割】反応が不自然な時に、どのトークンがどの辞書の語と一致し、どの修飾・手がかりで強度が変わったのかを追跡する
+func (a *Amygdala) ExplainIn(text string, lang models.Language) Assessment {
+	return a.assess(text, lang, true)
 }
 
-// Assess は入力テキストから反射的な感情を評価
-// トークン単位で辞書マッチングを行い、感情値を累積させる
-func (a *Amygdala) Assess(text string) []models.EmotionValue {
-	// Kagomeでトークン化
-	tokens := a.tokenizer.Tokenize(text)
+// assess は評価の本体 (explain なら根拠を記録する)
+func (a *Amygdala) assess(text string, lang models.Language, explain bool) Assessment {
+	analyzer := a.analyzers.For(lang)
+	lang = analyzer.Language()
+
+	signals, rest := extractSignals(text)
+	morphemes := newMorphemes(analyzer.Tokenize(rest))
 	emotionMap := make(models.EmotionMap)
+	var affect models.Affect
 	hit := false
 
-	slog.Debug("Amygdala Assessment", "text", text, "tokens_count", len(tokens))
+	slog.Debug("Amygdala Assessment", "text", text, "language", lang, "tokens_count", len(morphemes))
+
+	// 評価中に辞書が差し替えられても、1回の評価では同じ辞書を参照する
+	dict := a.lexicon.dictionary()
 
-	for _, token := range tokens {
-		if token.Class == tokenizer.DUMMY {
+	// 表層形 (Surface) と 基本形 (BaseForm) で照合 (表層形を優先)
+	hits := make([][]LexiconEntry, len(morphemes))
+	forms := make([]models.MatchForm, len(morphemes))
+	for i, m := range morphemes {
+		hits[i], forms[i] = dict.lookup(lang, a.character, m.surface, m.baseForm, m.features)
+	}
+
+	// 感情語ごとに修飾の範囲を解析する
+	// 他の感情語を修飾している語 (「すごく嬉しい」の「すごく」) は、それ自体の感情を評価しない
+	scopes := make([]scope, len(morphemes))
+	modifier := make([]bool, len(morphemes))
+	for i := range morphemes {
+		if len(hits[i]) == 0 {
 			continue
 		}
+		scopes[i] = scopeAnalyzers[lang](morphemes, i)
+		for _, j := range scopes[i].modifiers {
+			modifier[j] = true
+		}
+	}
 
-		// 表層形 (Surface) と 基本形 (BaseForm) を取得
-		surface := token.Surface
-		baseForm := extractBaseForm(token)
+	var tr *tracer
+	if explain {
+		tr = newTracer(lang, a.character, morphemes, modifier)
+	}
 
-		// 1. 表層形で検索
-		if val, ok := a.dict[surface]; ok {
+	for i, m := range morphemes {
+		if modifier[i] {
+			for _, e := range hits[i] {
+				tr.match(morphemes, i, e, forms[i], scopes[i], nil, true)
+			}
+			continue
+		}
+		for _, e := range hits[i] {
+			val, wordAffect, ok := scopes[i].apply(e)
+			if !ok {
+				slog.Debug("Emotion Suppressed", "token", m.surface, "word", e.Word, "negations", scopes[i].negations)
+				tr.match(morphemes, i, e, forms[i], scopes[i], nil, false)
+				continue
+			}
+			tr.match(morphemes, i, e, forms[i], scopes[i], &val, false)
 			updateEmotionMap(emotionMap, val)
+			affect = affect.Add(wordAffect)
 			hit = true
-			slog.Debug("Emotion Hit (Surface)", "token", surface, "value", val)
-			continue
+			slog.Debug("Emotion Hit", "token", m.surface, "word", e.Word, "value", val,
+				"degree", scopes[i].degree, "hedge", scopes[i].hedge, "negations", scopes[i].negations)
 		}
+	}
 
-		// 2. 基本形で検索
-		if val, ok := a.dict[baseForm]; ok {
-			updateEmotionMap(emotionMap, val)
+	// 語彙以外の手がかり: 感情コードを加え、強調表現の倍率は入力全体に掛ける
+	emphasis := 1.0
+	var arousal float64
+	for _, s := range signals {
+		if s.Emotion != nil {
+			updateEmotionMap(emotionMap, *s.Emotion)
+			affect = affect.Add(models.AffectOf(s.Emotion.Code, s.Emotion.Value))
 			hit = true
-			slog.Debug("Emotion Hit (Base)", "token", baseForm, "value", val)
 		}
+		if s.Emphasis > 0 {
+			emphasis *= s.Emphasis
+		}
+		arousal += s.Arousal
+		tr.signal(s)
+		slog.Debug("Affect Signal", "kind", s.Kind, "text", s.Text, "emotion", s.Emotion, "arousal", s.Arousal, "emphasis", s.Emphasis)
 	}
+	emphasis = math.Min(emphasis, maxEmphasis)
+	affect = affect.Scale(emphasis).Add(models.Affect{Arousal: arousal})
 
-	// 何もヒットしない場合は Neutral
+	// 何もヒットしない場合は Neutral (VAD は手がかりの覚醒度のみ。手がかりもなければ原点)
 	if !hit {
-		return []models.EmotionValue{
-			{Code: models.EmotionNeutral, Value: 10},
+		assessment := Assessment{
+			Emotions: []models.EmotionValue{
+				{Code: models.EmotionNeutral, Value: 10},
+			},
+			Affect:   affect.Clamp(),
+			Language: lang,
+			Signals:  signals,
 		}
+		assessment.Trace = tr.finish(emphasis, assessment)
+		return assessment
 	}
 
-	// ソートして返す
+	// 強調して、ソートして返す
+	tr.sums(emotionMap)
+	for code, value := range emotionMap {
+		emotionMap[code] = min(int(math.Round(float64(value)*emphasis)), 100)
+	}
 	result := emotionMap.ToEmotionValues()
 	sort.Slice(result, func(i, j int) bool {
 		return result[i].Value > result[j].Value
 	})
 
-	return result
-}
-
-// extractBaseForm はトークンから基本形を抽出するヘルパー
-func extractBaseForm(token tokenizer.Token) string {
-	features := token.Features()
-	if len(features) > 6 && features[6] != "*" {
-		return features[6]
-	}
-	return token.Surface
+	assessment := Assessment{Emotions: result, Affect: affect.Clamp(), Language: lang, Signals: signals}
+	assessment.Trace = tr.finish(emphasis, assessment)
+	return assessment
 }
 
 // updateEmotionMap は感情マップを更新（加算）する
@@ -99,7 +207,7 @@ func updateEmotionMap(em models.EmotionMap, ev models.EmotionValue) {
 	em[ev.Code] = newValue
 }
 
-// initializeDictionary は感情辞書を初期化
+// initializeDictionary は組み込みの日本語の基本辞書を返す (基本辞書のファイルを指定しない場合に使う)
 func initializeDictionary() map[string]models.EmotionValue {
 	return map[string]models.EmotionValue{
 		// Joy (喜び)
@@ -144,7 +252,6 @@ func initializeDictionary() map[string]models.EmotionValue {
 		"えっ":   {Code: models.EmotionSurprise, Value: 60},
 		"すごい":  {Code: models.EmotionSurprise, Value: 70},
 		"まさか":  {Code: models.EmotionSurprise, Value: 80},
-		"！？":   {Code: models.EmotionSurprise, Value: 75},
 		"びっくり": {Code: models.EmotionSurprise, Value: 80},
 
 		// Fear (恐れ)
@@ -15,10 +15,10 @@ func TestNew(t *testing.T) {
 	if a == nil {
 		t.Fatal("New() returned nil")
 	}
-	if a.dict == nil {
-		t.Fatal("dict not initialized")
+	if a.lexicon == nil {
+		t.Fatal("lexicon not initialized")
 	}
-	if len(a.dict) == 0 {
+	if len(a.lexicon.dictionary().shared.surface) == 0 {
 		t.Fatal("dict is empty")
 	}
 }
@@ -54,7 +54,7 @@ func TestAssess_PositiveEmotions(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			emotions := a.Assess(tt.text)
+			emotions := a.Assess(tt.text).Emotions
 			if len(emotions) == 0 {
 				t.Fatal("No emotions returned")
 			}
@@ -91,7 +91,7 @@ func TestAssess_NegativeEmotions(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			emotions := a.Assess(tt.text)
+			emotions := a.Assess(tt.text).Emotions
 			if len(emotions) == 0 {
 				t.Fatal("No emotions returned")
 			}
@@ -123,7 +123,7 @@ func TestAssess_Neutral(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			emotions := a.Assess(tt.text)
+			emotions := a.Assess(tt.text).Emotions
 			if len(emotions) == 0 {
 				t.Fatal("No emotions returned")
 			}
@@ -147,7 +147,7 @@ func TestAssess_MultipleKeywords(t *testing.T) {
 	}
 
 	text := "バグ 最高" // 文脈依存を避けるためスペース区切り
-	emotions := a.Assess(text)
+	emotions := a.Assess(text).Emotions
 
 	if len(emotions) == 0 {
 		t.Fatal("No emotions returned")
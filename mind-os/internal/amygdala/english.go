@@ -0,0 +1,216 @@
+// english.go: 英語の入力に対する扁桃体の反応 (組み込みの英語の感情辞書と、否定・程度副詞・ぼかし表現の解析)
+package amygdala
+
+import (
+	"github.com/umekku/mind-os/internal/language"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// englishModifiers は英語の感情語の前に置かれる修飾語 (キー: 見出し語)
+// 英語の程度副詞・ぼかし表現は品詞の曖昧さが少ないため、品詞の制約は使わない
+var englishModifiers = map[string]preModifier{
+	// 程度副詞 (強める)
+	"very":       {kind: modDegree, factor: 1.5},
+	"so":         {kind: modDegree, factor: 1.5},
+	"really":     {kind: modDegree, factor: 1.3},
+	"extremely":  {kind: modDegree, factor: 1.6},
+	"incredibly": {kind: modDegree, factor: 1.6},
+	"super":      {kind: modDegree, factor: 1.5},
+	"totally":    {kind: modDegree, factor: 1.4},
+	"absolutely": {kind: modDegree, factor: 1.5},
+	"truly":      {kind: modDegree, factor: 1.3},
+	"too":        {kind: modDegree, factor: 1.3},
+	"quite":      {kind: modDegree, factor: 1.2},
+	"pretty":     {kind: modDegree, factor: 1.2},
+
+	// 程度副詞 (弱める)
+	"slightly": {kind: modDegree, factor: 0.6},
+	"somewhat": {kind: modDegree, factor: 0.7},
+	"kinda":    {kind: modDegree, factor: 0.7},
+	"bit":      {kind: modDegree, factor: 0.6}, // a bit
+	"little":   {kind: modDegree, factor: 0.6}, // a little
+
+	// ぼかし表現
+	"maybe":      {kind: modHedge, factor: hedgeConjecture},
+	"perhaps":    {kind: modHedge, factor: hedgeConjecture},
+	"probably":   {kind: modHedge, factor: hedgeConjecture},
+	"possibly":   {kind: modHedge, factor: hedgeConjecture},
+	"apparently": {kind: modHedge, factor: hedgeConjecture},
+	"might":      {kind: modHedge, factor: hedgeConjecture},
+	"may":        {kind: modHedge, factor: hedgeConjecture},
+	"seem":       {kind: modHedge, factor: hedgeConjecture},
+	"think":      {kind: modHedge, factor: hedgeOpinion},
+	"guess":      {kind: modHedge, factor: hedgeOpinion},
+	"suppose":    {kind: modHedge, factor: hedgeOpinion},
+}
+
+// englishNegations は英語の否定語と否定の強さ (キー: 見出し語。don't / isn't の n't は not)
+var englishNegations = map[string]negationEffect{
+	"not":     plainNegation,
+	"no":      plainNegation, // no fear, no worries
+	"without": plainNegation,
+	"never":   emphaticNegation,
+	"hardly":  partialNegation,
+	"barely":  partialNegation,
+}
+
+// englishTransparent は感情語と修飾語の間に入っても修飾の範囲を切らない品詞
+// 例: I'm 「not」 happy (be動詞), I do「n't」 feel scared (助動詞・動詞), it is not 「that」 scary
+var englishTransparent = []string{
+	language.POSAuxiliary, language.POSParticle, language.POSPronoun, language.POSDeterminer, language.POSAdverb,
+}
+
+// englishLinkingVerbs は感情語と修飾語の間に入っても修飾の範囲を切らない動詞 (見出し語)
+var englishLinkingVerbs = map[string]bool{
+	"feel":   true,
+	"get":    true,
+	"become": true,
+	"look":   true,
+	"sound":  true,
+}
+
+// analyzeScopeEnglish は英語の感情語 (位置 head) の修飾を解析する
+// 【処理内容】
+// 1. 直前から節の始まり (句読点・接続詞・他の内容語) までの否定・程度副詞・ぼかし表現を集める
+// 2. 否定と感情語の間の強めの程度副詞は部分否定にする (not very happy は「あまり嬉しくない」)
+// 3. 否定の後の at all は全否定にする (not scared at all)
+func analyzeScopeEnglish(morphemes []morpheme, head int) scope {
+	s := scope{degree: 1, hedge: 1, negation: plainNegation}
+	intensified := false                // 否定より感情語に近い位置に強めの程度副詞がある
+	atAll := isAtAll(morphemes, head+1) // not scared at all
+	if atAll {
+		s.markers = append(s.markers, head+1, head+2)
+	}
+
+	for i := head - 1; i >= 0; i-- {
+		m := morphemes[i]
+		if mod, ok := englishModifiers[m.baseForm]; ok {
+			switch mod.kind {
+			case modDegree:
+				s.degree *= mod.factor
+				intensified = intensified || mod.factor > 1
+			case modHedge:
+				s.hedge *= mod.factor
+			}
+			s.modifiers = append(s.modifiers, i)
+			continue
+		}
+		if effect, ok := englishNegations[m.baseForm]; ok {
+			s.negations++
+			s.markers = append(s.markers, i)
+			s.negation = effect
+			if intensified && effect == plainNegation {
+				s.negation = partialNegation
+				s.degree = 1
+			}
+			continue
+		}
+		if isAtAll(morphemes, i-1) {
+			// not at all scared
+			atAll = true
+			s.markers = append(s.markers, i-1, i)
+			i--
+			continue
+		}
+		if !isEnglishTransparent(m) {
+			break
+		}
+	}
+
+	if atAll && s.negations%2 == 1 {
+		s.negation = emphaticNegation
+	}
+	return s
+}
+
+// isAtAll は位置 i から "at all" が続くか判定
+func isAtAll(morphemes []morpheme, i int) bool {
+	return i >= 0 && i+1 < len(morphemes) && morphemes[i].baseForm == "at" && morphemes[i+1].baseForm == "all"
+}
+
+// isEnglishTransparent は修飾の範囲を切らない語か判定
+func isEnglishTransparent(m morpheme) bool {
+	for _, pos := range englishTransparent {
+		if m.is(pos) {
+			return true
+		}
+	}
+	return m.is(language.POSVerb) && englishLinkingVerbs[m.baseForm]
+}
+
+// initializeEnglishDictionary は組み込みの英語の基本辞書を返す (基本辞書のファイルを指定しない場合に使う)
+// 語形変化 (loved, worries) は見出し語で照合するため、見出し語のみを載せる
+func initializeEnglishDictionary() map[string]models.EmotionValue {
+	return map[string]models.EmotionValue{
+		// Joy (喜び)
+		"happy":     {Code: models.EmotionJoy, Value: 85},
+		"glad":      {Code: models.EmotionJoy, Value: 75},
+		"great":     {Code: models.EmotionJoy, Value: 75},
+		"awesome":   {Code: models.EmotionJoy, Value: 85},
+		"best":      {Code: models.EmotionJoy, Value: 90},
+		"fun":       {Code: models.EmotionJoy, Value: 80},
+		"enjoy":     {Code: models.EmotionJoy, Value: 75},
+		"good":      {Code: models.EmotionJoy, Value: 50},
+		"nice":      {Code: models.EmotionJoy, Value: 60},
+		"yay":       {Code: models.EmotionJoy, Value: 80},
+		"delicious": {Code: models.EmotionJoy, Value: 85}, // 食事関連
+		"yummy":     {Code: models.EmotionJoy, Value: 80},
+
+		// Love (信頼/愛)
+		"love":     {Code: models.EmotionLove, Value: 90},
+		"trust":    {Code: models.EmotionLove, Value: 80},
+		"buddy":    {Code: models.EmotionLove, Value: 85},
+		"together": {Code: models.EmotionLove, Value: 60},
+		"hug":      {Code: models.EmotionLove, Value: 65},
+		"thanks":   {Code: models.EmotionLove, Value: 60},
+
+		// Anger (怒り)
+		"stupid":  {Code: models.EmotionAnger, Value: 80},
+		"idiot":   {Code: models.EmotionAnger, Value: 80},
+		"annoy":   {Code: models.EmotionAnger, Value: 70},
+		"hate":    {Code: models.EmotionAnger, Value: 80},
+		"damn":    {Code: models.EmotionAnger, Value: 85},
+		"angry":   {Code: models.EmotionAnger, Value: 90},
+		"furious": {Code: models.EmotionAnger, Value: 90},
+
+		// Sadness (悲しみ)
+		"sad":      {Code: models.EmotionSadness, Value: 80},
+		"hurt":     {Code: models.EmotionSadness, Value: 85},
+		"cry":      {Code: models.EmotionSadness, Value: 70},
+		"bad":      {Code: models.EmotionSadness, Value: 60},
+		"lonely":   {Code: models.EmotionSadness, Value: 75},
+		"worst":    {Code: models.EmotionSadness, Value: 90},
+		"sorry":    {Code: models.EmotionSadness, Value: 50}, // 罪悪感としての悲しみ
+		"hopeless": {Code: models.EmotionSadness, Value: 80},
+
+		// Surprise (驚き)
+		"wow":      {Code: models.EmotionSurprise, Value: 70},
+		"whoa":     {Code: models.EmotionSurprise, Value: 70},
+		"amazing":  {Code: models.EmotionSurprise, Value: 70},
+		"surprise": {Code: models.EmotionSurprise, Value: 80},
+		"omg":      {Code: models.EmotionSurprise, Value: 75},
+
+		// Fear (恐れ)
+		"scary":   {Code: models.EmotionFear, Value: 85},
+		"scare":   {Code: models.EmotionFear, Value: 80},
+		"afraid":  {Code: models.EmotionFear, Value: 80},
+		"worry":   {Code: models.EmotionFear, Value: 60},
+		"anxious": {Code: models.EmotionFear, Value: 65},
+		"warning": {Code: models.EmotionFear, Value: 75},
+		"error":   {Code: models.EmotionFear, Value: 65},
+		"bug":     {Code: models.EmotionFear, Value: 80},
+
+		// Disgust (嫌悪)
+		"bitter":     {Code: models.EmotionDisgust, Value: 70},
+		"disgusting": {Code: models.EmotionDisgust, Value: 90},
+		"gross":      {Code: models.EmotionDisgust, Value: 85},
+		"yuck":       {Code: models.EmotionDisgust, Value: 80},
+		"creepy":     {Code: models.EmotionDisgust, Value: 90},
+		"dirty":      {Code: models.EmotionDisgust, Value: 85},
+
+		// Hope (希望)
+		"hope":    {Code: models.EmotionHope, Value: 70},
+		"wish":    {Code: models.EmotionHope, Value: 60},
+		"excited": {Code: models.EmotionHope, Value: 75},
+	}
+}
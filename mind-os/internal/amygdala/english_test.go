@@ -0,0 +1,74 @@
+package amygdala
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// 組み込みの英語の辞書の強度: happy J85, love L90, hate A80, bug F80, scary F85, scare F80, worry F60, sad G80
+
+func TestAssess_English(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want models.EmotionMap
+	}{
+		{"見出し語", "I'm happy", models.EmotionMap{models.EmotionJoy: 85}},
+		{"過去形", "I loved it", models.EmotionMap{models.EmotionLove: 90}},
+		{"三人称単数・複数形", "She hates bugs", models.EmotionMap{models.EmotionAnger: 80, models.EmotionFear: 80}},
+		{"過去分詞", "I was worried", models.EmotionMap{models.EmotionFear: 60}},
+		// 否定
+		{"not", "I'm not happy", models.EmotionMap{models.EmotionGrief: 51}},
+		{"don't + 連結動詞", "I don't feel scared", models.EmotionMap{models.EmotionFear: 16}},
+		{"never", "I'm never scared", models.EmotionMap{models.EmotionNeutral: 10}},
+		{"at all (後置)", "I'm not scared at all", models.EmotionMap{models.EmotionNeutral: 10}},
+		{"at all (前置)", "not at all scared", models.EmotionMap{models.EmotionNeutral: 10}},
+		{"部分否定", "I'm not very happy", models.EmotionMap{models.EmotionGrief: 26}},
+		{"hardly", "it's hardly scary", models.EmotionMap{models.EmotionFear: 34}},
+		// 程度副詞・ぼかし表現
+		{"so", "I am so happy!", models.EmotionMap{models.EmotionJoy: 100}},
+		{"a bit", "I'm a bit worried", models.EmotionMap{models.EmotionFear: 36}},
+		{"maybe", "maybe I'm sad", models.EmotionMap{models.EmotionGrief: 56}},
+		{"I think", "I think I'm sad", models.EmotionMap{models.EmotionGrief: 64}},
+		// 接続詞で修飾の範囲が切れる
+		{"節の区切り", "not happy but excited", models.EmotionMap{models.EmotionGrief: 51, models.EmotionHope: 75}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.text, func(t *testing.T) {
+			if got := assessCodes(t, a, tt.text); !maps.Equal(got, tt.want) {
+				t.Errorf("Assess(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssessIn_Language(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := a.Assess("I'm happy").Language; got != models.LanguageEnglish {
+		t.Errorf("Assess(English).Language = %q, want en", got)
+	}
+	if got := a.Assess("嬉しい").Language; got != models.LanguageJapanese {
+		t.Errorf("Assess(Japanese).Language = %q, want ja", got)
+	}
+
+	// 言語を指定すると、その言語の辞書のみで照合する
+	if got := a.AssessIn("happy", models.LanguageJapanese); len(got.Emotions) != 1 || got.Emotions[0].Code != models.EmotionNeutral {
+		t.Errorf("AssessIn(happy, ja) = %v, want neutral", got.Emotions)
+	}
+	// 対応していない言語は既定の言語として解析する
+	if got := a.AssessIn("嬉しい", "fr"); got.Language != models.LanguageJapanese || got.Emotions[0].Code != models.EmotionJoy {
+		t.Errorf("AssessIn(嬉しい, fr) = %+v, want Japanese joy", got)
+	}
+}
@@ -0,0 +1,150 @@
+// explain.go: 扁桃体の評価の根拠 (トークン・辞書の一致・修飾・感情コードごとの寄与) の記録
+package amygdala
+
+import (
+	"sort"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// tracer は評価の根拠を記録する
+// nil の場合は何も記録しないため、評価の処理は根拠が不要な場合も同じ手順で呼び出せる
+type tracer struct {
+	trace   models.AssessmentTrace
+	sources map[models.EmotionCode][]models.ContributionSource
+}
+
+// newTracer は形態素解析の結果 (修飾語の位置を含む) から記録を始める
+func newTracer(lang models.Language, character string, morphemes []morpheme, modifier []bool) *tracer {
+	tokens := make([]models.TokenTrace, len(morphemes))
+	for i, m := range morphemes {
+		tokens[i] = models.TokenTrace{
+			Surface:  m.surface,
+			BaseForm: m.baseForm,
+			POS:      m.pos(),
+			Modifier: modifier[i],
+		}
+	}
+	return &tracer{
+		trace: models.AssessmentTrace{
+			Language:  lang,
+			Tokens:    tokens,
+			Matches:   []models.LexiconMatch{},
+			Character: character,
+		},
+		sources: make(map[models.EmotionCode][]models.ContributionSource),
+	}
+}
+
+// match は辞書の語との一致と修飾の結果を記録する (result が nil なら打ち消し、skipped なら修飾語として評価しなかった)
+func (t *tracer) match(morphemes []morpheme, i int, e LexiconEntry, form models.MatchForm, s scope, result *models.EmotionValue, skipped bool) {
+	if t == nil {
+		return
+	}
+	m := models.LexiconMatch{
+		Token:     i,
+		Word:      e.Word,
+		MatchedBy: form,
+		Entry:     models.EmotionValue{Code: e.Emotion, Value: e.Intensity},
+		Skipped:   skipped,
+	}
+	if !skipped {
+		m.Modifiers = s.words(morphemes)
+		m.Degree, m.Hedge, m.Negations, m.Negation = s.degree, s.hedge, s.negations, s.negationName()
+		m.Result = result
+	}
+	t.trace.Matches = append(t.trace.Matches, m)
+	if result != nil {
+		t.contribute(result.Code, "lexicon", morphemes[i].surface, result.Value)
+	}
+}
+
+// signal は語彙以外の手がかりを記録する
+func (t *tracer) signal(s models.AffectSignal) {
+	if t == nil {
+		return
+	}
+	t.trace.Signals = append(t.trace.Signals, s)
+	if s.Emotion != nil {
+		t.contribute(s.Emotion.Code, string(s.Kind), s.Text, s.Emotion.Value)
+	}
+}
+
+func (t *tracer) contribute(code models.EmotionCode, kind, text string, value int) {
+	t.sources[code] = append(t.sources[code], models.ContributionSource{Kind: kind, Text: text, Value: value})
+}
+
+// sums は強調表現を反映する前の感情コードごとの合計を記録する
+func (t *tracer) sums(emotionMap models.EmotionMap) {
+	if t == nil {
+		return
+	}
+	t.trace.Contributions = make([]models.EmotionContribution, 0, len(emotionMap))
+	for code, sum := range emotionMap {
+		t.trace.Contributions = append(t.trace.Contributions, models.EmotionContribution{
+			Code:    code,
+			Sources: t.sources[code],
+			Sum:     sum,
+		})
+	}
+}
+
+// finish は評価結果を記録して根拠を返す
+func (t *tracer) finish(emphasis float64, assessment Assessment) *models.AssessmentTrace {
+	if t == nil {
+		return nil
+	}
+	final := make(map[models.EmotionCode]int, len(assessment.Emotions))
+	for _, e := range assessment.Emotions {
+		final[e.Code] = e.Value
+	}
+	if t.trace.Contributions == nil {
+		t.trace.Contributions = []models.EmotionContribution{}
+	}
+	for i := range t.trace.Contributions {
+		t.trace.Contributions[i].Value = final[t.trace.Contributions[i].Code]
+	}
+	sort.Slice(t.trace.Contributions, func(i, j int) bool {
+		return t.trace.Contributions[i].Value > t.trace.Contributions[j].Value
+	})
+
+	t.trace.Emphasis = emphasis
+	t.trace.Affect = assessment.Affect
+	t.trace.Emotions = assessment.Emotions
+	return &t.trace
+}
+
+// pos は品詞 (素性の先頭) を返す
+func (m morpheme) pos() string {
+	if len(m.features) > 0 {
+		return m.features[0]
+	}
+	return ""
+}
+
+// words は修飾に関わった語を入力中の順に返す
+func (s scope) words(morphemes []morpheme) []string {
+	positions := append(append([]int{}, s.modifiers...), s.markers...)
+	sort.Ints(positions)
+	words := make([]string, 0, len(positions))
+	for _, i := range positions {
+		words = append(words, morphemes[i].surface)
+	}
+	return words
+}
+
+// negationName は否定の強さの名前を返す (否定がなければ空)
+func (s scope) negationName() string {
+	switch {
+	case s.negations == 0:
+		return ""
+	case s.negations%2 == 0:
+		return "litotes"
+	case s.negation == emphaticNegation:
+		return "emphatic"
+	case s.negation == partialNegation:
+		return "partial"
+	default:
+		return "plain"
+	}
+}
@@ -0,0 +1,126 @@
+package amygdala
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+func TestExplainIn(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 根拠は ExplainIn の場合のみ記録する
+	if got := a.AssessIn("すごく嬉しい", models.LanguageJapanese); got.Trace != nil {
+		t.Errorf("AssessIn().Trace = %+v, want nil", got.Trace)
+	}
+
+	got := a.ExplainIn("すごく嬉しい", models.LanguageJapanese)
+	trace := got.Trace
+	if trace == nil {
+		t.Fatal("ExplainIn().Trace = nil")
+	}
+	if len(trace.Tokens) != 2 || !trace.Tokens[0].Modifier || trace.Tokens[1].Modifier {
+		t.Fatalf("Tokens = %+v, want [すごく (modifier), 嬉しい]", trace.Tokens)
+	}
+
+	// 修飾語の「すごく」(すごい S70) は評価しない
+	if len(trace.Matches) != 2 {
+		t.Fatalf("Matches = %+v, want 2", trace.Matches)
+	}
+	skipped := trace.Matches[0]
+	if !skipped.Skipped || skipped.Word != "すごい" || skipped.MatchedBy != models.MatchBaseForm || skipped.Result != nil {
+		t.Errorf("Matches[0] = %+v, want skipped すごい matched by base form", skipped)
+	}
+	hit := trace.Matches[1]
+	if hit.Token != 1 || hit.MatchedBy != models.MatchSurface || hit.Degree != 1.5 || !slices.Equal(hit.Modifiers, []string{"すごく"}) {
+		t.Errorf("Matches[1] = %+v, want 嬉しい matched by surface with degree 1.5", hit)
+	}
+	if hit.Result == nil || *hit.Result != (models.EmotionValue{Code: models.EmotionJoy, Value: 100}) {
+		t.Errorf("Matches[1].Result = %v, want J100", hit.Result)
+	}
+
+	// 根拠の結果は評価結果と一致する
+	if !slices.Equal(trace.Emotions, got.Emotions) || trace.Affect != got.Affect {
+		t.Errorf("trace result = %v %+v, want %v %+v", trace.Emotions, trace.Affect, got.Emotions, got.Affect)
+	}
+}
+
+func TestExplainIn_Negation(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		text          string
+		lang          models.Language
+		wantNegation  string
+		wantModifiers []string
+		wantResult    *models.EmotionValue
+	}{
+		{"否定", "嬉しくない", models.LanguageJapanese, "plain", []string{"ない"}, &models.EmotionValue{Code: models.EmotionGrief, Value: 51}},
+		{"全否定で打ち消し", "全然怖くない", models.LanguageJapanese, "emphatic", []string{"全然", "ない"}, nil},
+		{"ぼかし表現", "楽しいかも", models.LanguageJapanese, "", []string{"かも"}, &models.EmotionValue{Code: models.EmotionJoy, Value: 56}},
+		{"英語の at all", "I'm not scared at all", models.LanguageEnglish, "emphatic", []string{"not", "at", "all"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.text, func(t *testing.T) {
+			trace := a.ExplainIn(tt.text, tt.lang).Trace
+			if len(trace.Matches) != 1 {
+				t.Fatalf("Matches = %+v, want 1", trace.Matches)
+			}
+			m := trace.Matches[0]
+			if m.Negation != tt.wantNegation || !slices.Equal(m.Modifiers, tt.wantModifiers) {
+				t.Errorf("match = %+v, want negation %q, modifiers %v", m, tt.wantNegation, tt.wantModifiers)
+			}
+			switch {
+			case tt.wantResult == nil && m.Result != nil:
+				t.Errorf("Result = %v, want suppressed", *m.Result)
+			case tt.wantResult != nil && (m.Result == nil || *m.Result != *tt.wantResult):
+				t.Errorf("Result = %v, want %v", m.Result, *tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestExplainIn_Contributions(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 最高 J90 + 😂 J75 (上限 100)、！！！ で 1.2 倍
+	trace := a.ExplainIn("最高😂！！！", models.LanguageJapanese).Trace
+	if trace.Emphasis != 1.2 || len(trace.Signals) != 2 {
+		t.Errorf("Emphasis = %v, Signals = %+v, want 1.2 and 2 signals", trace.Emphasis, trace.Signals)
+	}
+	if len(trace.Contributions) != 1 {
+		t.Fatalf("Contributions = %+v, want only Joy", trace.Contributions)
+	}
+	joy := trace.Contributions[0]
+	want := []models.ContributionSource{
+		{Kind: "lexicon", Text: "最高", Value: 90},
+		{Kind: string(models.AffectSignalEmoji), Text: "😂", Value: 75},
+	}
+	if joy.Code != models.EmotionJoy || !slices.Equal(joy.Sources, want) || joy.Sum != 100 || joy.Value != 100 {
+		t.Errorf("Contributions[0] = %+v, want Joy from %v", joy, want)
+	}
+
+	// 強調表現の倍率は合計に掛かる
+	trace = a.ExplainIn("This is great!!!", models.LanguageEnglish).Trace
+	if len(trace.Contributions) != 1 || trace.Contributions[0].Sum != 75 || trace.Contributions[0].Value != 90 {
+		t.Errorf("Contributions = %+v, want Joy sum 75, value 90", trace.Contributions)
+	}
+
+	// 何も反応しない場合は内訳が空
+	trace = a.ExplainIn("こんにちは", models.LanguageJapanese).Trace
+	if len(trace.Matches) != 0 || len(trace.Contributions) != 0 || trace.Emotions[0].Code != models.EmotionNeutral {
+		t.Errorf("trace = %+v, want no matches and neutral", trace)
+	}
+}
@@ -0,0 +1,645 @@
+package amygdala
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/umekku/mind-os/internal/language"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// Layer は感情辞書のレイヤー
+// 【構造】base (基本語彙) < domain (利用分野の語彙) < character (キャラクター固有の語彙) の順に重ね、
+// 上位のレイヤーで定義された語は下位のレイヤーの同じ語を置き換える
+type Layer string
+
+const (
+	LayerBase      Layer = "base"
+	LayerDomain    Layer = "domain"
+	LayerCharacter Layer = "character"
+)
+
+// 辞書操作のエラー
+var (
+	ErrInvalidLayer = errors.New("invalid lexicon layer: must be base, domain or character")
+	ErrWordNotFound = errors.New("word not found in lexicon layer")
+)
+
+// LexiconEntry は感情辞書の1語
+// 同じ語に複数の感情 (例: 「できた」→ Joy と Hope) を持たせる場合は複数のエントリを定義する
+type LexiconEntry struct {
+	Word      string             `json:"word"`               // 表層形
+	BaseForm  string             `json:"baseForm,omitempty"` // 基本形 (省略時は Word。活用形を基本形で照合する)
+	Emotion   models.EmotionCode `json:"emotion"`            // 感情コード
+	Intensity int                `json:"intensity"`          // 強度 (1-100)
+	POS       string             `json:"pos,omitempty"`      // 品詞の制約 ("名詞", "形容詞,自立", 英語は "ADJ" など。省略時は制約なし)
+	Affect    *models.Affect     `json:"affect,omitempty"`   // VAD 座標 (省略時は感情コードの代表点を強度で縮小した点)
+	Language  models.Language    `json:"language,omitempty"` // 語の言語 (省略時は Word の文字種から判定)
+}
+
+// Validate はエントリの値を検証
+func (e LexiconEntry) Validate() error {
+	if strings.TrimSpace(e.Word) == "" {
+		return errors.New("word is required")
+	}
+	if !models.IsValidEmotionCode(e.Emotion) {
+		return fmt.Errorf("unknown emotion code %q", e.Emotion)
+	}
+	if e.Intensity < 1 || e.Intensity > 100 {
+		return fmt.Errorf("intensity %d must be in [1, 100]", e.Intensity)
+	}
+	if e.Affect != nil {
+		if err := e.Affect.Validate(); err != nil {
+			return fmt.Errorf("affect: %w", err)
+		}
+	}
+	if e.Language != "" && !models.IsValidLanguage(e.Language) {
+		return fmt.Errorf("unsupported language %q", e.Language)
+	}
+	return nil
+}
+
+// ResolvedLanguage はエントリの言語を返す
+// 指定がない場合は語の文字種から判定する (「OK」のような英字の語を日本語の入力で照合するには ja を指定する)
+func (e LexiconEntry) ResolvedLanguage() models.Language {
+	if e.Language != "" {
+		return e.Language
+	}
+	return language.Detect(e.Word)
+}
+
+// affect はエントリの VAD 座標を返す
+func (e LexiconEntry) affect() models.Affect {
+	if e.Affect != nil {
+		return *e.Affect
+	}
+	return models.AffectOf(e.Emotion, e.Intensity)
+}
+
+// baseKey は基本形での照合に使うキー (英語は語形変化を除いた正規形)
+func (e LexiconEntry) baseKey() string {
+	key := e.Word
+	if e.BaseForm != "" {
+		key = e.BaseForm
+	}
+	return language.Fold(e.ResolvedLanguage(), key)
+}
+
+// matchesPOS はトークンの品詞情報が制約を満たすか判定
+// 制約はカンマ区切りで品詞・品詞細分類を先頭から指定する (IPA辞書の素性の並び)
+func (e LexiconEntry) matchesPOS(features []string) bool {
+	return hasPOS(features, e.POS)
+}
+
+// hasPOS は素性が品詞の指定 (カンマ区切りの前方一致) を満たすか判定 (空の指定は常に満たす)
+func hasPOS(features []string, pos string) bool {
+	if pos == "" {
+		return true
+	}
+	parts := strings.Split(pos, ",")
+	if len(parts) > len(features) {
+		return false
+	}
+	for i, part := range parts {
+		if part != features[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// LexiconSources は辞書ファイルの場所 (*.csv, *.tsv, *.json)
+type LexiconSources struct {
+	Base          string   // 基本辞書 (空の場合は組み込みの辞書)
+	Domain        []string // 分野別の辞書 (指定順に重ねる)
+	CharactersDir string   // キャラクター別の辞書のディレクトリ (ファイル名 = プロファイル名)
+}
+
+// layerKey はレイヤーの識別子 (character レイヤーはキャラクターごと)
+type layerKey struct {
+	layer     Layer
+	character string
+}
+
+// layerData は1つのレイヤーの語彙
+type layerData struct {
+	source    string                    // 読み込んだファイル (組み込み・実行時のみの場合は空)
+	files     map[string][]LexiconEntry // ファイルから読み込んだ語彙 (キー: 表層形)
+	overrides map[string][]LexiconEntry // 実行時の追加・削除 (空のスライスは削除を表す)
+}
+
+func newLayerData() *layerData {
+	return &layerData{
+		files:     make(map[string][]LexiconEntry),
+		overrides: make(map[string][]LexiconEntry),
+	}
+}
+
+// effective は実行時の変更を反映した語彙を返す (削除された語は空のスライス)
+func (l *layerData) effective() map[string][]LexiconEntry {
+	merged := maps.Clone(l.files)
+	maps.Copy(merged, l.overrides)
+	return merged
+}
+
+// dictionaryIndex は表層形・基本形による検索用の索引
+type dictionaryIndex struct {
+	surface map[string][]LexiconEntry
+	base    map[string][]LexiconEntry
+}
+
+// newDictionaryIndex は語彙から索引を作る (削除された語は両方の索引に空のエントリとして残し、下位レイヤーを隠す)
+// 索引のエントリには判定した言語を設定しておき、照合のたびに判定し直さない
+func newDictionaryIndex(words map[string][]LexiconEntry) *dictionaryIndex {
+	idx := &dictionaryIndex{
+		surface: make(map[string][]LexiconEntry, len(words)),
+		base:    make(map[string][]LexiconEntry, len(words)),
+	}
+	for word, entries := range words {
+		if len(entries) == 0 {
+			idx.surface[word] = nil
+			idx.base[LexiconEntry{Word: word}.baseKey()] = nil
+			continue
+		}
+		for _, e := range entries {
+			e.Language = e.ResolvedLanguage()
+			idx.surface[e.Word] = append(idx.surface[e.Word], e)
+			idx.base[e.baseKey()] = append(idx.base[e.baseKey()], e)
+		}
+	}
+	return idx
+}
+
+// dictionary は読み取り専用の辞書 (作成後は変更しないため、ロックなしで並行して参照できる)
+type dictionary struct {
+	shared     *dictionaryIndex            // base + domain
+	characters map[string]*dictionaryIndex // キャラクター別
+	loadedAt   time.Time
+}
+
+// lookup はトークンに一致するエントリと、一致した形 (表層形・基本形) を返す
+// 【処理内容】キャラクターのレイヤー → 共通のレイヤーの順に、表層形・基本形で照合する (入力と同じ言語の語のみ)
+// 上位のレイヤーで見つかった語は (品詞の制約を満たさなくても) 下位のレイヤーを参照しない
+func (d *dictionary) lookup(lang models.Language, character, surface, baseForm string, features []string) ([]LexiconEntry, models.MatchForm) {
+	indexes := make([]*dictionaryIndex, 0, 2)
+	if idx, ok := d.characters[character]; ok {
+		indexes = append(indexes, idx)
+	}
+	indexes = append(indexes, d.shared)
+
+	baseKey := language.Fold(lang, baseForm)
+	for _, idx := range indexes {
+		form := models.MatchSurface
+		entries, ok := idx.surface[surface]
+		if !ok {
+			form = models.MatchBaseForm
+			entries, ok = idx.base[baseKey]
+		}
+		if !ok {
+			continue
+		}
+
+		var matched []LexiconEntry
+		for _, e := range entries {
+			if e.Language == lang && e.matchesPOS(features) {
+				matched = append(matched, e)
+			}
+		}
+		return matched, form
+	}
+	return nil, ""
+}
+
+// Lexicon は扁桃体の感情辞書
+// 【役割】外部ファイルからの読み込み、実行時の語の追加・削除、再読み込みを行う
+// 【並行性】変更時は新しい辞書を丸ごと作成してから atomic に差し替えるため、
+// 並行する Assess は常に変更前か変更後のどちらか一方の完全な辞書を参照する
+type Lexicon struct {
+	mu      sync.Mutex // 読み込み・変更の直列化 (参照はロックを取らない)
+	sources LexiconSources
+	layers  map[layerKey]*layerData
+	current atomic.Pointer[dictionary]
+}
+
+// NewLexicon は組み込みの基本辞書のみを持つ Lexicon を作成
+func NewLexicon() *Lexicon {
+	l, _ := LoadLexicon(LexiconSources{})
+	return l
+}
+
+// LoadLexicon は辞書ファイルを読み込んで Lexicon を作成
+func LoadLexicon(sources LexiconSources) (*Lexicon, error) {
+	l := &Lexicon{sources: sources}
+	if _, err := l.Reload(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Reload は辞書ファイルを再読み込みし、辞書を差し替える
+// 実行時に追加・削除した語は再読み込み後も維持する。読み込みに失敗した場合は現在の辞書を使い続ける
+func (l *Lexicon) Reload() (LexiconStats, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	layers, err := readLayers(l.sources)
+	if err != nil {
+		return LexiconStats{}, err
+	}
+	for key, old := range l.layers {
+		if len(old.overrides) == 0 {
+			continue
+		}
+		data, ok := layers[key]
+		if !ok {
+			data = newLayerData()
+			layers[key] = data
+		}
+		data.overrides = old.overrides
+	}
+
+	l.layers = layers
+	l.publishLocked()
+	return l.statsLocked(), nil
+}
+
+// Add は語を追加する (同じ語・感情・品詞のエントリがあれば強度などを置き換える)
+// character レイヤーの場合は character でキャラクター (プロファイル名) を指定する
+func (l *Lexicon) Add(layer Layer, character string, entry LexiconEntry) error {
+	key, err := newLayerKey(layer, character)
+	if err != nil {
+		return err
+	}
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data := l.layerLocked(key)
+	entries := slices.Clone(data.effective()[entry.Word])
+	i := slices.IndexFunc(entries, func(e LexiconEntry) bool {
+		return e.Emotion == entry.Emotion && e.POS == entry.POS
+	})
+	if i >= 0 {
+		entries[i] = entry
+	} else {
+		entries = append(entries, entry)
+	}
+	data.overrides[entry.Word] = entries
+
+	l.publishLocked()
+	return nil
+}
+
+// Remove はレイヤーから語を削除する (下位のレイヤーに同じ語があれば、その語も照合されなくなる)
+// レイヤーに語がない場合は ErrWordNotFound
+func (l *Lexicon) Remove(layer Layer, character, word string) error {
+	key, err := newLayerKey(layer, character)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, ok := l.layers[key]
+	if !ok || len(data.effective()[word]) == 0 {
+		return fmt.Errorf("%w: %s", ErrWordNotFound, word)
+	}
+	data.overrides[word] = []LexiconEntry{}
+
+	l.publishLocked()
+	return nil
+}
+
+// Entries はレイヤーの語を表層形の順で返す (実行時の変更を反映済み)
+func (l *Lexicon) Entries(layer Layer, character string) ([]LexiconEntry, error) {
+	key, err := newLayerKey(layer, character)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, ok := l.layers[key]
+	if !ok {
+		return []LexiconEntry{}, nil
+	}
+	words := data.effective()
+	entries := make([]LexiconEntry, 0, len(words))
+	for _, word := range slices.Sorted(maps.Keys(words)) {
+		entries = append(entries, words[word]...)
+	}
+	return entries, nil
+}
+
+// LexiconStats は辞書の状態
+type LexiconStats struct {
+	LoadedAt time.Time    // 最後に辞書を差し替えた日時
+	Layers   []LayerStats // レイヤーごとの語数 (base, domain, character の順)
+}
+
+// LayerStats はレイヤーの語数
+type LayerStats struct {
+	Layer     Layer
+	Character string // character レイヤーのキャラクター
+	Source    string // 読み込んだファイル (組み込み・実行時のみの場合は空)
+	Words     int    // 語数 (実行時の変更を反映済み)
+	Overrides int    // 実行時に追加・削除された語数
+}
+
+// Stats は辞書の状態を返す
+func (l *Lexicon) Stats() LexiconStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.statsLocked()
+}
+
+// statsLocked は辞書の状態を返す (l.mu を保持した状態で呼ぶこと)
+func (l *Lexicon) statsLocked() LexiconStats {
+	keys := slices.SortedFunc(maps.Keys(l.layers), compareLayerKeys)
+
+	stats := LexiconStats{LoadedAt: l.current.Load().loadedAt, Layers: make([]LayerStats, 0, len(keys))}
+	for _, key := range keys {
+		data := l.layers[key]
+		words := 0
+		for _, entries := range data.effective() {
+			if len(entries) > 0 {
+				words++
+			}
+		}
+		stats.Layers = append(stats.Layers, LayerStats{
+			Layer:     key.layer,
+			Character: key.character,
+			Source:    data.source,
+			Words:     words,
+			Overrides: len(data.overrides),
+		})
+	}
+	return stats
+}
+
+// layerLocked はレイヤーを返す (なければ作成する。l.mu を保持した状態で呼ぶこと)
+func (l *Lexicon) layerLocked(key layerKey) *layerData {
+	data, ok := l.layers[key]
+	if !ok {
+		data = newLayerData()
+		l.layers[key] = data
+	}
+	return data
+}
+
+// publishLocked は現在のレイヤーから新しい辞書を作成して差し替える (l.mu を保持した状態で呼ぶこと)
+func (l *Lexicon) publishLocked() {
+	shared := make(map[string][]LexiconEntry)
+	characters := make(map[string]*dictionaryIndex)
+	for _, key := range slices.SortedFunc(maps.Keys(l.layers), compareLayerKeys) {
+		words := l.layers[key].effective()
+		if key.layer == LayerCharacter {
+			characters[key.character] = newDictionaryIndex(words)
+			continue
+		}
+		maps.Copy(shared, words)
+	}
+
+	l.current.Store(&dictionary{
+		shared:     newDictionaryIndex(shared),
+		characters: characters,
+		loadedAt:   time.Now(),
+	})
+}
+
+// dictionary は現在の辞書を返す
+func (l *Lexicon) dictionary() *dictionary {
+	return l.current.Load()
+}
+
+// newLayerKey はレイヤーの識別子を作成
+func newLayerKey(layer Layer, character string) (layerKey, error) {
+	switch layer {
+	case LayerBase, LayerDomain:
+		return layerKey{layer: layer}, nil
+	case LayerCharacter:
+		if character == "" {
+			return layerKey{}, errors.New("character is required for the character layer")
+		}
+		return layerKey{layer: layer, character: character}, nil
+	default:
+		return layerKey{}, ErrInvalidLayer
+	}
+}
+
+// layerOrder はレイヤーの重ね順
+var layerOrder = map[Layer]int{LayerBase: 0, LayerDomain: 1, LayerCharacter: 2}
+
+// compareLayerKeys はレイヤーを重ね順に並べる
+func compareLayerKeys(a, b layerKey) int {
+	if a.layer != b.layer {
+		return layerOrder[a.layer] - layerOrder[b.layer]
+	}
+	return strings.Compare(a.character, b.character)
+}
+
+// readLayers は辞書ファイルを全て読み込む
+func readLayers(sources LexiconSources) (map[layerKey]*layerData, error) {
+	layers := make(map[layerKey]*layerData)
+
+	base := newLayerData()
+	if sources.Base == "" {
+		for word, v := range initializeDictionary() {
+			base.files[word] = []LexiconEntry{{Word: word, Emotion: v.Code, Intensity: v.Value, Language: models.LanguageJapanese}}
+		}
+		for word, v := range initializeEnglishDictionary() {
+			base.files[word] = []LexiconEntry{{Word: word, Emotion: v.Code, Intensity: v.Value, Language: models.LanguageEnglish}}
+		}
+	} else if err := base.readFile(sources.Base); err != nil {
+		return nil, err
+	}
+	layers[layerKey{layer: LayerBase}] = base
+
+	// 分野別の辞書は指定順に重ねる (後のファイルの語が優先)
+	domain := newLayerData()
+	for _, path := range sources.Domain {
+		if err := domain.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+	domain.source = strings.Join(sources.Domain, ",")
+	layers[layerKey{layer: LayerDomain}] = domain
+
+	if sources.CharactersDir != "" {
+		files, err := os.ReadDir(sources.CharactersDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read lexicon characters directory: %w", err)
+		}
+		for _, f := range files {
+			if f.IsDir() || !isLexiconFile(f.Name()) {
+				continue
+			}
+			character := strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))
+			key := layerKey{layer: LayerCharacter, character: character}
+			if _, exists := layers[key]; exists {
+				return nil, fmt.Errorf("%s: duplicate lexicon for character %q", f.Name(), character)
+			}
+			data := newLayerData()
+			if err := data.readFile(filepath.Join(sources.CharactersDir, f.Name())); err != nil {
+				return nil, err
+			}
+			layers[key] = data
+		}
+	}
+
+	return layers, nil
+}
+
+// isLexiconFile は辞書ファイルの拡張子か判定
+func isLexiconFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv", ".tsv", ".json":
+		return true
+	}
+	return false
+}
+
+// readFile は辞書ファイルを読み込んでレイヤーに加える (同じ語は後から読み込んだファイルが優先)
+func (l *layerData) readFile(path string) error {
+	entries, err := ReadLexiconFile(path)
+	if err != nil {
+		return err
+	}
+
+	fromFile := make(map[string][]LexiconEntry)
+	for _, e := range entries {
+		fromFile[e.Word] = append(fromFile[e.Word], e)
+	}
+	maps.Copy(l.files, fromFile)
+	l.source = path
+	return nil
+}
+
+// ReadLexiconFile は辞書ファイル (*.csv, *.tsv, *.json) を読み込む
+// CSV/TSV の列: word, baseForm, emotion, intensity[, pos[, valence, arousal, dominance]]
+// (# で始まる行はコメント、先頭の word 行は見出し)
+// JSON: LexiconEntry の配列
+func ReadLexiconFile(path string) ([]LexiconEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lexicon: %w", err)
+	}
+
+	var entries []LexiconEntry
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		entries, err = decodeLexiconJSON(data)
+	case ".csv":
+		entries, err = decodeLexiconCSV(data, ',')
+	case ".tsv":
+		entries, err = decodeLexiconCSV(data, '\t')
+	default:
+		err = fmt.Errorf("unsupported lexicon format %q (expected .csv, .tsv or .json)", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// decodeLexiconJSON は JSON の辞書を未知のフィールドを拒否してデコードする
+func decodeLexiconJSON(data []byte) ([]LexiconEntry, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var entries []LexiconEntry
+	if err := dec.Decode(&entries); err != nil {
+		return nil, fmt.Errorf("invalid JSON lexicon: %w", err)
+	}
+	for i, e := range entries {
+		if err := e.Validate(); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+	}
+	return entries, nil
+}
+
+// decodeLexiconCSV は CSV/TSV の辞書をデコードする
+func decodeLexiconCSV(data []byte, comma rune) ([]LexiconEntry, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = comma
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+
+	var entries []LexiconEntry
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := r.FieldPos(0)
+
+		if len(entries) == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "word") {
+			continue // 見出し行
+		}
+		if len(record) != 4 && len(record) != 5 && len(record) != 8 {
+			return nil, fmt.Errorf("line %d: expected 4, 5 or 8 columns (word, baseForm, emotion, intensity[, pos[, valence, arousal, dominance]]), got %d", line, len(record))
+		}
+
+		intensity, err := strconv.Atoi(strings.TrimSpace(record[3]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid intensity %q", line, record[3])
+		}
+		e := LexiconEntry{
+			Word:      strings.TrimSpace(record[0]),
+			BaseForm:  strings.TrimSpace(record[1]),
+			Emotion:   models.EmotionCode(strings.TrimSpace(record[2])),
+			Intensity: intensity,
+		}
+		if len(record) >= 5 {
+			e.POS = strings.TrimSpace(record[4])
+		}
+		if len(record) == 8 {
+			affect, err := parseAffect(record[5:8])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			e.Affect = &affect
+		}
+		if err := e.Validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// parseAffect は valence, arousal, dominance の列を VAD 座標に変換する
+func parseAffect(fields []string) (models.Affect, error) {
+	var values [3]float64
+	for i, name := range []string{"valence", "arousal", "dominance"} {
+		v, err := strconv.ParseFloat(strings.TrimSpace(fields[i]), 64)
+		if err != nil {
+			return models.Affect{}, fmt.Errorf("invalid %s %q", name, fields[i])
+		}
+		values[i] = v
+	}
+	return models.Affect{Valence: values[0], Arousal: values[1], Dominance: values[2]}, nil
+}
@@ -0,0 +1,265 @@
+package amygdala
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// writeLexicon は dir に辞書ファイルを作成する
+func writeLexicon(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+// assessCodes は評価結果を感情コードごとの値で返す
+func assessCodes(t *testing.T, a *Amygdala, text string) models.EmotionMap {
+	t.Helper()
+	return models.FromEmotionValues(a.Assess(text).Emotions)
+}
+
+func TestReadLexiconFile_Formats(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"words.csv":  "word,baseForm,emotion,intensity,pos\n# コメント\nデプロイ,,J,60\n落ちる,,F,70,動詞\n",
+		"words.tsv":  "デプロイ\t\tJ\t60\n落ちる\t\tF\t70\t動詞\n",
+		"words.json": `[{"word":"デプロイ","emotion":"J","intensity":60},{"word":"落ちる","emotion":"F","intensity":70,"pos":"動詞"}]`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			entries, err := ReadLexiconFile(writeLexicon(t, dir, name, content))
+			if err != nil {
+				t.Fatalf("ReadLexiconFile: %v", err)
+			}
+			if len(entries) != 2 {
+				t.Fatalf("entries = %v, want 2", entries)
+			}
+			if entries[1].Word != "落ちる" || entries[1].Emotion != models.EmotionFear || entries[1].POS != "動詞" {
+				t.Errorf("entries[1] = %+v", entries[1])
+			}
+		})
+	}
+}
+
+func TestReadLexiconFile_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"code.tsv":      "語\t\tX\t50\n",
+		"intensity.csv": "語,,J,150\n",
+		"columns.csv":   "語,J\n",
+		"unknown.json":  `[{"word":"語","emotion":"J","intensity":50,"weight":1}]`,
+		"format.txt":    "語,,J,50\n",
+	} {
+		if _, err := ReadLexiconFile(writeLexicon(t, dir, name, content)); err == nil {
+			t.Errorf("ReadLexiconFile(%s) should fail", name)
+		}
+	}
+}
+
+func TestLexicon_Layers(t *testing.T) {
+	dir := t.TempDir()
+	base := writeLexicon(t, dir, "base.tsv", "嬉しい\t\tJ\t80\n怖い\t\tF\t80\n")
+	domain := writeLexicon(t, dir, "domain.tsv", "デプロイ\t\tJ\t60\n怖い\t\tF\t30\n")
+	charsDir := filepath.Join(dir, "characters")
+	if err := os.Mkdir(charsDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	writeLexicon(t, charsDir, "anxious.tsv", "デプロイ\t\tF\t90\n")
+
+	l, err := LoadLexicon(LexiconSources{Base: base, Domain: []string{domain}, CharactersDir: charsDir})
+	if err != nil {
+		t.Fatalf("LoadLexicon: %v", err)
+	}
+
+	plain, _ := New(WithLexicon(l))
+	anxious, _ := New(WithLexicon(l), WithCharacter("anxious"))
+
+	// 基本形での照合
+	if got := assessCodes(t, plain, "嬉しかった")[models.EmotionJoy]; got != 80 {
+		t.Errorf("base form match Joy = %d, want 80", got)
+	}
+	// domain は base の同じ語を置き換える
+	if got := assessCodes(t, plain, "怖い")[models.EmotionFear]; got != 30 {
+		t.Errorf("domain override Fear = %d, want 30", got)
+	}
+	// character は共通の語を置き換える (他のキャラクターには影響しない)
+	if got := assessCodes(t, plain, "デプロイ"); got[models.EmotionJoy] != 60 {
+		t.Errorf("plain デプロイ = %v, want Joy 60", got)
+	}
+	if got := assessCodes(t, anxious, "デプロイ"); got[models.EmotionFear] != 90 || got[models.EmotionJoy] != 0 {
+		t.Errorf("anxious デプロイ = %v, want Fear 90 only", got)
+	}
+}
+
+func TestLexicon_POSConstraint(t *testing.T) {
+	l := NewLexicon()
+	if err := l.Add(LayerDomain, "", LexiconEntry{Word: "落ち", BaseForm: "落ちる", Emotion: models.EmotionFear, Intensity: 70, POS: "動詞"}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := l.Add(LayerDomain, "", LexiconEntry{Word: "サーバー", Emotion: models.EmotionFear, Intensity: 50, POS: "名詞,副詞可能"}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	a, _ := New(WithLexicon(l))
+
+	if got := assessCodes(t, a, "サーバーが落ちた")[models.EmotionFear]; got != 70 {
+		t.Errorf("Fear = %d, want 70 (verb matches, noun subcategory does not)", got)
+	}
+}
+
+func TestLexicon_AddRemoveReload(t *testing.T) {
+	dir := t.TempDir()
+	domain := writeLexicon(t, dir, "domain.tsv", "リリース\t\tJ\t60\n")
+	l, err := LoadLexicon(LexiconSources{Domain: []string{domain}})
+	if err != nil {
+		t.Fatalf("LoadLexicon: %v", err)
+	}
+	a, _ := New(WithLexicon(l))
+
+	// 追加は即座に反映される
+	if err := l.Add(LayerCharacter, "calm", LexiconEntry{Word: "障害", Emotion: models.EmotionFear, Intensity: 40}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	calm, _ := New(WithLexicon(l), WithCharacter("calm"))
+	if got := assessCodes(t, calm, "障害")[models.EmotionFear]; got != 40 {
+		t.Errorf("added word Fear = %d, want 40", got)
+	}
+
+	// 基本辞書の語を削除すると照合されなくなる
+	if err := l.Remove(LayerBase, "", "最高"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if got := assessCodes(t, a, "最高"); got[models.EmotionJoy] != 0 {
+		t.Errorf("removed word still matched: %v", got)
+	}
+	if err := l.Remove(LayerDomain, "", "最高"); !errors.Is(err, ErrWordNotFound) {
+		t.Errorf("Remove of missing word error = %v, want ErrWordNotFound", err)
+	}
+	if err := l.Add("unknown", "", LexiconEntry{Word: "語", Emotion: models.EmotionJoy, Intensity: 1}); !errors.Is(err, ErrInvalidLayer) {
+		t.Errorf("Add to unknown layer error = %v, want ErrInvalidLayer", err)
+	}
+
+	// 再読み込みでファイルの変更を反映し、実行時の変更は維持する
+	writeLexicon(t, dir, "domain.tsv", "リリース\t\tJ\t90\n")
+	if _, err := l.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got := assessCodes(t, a, "リリース")[models.EmotionJoy]; got != 90 {
+		t.Errorf("reloaded Joy = %d, want 90", got)
+	}
+	if got := assessCodes(t, a, "最高"); got[models.EmotionJoy] != 0 {
+		t.Errorf("runtime removal lost after reload: %v", got)
+	}
+
+	// 読み込みに失敗した場合は現在の辞書を使い続ける
+	writeLexicon(t, dir, "domain.tsv", "リリース\t\tJ\tmany\n")
+	if _, err := l.Reload(); err == nil {
+		t.Fatal("Reload of invalid file should fail")
+	}
+	if got := assessCodes(t, a, "リリース")[models.EmotionJoy]; got != 90 {
+		t.Errorf("Joy after failed reload = %d, want 90", got)
+	}
+}
+
+func TestLexicon_ConcurrentAssessDuringReload(t *testing.T) {
+	l := NewLexicon()
+	a, _ := New(WithLexicon(l))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if _, err := l.Reload(); err != nil {
+				t.Errorf("Reload: %v", err)
+				return
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if got := assessCodes(t, a, "嬉しい")[models.EmotionJoy]; got != 85 {
+				t.Errorf("Joy during reload = %d, want 85", got)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+}
+
+func TestLexicon_Affect(t *testing.T) {
+	dir := t.TempDir()
+	domain := writeLexicon(t, dir, "domain.tsv", "穏やか\t\tH\t50\t\t0.6\t-0.5\t0.3\n")
+	l, err := LoadLexicon(LexiconSources{Domain: []string{domain}})
+	if err != nil {
+		t.Fatalf("LoadLexicon: %v", err)
+	}
+	a, _ := New(WithLexicon(l))
+
+	// VAD 座標を指定した語はその座標を使う
+	if got := a.Assess("穏やか").Affect; got != (models.Affect{Valence: 0.6, Arousal: -0.5, Dominance: 0.3}) {
+		t.Errorf("explicit Affect = %+v", got)
+	}
+	// 指定がない語は感情コードの代表点を強度で縮小した点
+	if got, want := a.Assess("嬉しい").Affect, models.AffectOf(models.EmotionJoy, 85); got != want {
+		t.Errorf("derived Affect = %+v, want %+v", got, want)
+	}
+	// 何もヒットしない場合は原点
+	if got := a.Assess("xyzabc").Affect; got != (models.Affect{}) {
+		t.Errorf("neutral Affect = %+v, want origin", got)
+	}
+
+	for name, content := range map[string]string{
+		"range.tsv":   "語\t\tJ\t50\t\t1.5\t0\t0\n",
+		"number.csv":  "語,,J,50,,high,0,0\n",
+		"columns.tsv": "語\t\tJ\t50\t\t0.5\t0\n",
+		"range.json":  `[{"word":"語","emotion":"J","intensity":50,"affect":{"valence":0,"arousal":-2,"dominance":0}}]`,
+	} {
+		if _, err := ReadLexiconFile(writeLexicon(t, dir, name, content)); err == nil {
+			t.Errorf("ReadLexiconFile(%s) should fail", name)
+		}
+	}
+}
+
+func TestLexicon_Language(t *testing.T) {
+	l := NewLexicon()
+	// 英字の語は英語の語として照合する (語形変化は見出し語で照合)
+	if err := l.Add(LayerDomain, "", LexiconEntry{Word: "deploy", Emotion: models.EmotionFear, Intensity: 60}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	// 言語を指定した英字の語は日本語の入力で照合する
+	if err := l.Add(LayerDomain, "", LexiconEntry{Word: "OK", Emotion: models.EmotionJoy, Intensity: 40, Language: models.LanguageJapanese}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := l.Add(LayerDomain, "", LexiconEntry{Word: "語", Emotion: models.EmotionJoy, Intensity: 1, Language: "fr"}); err == nil {
+		t.Error("Add with unsupported language should fail")
+	}
+	a, _ := New(WithLexicon(l))
+
+	if got := assessCodes(t, a, "we deployed it")[models.EmotionFear]; got != 60 {
+		t.Errorf("inflected English word Fear = %d, want 60", got)
+	}
+	if got := assessCodes(t, a, "OKだよ")[models.EmotionJoy]; got != 40 {
+		t.Errorf("Japanese-tagged OK Joy = %d, want 40", got)
+	}
+	if got := assessCodes(t, a, "it is OK")[models.EmotionJoy]; got != 0 {
+		t.Errorf("Japanese-tagged OK matched English input: %v", got)
+	}
+
+	// 英語の基本辞書の語の削除は語形変化した語にも及ぶ
+	if err := l.Remove(LayerBase, "", "hate"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if got := assessCodes(t, a, "I hated it")[models.EmotionAnger]; got != 0 {
+		t.Errorf("removed English word still matched: %v", got)
+	}
+}
@@ -0,0 +1,290 @@
+// modifier.go: 否定・程度副詞・ぼかし表現による感情語の修飾を解析し、扁桃体の反応を文脈に合わせて調整する
+package amygdala
+
+import (
+	"math"
+	"strings"
+
+	"github.com/umekku/mind-os/internal/language"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// modifierKind は修飾語の種類
+type modifierKind int
+
+const (
+	modDegree           modifierKind = iota // 程度副詞 (とても, ちょっと) - 強度を倍率で変える
+	modHedge                                // ぼかし表現 (たぶん, かも) - 確信度が低い分だけ弱める
+	modEmphaticNegation                     // 否定を強める副詞 (全然, 全く) - 否定と共に使われた場合のみ有効
+	modPartialNegation                      // 否定を弱める副詞 (あまり, それほど) - 否定と共に使われた場合のみ有効
+)
+
+// preModifier は感情語の直前に置かれて感情語を修飾する語
+type preModifier struct {
+	pos         string // 品詞の制約 (カンマ区切りの前方一致)
+	conjugation string // 活用形の制約 (前方一致。「すごく」のように連用形でのみ副詞として働く語)
+	kind        modifierKind
+	factor      float64 // 強度の倍率 (程度副詞・ぼかし表現)
+}
+
+// preModifiers は感情語の直前の修飾語 (キー: 基本形)
+var preModifiers = map[string]preModifier{
+	// 程度副詞 (強める)
+	"とても":  {pos: "副詞", kind: modDegree, factor: 1.5},
+	"とっても": {pos: "副詞", kind: modDegree, factor: 1.5},
+	"すごい":  {pos: "形容詞,自立", conjugation: "連用", kind: modDegree, factor: 1.5},
+	"すっごい": {pos: "形容詞,自立", conjugation: "連用", kind: modDegree, factor: 1.6},
+	"めっちゃ": {pos: "副詞", kind: modDegree, factor: 1.5},
+	"超":    {pos: "接頭詞", kind: modDegree, factor: 1.5},
+	"非常":   {pos: "名詞,形容動詞語幹", kind: modDegree, factor: 1.5},
+	"大変":   {pos: "名詞,形容動詞語幹", kind: modDegree, factor: 1.4},
+	"最も":   {pos: "副詞", kind: modDegree, factor: 1.5},
+	"本当に":  {pos: "副詞", kind: modDegree, factor: 1.3},
+	"かなり":  {pos: "副詞", kind: modDegree, factor: 1.3},
+	"結構":   {pos: "副詞", kind: modDegree, factor: 1.2},
+
+	// 程度副詞 (弱める)
+	"ちょっと":  {pos: "副詞", kind: modDegree, factor: 0.6},
+	"少し":    {pos: "副詞", kind: modDegree, factor: 0.6},
+	"ちょっぴり": {pos: "副詞", kind: modDegree, factor: 0.5},
+	"やや":    {pos: "副詞", kind: modDegree, factor: 0.7},
+	"多少":    {pos: "副詞", kind: modDegree, factor: 0.7},
+	"若干":    {pos: "名詞,副詞可能", kind: modDegree, factor: 0.7},
+
+	// ぼかし表現
+	"たぶん":  {pos: "副詞", kind: modHedge, factor: 0.7},
+	"多分":   {pos: "副詞", kind: modHedge, factor: 0.7},
+	"おそらく": {pos: "副詞", kind: modHedge, factor: 0.7},
+
+	// 否定を強める副詞 (全否定)
+	"全然":   {pos: "副詞", kind: modEmphaticNegation},
+	"全く":   {pos: "副詞", kind: modEmphaticNegation},
+	"まったく": {pos: "副詞", kind: modEmphaticNegation},
+	"ちっとも": {pos: "副詞", kind: modEmphaticNegation},
+	"決して":  {pos: "副詞", kind: modEmphaticNegation},
+
+	// 否定を弱める副詞 (部分否定)
+	"あまり":  {pos: "名詞", kind: modPartialNegation},
+	"あんまり": {pos: "副詞", kind: modPartialNegation},
+	"それほど": {pos: "副詞", kind: modPartialNegation},
+	"そんなに": {pos: "副詞", kind: modPartialNegation},
+	"さほど":  {pos: "副詞", kind: modPartialNegation},
+}
+
+// 感情語の後に続くぼかし表現の倍率
+const (
+	hedgeConjecture = 0.7 // 推量・伝聞 (かも, らしい, みたい, ようだ, そうだ)
+	hedgeOpinion    = 0.8 // 意見・推測 (と思う, でしょう)
+)
+
+// negationEffect は否定された感情の変化
+type negationEffect struct {
+	flip   float64 // 快情動が反転した不快情動の強度の倍率
+	dampen float64 // 不快情動・驚きの残る強度の倍率 (安堵)
+}
+
+var (
+	plainNegation    = negationEffect{flip: 0.6, dampen: 0.2} // 嬉しくない, 怖くない
+	emphaticNegation = negationEffect{flip: 0.9, dampen: 0}   // 全然嬉しくない, 全く怖くない
+	partialNegation  = negationEffect{flip: 0.3, dampen: 0.4} // あまり嬉しくない, それほど怖くない
+)
+
+// litotesRate は二重否定 (嬉しくなくはない) で残る強度の倍率 (控えめな肯定)
+const litotesRate = 0.6
+
+// negationOpposites は否定された快情動が反転する先
+// 【神経科学的意味】期待した報酬の否定は失望 (悲嘆)、希望の否定は不安、愛着の否定は拒絶として現れる
+var negationOpposites = map[models.EmotionCode]models.EmotionCode{
+	models.EmotionJoy:  models.EmotionGrief,
+	models.EmotionHope: models.EmotionFear,
+	models.EmotionLove: models.EmotionDisgust,
+}
+
+// transparentVerbs は感情語と否定の間に入っても修飾の範囲を切らない動詞 (基本形)
+// 例: 嬉しく「あり」ません, 信頼「でき」ない, 嬉しく「なら」ない
+var transparentVerbs = map[string]bool{
+	"ある":  true,
+	"いる":  true,
+	"できる": true,
+	"なる":  true,
+}
+
+// morpheme は形態素解析の結果
+type morpheme struct {
+	surface  string
+	baseForm string
+	features []string
+}
+
+// newMorphemes はトークンを形態素の列に変換する
+func newMorphemes(tokens []language.Token) []morpheme {
+	morphemes := make([]morpheme, 0, len(tokens))
+	for _, token := range tokens {
+		morphemes = append(morphemes, morpheme{
+			surface:  token.Surface,
+			baseForm: token.BaseForm,
+			features: token.Features,
+		})
+	}
+	return morphemes
+}
+
+// is は品詞が指定 (カンマ区切りの前方一致) を満たすか判定
+func (m morpheme) is(pos string) bool {
+	return hasPOS(m.features, pos)
+}
+
+// conjugation は活用形を返す
+func (m morpheme) conjugation() string {
+	if len(m.features) > 5 {
+		return m.features[5]
+	}
+	return ""
+}
+
+// isNegation は否定の助動詞 (ない, ぬ, ん) または形容詞の「ない」(怖くはない, 嫌いではない) か判定
+func (m morpheme) isNegation() bool {
+	switch {
+	case m.is("助動詞"):
+		return m.baseForm == "ない" || m.baseForm == "ぬ" || m.baseForm == "ん"
+	case m.is("形容詞,自立"):
+		return m.baseForm == "ない"
+	}
+	return false
+}
+
+// preModifier は感情語の直前の修飾語として働く場合にその内容を返す
+func (m morpheme) preModifier() (preModifier, bool) {
+	mod, ok := preModifiers[m.baseForm]
+	if !ok || !m.is(mod.pos) || !strings.HasPrefix(m.conjugation(), mod.conjugation) {
+		return preModifier{}, false
+	}
+	return mod, true
+}
+
+// scope は感情語にかかる修飾の解析結果
+type scope struct {
+	degree    float64        // 程度副詞による強度の倍率
+	hedge     float64        // ぼかし表現による強度の倍率
+	negations int            // 否定の数 (奇数なら否定、2以上の偶数なら二重否定)
+	negation  negationEffect // 否定の強さ (全否定・部分否定の副詞で変わる)
+	modifiers []int          // 修飾語として使われた形態素の位置 (修飾語自体の感情は評価しない)
+	markers   []int          // 否定・後置のぼかし表現として使われた形態素の位置 (評価の根拠の表示用)
+}
+
+// scopeAnalyzers は言語ごとの修飾の解析 (英語は english.go)
+var scopeAnalyzers = map[models.Language]func(morphemes []morpheme, head int) scope{
+	models.LanguageJapanese: analyzeScope,
+	models.LanguageEnglish:  analyzeScopeEnglish,
+}
+
+// analyzeScope は日本語の感情語 (位置 head) の修飾を解析する
+// 【処理内容】
+// 1. 直前に連続する修飾語 (程度副詞・ぼかし表現・否定を強める/弱める副詞) を集める
+// 2. 直後から文節の終わりまでの否定・ぼかし表現を集める
+// 修飾の範囲は句読点・接続助詞・次の自立語で終わるため、「怖くないし楽しい」の否定は「楽しい」に及ばない
+func analyzeScope(morphemes []morpheme, head int) scope {
+	s := scope{degree: 1, hedge: 1, negation: plainNegation}
+
+	// 直前の修飾語 (「非常に」の「に」のような副詞化の助詞は読み飛ばす)
+	for i := head - 1; i >= 0; i-- {
+		m := morphemes[i]
+		if m.is("助詞,副詞化") {
+			continue
+		}
+		mod, ok := m.preModifier()
+		if !ok {
+			break
+		}
+		switch mod.kind {
+		case modDegree:
+			s.degree *= mod.factor
+		case modHedge:
+			s.hedge *= mod.factor
+		case modEmphaticNegation:
+			s.negation = emphaticNegation
+		case modPartialNegation:
+			s.negation = partialNegation
+		}
+		s.modifiers = append(s.modifiers, i)
+	}
+
+	// 直後の否定・ぼかし表現
+	quoted := false      // 引用の「と」の後 (と思う)
+	conjectured := false // 「かも」の後 (かもしれない の「ない」は否定ではない)
+	for i := head + 1; i < len(morphemes); i++ {
+		m := morphemes[i]
+		switch {
+		case conjectured && (m.baseForm == "しれる" || m.isNegation() || m.baseForm == "ます"):
+			// かも「しれ」「ない」/ かも「しれ」「ませ」「ん」
+		case m.isNegation():
+			s.negations++
+			s.markers = append(s.markers, i)
+		case m.is("助詞,副助詞") && m.baseForm == "かも":
+			s.hedge *= hedgeConjecture
+			s.markers = append(s.markers, i)
+			conjectured = true
+		case m.is("助詞,格助詞,引用"):
+			quoted = true
+		case quoted && m.is("動詞,自立") && m.baseForm == "思う":
+			s.hedge *= hedgeOpinion
+			s.markers = append(s.markers, i)
+			quoted = false
+		case m.is("助詞,格助詞") && m.baseForm == "が" && i+1 < len(morphemes) && morphemes[i+1].is("形容詞,自立") && morphemes[i+1].baseForm == "ない":
+			// 不安がない, エラーがない
+		case m.is("助動詞") && m.baseForm == "らしい":
+			s.hedge *= hedgeConjecture
+			s.markers = append(s.markers, i)
+		case m.is("助動詞") && m.baseForm == "う":
+			// でしょう, だろう
+			s.hedge *= hedgeOpinion
+			s.markers = append(s.markers, i)
+		case m.is("名詞,非自立,助動詞語幹"), m.is("名詞,接尾,助動詞語幹"), m.is("名詞,非自立,形容動詞語幹") && m.baseForm == "みたい":
+			// ようだ, そうだ, みたい
+			s.hedge *= hedgeConjecture
+			s.markers = append(s.markers, i)
+		case m.is("助動詞"), m.is("助詞,係助詞"), m.is("助詞,副助詞"), m.is("名詞,接尾"),
+			m.is("動詞,非自立"), m.is("動詞,接尾"), m.is("動詞,自立") && transparentVerbs[m.baseForm]:
+			// 文節の続き (た, です, は, じゃ, ある, できる など)
+		default:
+			return s
+		}
+	}
+	return s
+}
+
+// apply は修飾を反映した感情値と VAD を返す (修飾によって感情が消えた場合は false)
+// 程度副詞・ぼかし表現・打ち消し・二重否定は語の VAD を縮小・拡大し、
+// 快情動の反転は別の感情になるため反転先の感情コードの VAD を使う
+func (s scope) apply(e LexiconEntry) (models.EmotionValue, models.Affect, bool) {
+	code := e.Emotion
+	rate := s.degree * s.hedge
+	flipped := false
+
+	switch {
+	case s.negations%2 == 1:
+		if opposite, ok := negationOpposites[code]; ok {
+			code = opposite
+			rate *= s.negation.flip
+			flipped = true
+		} else {
+			rate *= s.negation.dampen
+		}
+	case s.negations > 0:
+		rate *= litotesRate
+	}
+
+	value := int(math.Round(float64(e.Intensity) * rate))
+	if value < 1 {
+		return models.EmotionValue{}, models.Affect{}, false
+	}
+	if value > 100 {
+		value = 100
+	}
+
+	affect := e.affect().Scale(rate)
+	if flipped {
+		affect = models.AffectOf(code, value)
+	}
+	return models.EmotionValue{Code: code, Value: value}, affect, true
+}
@@ -0,0 +1,171 @@
+package amygdala
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// 組み込み辞書の強度: 嬉しい J85, 楽しい J80, 好き J70, 信頼 L80, 怖い F85, 不安 F60, エラー F65, 悲しい G80, 嫌い A80, すごい S70
+
+func TestAssess_Negation(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want models.EmotionMap
+	}{
+		// 快情動の否定は不快情動に反転する
+		{"形容詞+ない", "嬉しくない", models.EmotionMap{models.EmotionGrief: 51}},
+		{"過去形", "楽しくなかった", models.EmotionMap{models.EmotionGrief: 48}},
+		{"ません", "嬉しくありません", models.EmotionMap{models.EmotionGrief: 51}},
+		{"名詞+じゃない", "好きじゃない", models.EmotionMap{models.EmotionGrief: 42}},
+		{"可能動詞+ない", "信頼できない", models.EmotionMap{models.EmotionDisgust: 48}},
+		// 不快情動の否定は弱まる (安堵)
+		{"形容詞+ない", "怖くない", models.EmotionMap{models.EmotionFear: 17}},
+		{"音便", "怖くねえ", models.EmotionMap{models.EmotionFear: 17}},
+		{"ではない", "嫌いではない", models.EmotionMap{models.EmotionAnger: 16}},
+		{"じゃないです", "不安じゃないです", models.EmotionMap{models.EmotionFear: 12}},
+		{"がない", "エラーがない", models.EmotionMap{models.EmotionFear: 13}},
+		// 否定を強める副詞 (全否定)
+		{"全然+不快", "全然怖くない", models.EmotionMap{models.EmotionNeutral: 10}},
+		{"全く+快", "全く楽しくない", models.EmotionMap{models.EmotionGrief: 72}},
+		{"ちっとも", "ちっとも楽しくない", models.EmotionMap{models.EmotionGrief: 72}},
+		// 否定を弱める副詞 (部分否定)
+		{"あんまり+快", "あんまり楽しくない", models.EmotionMap{models.EmotionGrief: 24}},
+		{"それほど+不快", "それほど怖くない", models.EmotionMap{models.EmotionFear: 34}},
+		// 二重否定は控えめな肯定
+		{"二重否定", "嬉しくなくはない", models.EmotionMap{models.EmotionJoy: 51}},
+		// 否定を伴わない全否定の副詞は影響しない
+		{"否定なし", "全然楽しい", models.EmotionMap{models.EmotionJoy: 80}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.text, func(t *testing.T) {
+			if got := assessCodes(t, a, tt.text); !maps.Equal(got, tt.want) {
+				t.Errorf("Assess(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssess_Degree(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want models.EmotionMap
+	}{
+		{"とても", "とても不安", models.EmotionMap{models.EmotionFear: 90}},
+		{"すごく (修飾語自体の驚きは評価しない)", "すごく不安", models.EmotionMap{models.EmotionFear: 90}},
+		{"非常に", "非常に不安", models.EmotionMap{models.EmotionFear: 90}},
+		{"かなり", "かなり不安", models.EmotionMap{models.EmotionFear: 78}},
+		{"上限", "すごく嬉しい", models.EmotionMap{models.EmotionJoy: 100}},
+		{"ちょっと", "ちょっと怖い", models.EmotionMap{models.EmotionFear: 51}},
+		{"少し", "少し悲しい", models.EmotionMap{models.EmotionGrief: 48}},
+		{"程度副詞+否定", "とても楽しくない", models.EmotionMap{models.EmotionGrief: 72}},
+		// 感情語を修飾していない「すごい」はそれ自体の感情を持つ
+		{"修飾先なし", "すごく疲れた", models.EmotionMap{models.EmotionSurprise: 70}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.text, func(t *testing.T) {
+			if got := assessCodes(t, a, tt.text); !maps.Equal(got, tt.want) {
+				t.Errorf("Assess(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssess_Hedge(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want models.EmotionMap
+	}{
+		{"たぶん", "たぶん楽しい", models.EmotionMap{models.EmotionJoy: 56}},
+		{"かもしれない (否定ではない)", "楽しいかもしれない", models.EmotionMap{models.EmotionJoy: 56}},
+		{"らしい", "悲しいらしい", models.EmotionMap{models.EmotionGrief: 56}},
+		{"みたい", "楽しいみたい", models.EmotionMap{models.EmotionJoy: 56}},
+		{"と思う", "楽しいと思う", models.EmotionMap{models.EmotionJoy: 64}},
+		{"否定+かも", "楽しくないかも", models.EmotionMap{models.EmotionGrief: 34}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.text, func(t *testing.T) {
+			if got := assessCodes(t, a, tt.text); !maps.Equal(got, tt.want) {
+				t.Errorf("Assess(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssess_ModifierScope(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want models.EmotionMap
+	}{
+		// 否定は接続助詞で終わり、次の感情語に及ばない
+		{"接続助詞 し", "怖くないし楽しい", models.EmotionMap{models.EmotionFear: 17, models.EmotionJoy: 80}},
+		{"接続助詞 けど", "楽しくなかったけど不安", models.EmotionMap{models.EmotionGrief: 48, models.EmotionFear: 60}},
+		// 程度副詞は直後の感情語のみを修飾する
+		{"句点", "とても不安。楽しい", models.EmotionMap{models.EmotionFear: 90, models.EmotionJoy: 80}},
+		{"直前の語のみ", "とても不安で楽しい", models.EmotionMap{models.EmotionFear: 90, models.EmotionJoy: 80}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.text, func(t *testing.T) {
+			if got := assessCodes(t, a, tt.text); !maps.Equal(got, tt.want) {
+				t.Errorf("Assess(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssess_ModifierAffect(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		text string
+		want models.Affect
+	}{
+		// 反転した感情は反転先の感情コードの VAD になる
+		{"嬉しくない", models.AffectOf(models.EmotionGrief, 51)},
+		// 程度副詞・打ち消しは語の VAD を拡大・縮小する
+		{"ちょっと怖い", models.AffectOf(models.EmotionFear, 85).Scale(0.6)},
+		{"怖くない", models.AffectOf(models.EmotionFear, 85).Scale(0.2)},
+		{"全然怖くない", models.Affect{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := a.Assess(tt.text).Affect
+			if d := got.Add(tt.want.Scale(-1)).Magnitude(); d > 1e-9 {
+				t.Errorf("Assess(%q).Affect = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,559 @@
+// signal.go: 語彙の照合の前に、絵文字・顔文字・記号の繰り返し・引き伸ばし・笑いの表記から感情の手がかりを抽出する
+package amygdala
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// signalEffect は感情の手がかり1つの寄与
+type signalEffect struct {
+	code    models.EmotionCode // 感情コード (空の場合は覚醒度・強調のみ)
+	value   int                // 感情の強度
+	arousal float64            // 感情コードの VAD に加える覚醒度
+}
+
+const (
+	repeatValueStep   = 5    // 同じ表記の繰り返し1回あたりの強度の増加
+	repeatArousalStep = 0.05 // 繰り返し1回あたりの覚醒度の増加
+	maxRepeatArousal  = 0.3  // 繰り返しによる覚醒度の増加の上限
+	maxEmphasis       = 1.5  // 入力全体の強調の倍率の上限
+	maxKaomojiLength  = 12   // 顔文字とみなす括弧の中身の最大文字数
+)
+
+// signal は繰り返しの回数 (1 = 繰り返しなし) を反映した手がかりを返す
+func (e signalEffect) signal(kind models.AffectSignalKind, text string, repeats int) models.AffectSignal {
+	extra := float64(repeats - 1)
+	s := models.AffectSignal{
+		Kind:    kind,
+		Text:    text,
+		Arousal: roundSignal(e.arousal + math.Min(repeatArousalStep*extra, maxRepeatArousal)),
+	}
+	if e.code != "" {
+		s.Emotion = &models.EmotionValue{Code: e.code, Value: min(e.value+repeatValueStep*(repeats-1), 100)}
+	}
+	return s
+}
+
+// emphasisSignal は感情コードを持たず、入力全体の感情を強める手がかりを返す
+func emphasisSignal(kind models.AffectSignalKind, text string, emphasis, arousal float64) models.AffectSignal {
+	return models.AffectSignal{Kind: kind, Text: text, Arousal: roundSignal(arousal), Emphasis: roundSignal(emphasis)}
+}
+
+// roundSignal は寄与を小数第2位に丸める (API の出力を読みやすくするため)
+func roundSignal(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// signalMatcher は位置 i から始まる手がかりを判定する
+// 手がかりであれば、消費した文字数・手がかり・形態素解析に渡すテキストでの置き換え (空白や1文字に縮めた記号) を返す
+type signalMatcher func(runes []rune, i int) (length int, signal models.AffectSignal, replacement string, ok bool)
+
+// signalMatchers は手がかりの判定順 (先に判定したものを優先する)
+var signalMatchers = []signalMatcher{
+	matchEmoji,
+	matchKaomoji,
+	matchEmoticon,
+	matchLaughter,
+	matchPunctuation,
+	matchElongation,
+}
+
+// extractSignals はテキストから感情の手がかりを抽出する
+// 【処理内容】形態素解析の前に手がかりを取り除き (記号の繰り返し・引き伸ばしは1文字に縮める)、
+// 辞書の照合に使うテキストを返す。顔文字の中の「怒」や「ｗｗｗ」が語として解析されることを防ぐ
+func extractSignals(text string) ([]models.AffectSignal, string) {
+	runes := []rune(text)
+	var signals []models.AffectSignal
+	var rest strings.Builder
+
+	for i := 0; i < len(runes); {
+		matched := false
+		for _, match := range signalMatchers {
+			if n, s, replacement, ok := match(runes, i); ok {
+				signals = append(signals, s)
+				rest.WriteString(replacement)
+				i += n
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			rest.WriteRune(runes[i])
+			i++
+		}
+	}
+
+	return signals, rest.String()
+}
+
+// --- 絵文字 ---
+
+// extendedPictographic は Unicode の Extended_Pictographic プロパティ (絵文字として表示されうる文字) の主な範囲
+// 標準の unicode パッケージには絵文字のプロパティがないため定義する
+var extendedPictographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x203C, Hi: 0x203C, Stride: 1},
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+		{Lo: 0x231A, Hi: 0x23FF, Stride: 1},
+		{Lo: 0x25AA, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2600, Hi: 0x27BF, Stride: 1},
+		{Lo: 0x2B05, Hi: 0x2B55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+		{Lo: 0x303D, Hi: 0x303D, Stride: 1},
+		{Lo: 0x3297, Hi: 0x3299, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1F000, Hi: 0x1FAFF, Stride: 1},
+	},
+}
+
+const (
+	zeroWidthJoiner = '\u200d' // 複数の絵文字を1つに連結する (👨‍👩‍👧)
+	keycapMark      = '\u20e3' // 囲み記号 (1️⃣)
+)
+
+// isEmojiModifier は絵文字の表示を変える後続の文字 (異体字セレクタ・肌の色・囲み記号) か判定
+func isEmojiModifier(r rune) bool {
+	return r == '\ufe0e' || r == '\ufe0f' || r == keycapMark || (r >= 0x1F3FB && r <= 0x1F3FF)
+}
+
+// emojiClusterLength は位置 i から始まる1つの絵文字 (修飾・ZWJ による連結を含む) の文字数を返す
+func emojiClusterLength(runes []rune, i int) int {
+	j := i + 1
+	for j < len(runes) {
+		switch {
+		case isEmojiModifier(runes[j]):
+			j++
+		case runes[j] == zeroWidthJoiner && j+1 < len(runes) && unicode.Is(extendedPictographic, runes[j+1]):
+			j += 2
+		default:
+			return j - i
+		}
+	}
+	return j - i
+}
+
+// emojiKey は絵文字の表示の違い (異体字セレクタ・肌の色) を除いた照合用のキーを返す
+func emojiKey(cluster []rune) string {
+	var b strings.Builder
+	for _, r := range cluster {
+		if !isEmojiModifier(r) || r == keycapMark {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// emojiEffects は絵文字の感情 (キー: 異体字セレクタ・肌の色を除いた絵文字)
+// 表にない絵文字は手がかりとして扱わない
+var emojiEffects = map[string]signalEffect{
+	// Joy (喜び)
+	"😀": {models.EmotionJoy, 65, 0}, "😃": {models.EmotionJoy, 65, 0}, "😄": {models.EmotionJoy, 70, 0},
+	"😁": {models.EmotionJoy, 70, 0}, "😆": {models.EmotionJoy, 70, 0.1}, "😊": {models.EmotionJoy, 65, 0},
+	"🙂": {models.EmotionJoy, 45, 0}, "😺": {models.EmotionJoy, 60, 0}, "😸": {models.EmotionJoy, 65, 0},
+	"😂": {models.EmotionJoy, 75, 0.2}, "🤣": {models.EmotionJoy, 80, 0.2}, "😎": {models.EmotionJoy, 50, 0},
+	"🥳": {models.EmotionJoy, 80, 0.2}, "🎉": {models.EmotionJoy, 75, 0.2}, "👍": {models.EmotionJoy, 45, 0},
+	"😋": {models.EmotionJoy, 60, 0},
+
+	// Love (信頼/愛)
+	"❤": {models.EmotionLove, 75, 0}, "♥": {models.EmotionLove, 70, 0}, "♡": {models.EmotionLove, 65, 0},
+	"💕": {models.EmotionLove, 75, 0}, "💖": {models.EmotionLove, 75, 0.1}, "💗": {models.EmotionLove, 70, 0},
+	"💓": {models.EmotionLove, 70, 0.1}, "💞": {models.EmotionLove, 70, 0}, "😍": {models.EmotionLove, 80, 0.1},
+	"🥰": {models.EmotionLove, 80, 0}, "😘": {models.EmotionLove, 70, 0}, "🤗": {models.EmotionLove, 65, 0},
+	"🙏": {models.EmotionLove, 50, 0},
+
+	// Grief (悲しみ)
+	"😢": {models.EmotionGrief, 65, 0}, "😭": {models.EmotionGrief, 80, 0.3}, "😞": {models.EmotionGrief, 55, 0},
+	"😔": {models.EmotionGrief, 55, 0}, "😟": {models.EmotionGrief, 50, 0}, "🥺": {models.EmotionGrief, 45, 0},
+	"💔": {models.EmotionGrief, 75, 0}, "😿": {models.EmotionGrief, 65, 0},
+
+	// Anger (怒り)
+	"😠": {models.EmotionAnger, 70, 0}, "😡": {models.EmotionAnger, 80, 0.1}, "🤬": {models.EmotionAnger, 90, 0.2},
+	"💢": {models.EmotionAnger, 60, 0.1}, "👿": {models.EmotionAnger, 70, 0}, "😤": {models.EmotionAnger, 55, 0},
+
+	// Fear (恐れ)
+	"😱": {models.EmotionFear, 80, 0.2}, "😨": {models.EmotionFear, 70, 0.1}, "😰": {models.EmotionFear, 65, 0.1},
+	"😧": {models.EmotionFear, 60, 0}, "😖": {models.EmotionFear, 50, 0},
+
+	// Surprise (驚き)
+	"😮": {models.EmotionSurprise, 55, 0}, "😯": {models.EmotionSurprise, 55, 0}, "😲": {models.EmotionSurprise, 70, 0.1},
+	"🤯": {models.EmotionSurprise, 75, 0.2}, "😳": {models.EmotionSurprise, 55, 0},
+
+	// Disgust (嫌悪)
+	"🤮": {models.EmotionDisgust, 85, 0.1}, "🤢": {models.EmotionDisgust, 75, 0}, "😒": {models.EmotionDisgust, 45, 0},
+	"🙄": {models.EmotionDisgust, 40, 0}, "💩": {models.EmotionDisgust, 60, 0},
+
+	// Hope (希望)
+	"🤞": {models.EmotionHope, 55, 0}, "🍀": {models.EmotionHope, 50, 0}, "🌈": {models.EmotionHope, 45, 0},
+	"✨": {models.EmotionHope, 40, 0.1},
+}
+
+// matchEmoji は絵文字 (同じ絵文字の連続は1つの手がかり) を判定する
+func matchEmoji(runes []rune, i int) (int, models.AffectSignal, string, bool) {
+	if !unicode.Is(extendedPictographic, runes[i]) {
+		return 0, models.AffectSignal{}, "", false
+	}
+
+	n := emojiClusterLength(runes, i)
+	key := emojiKey(runes[i : i+n])
+	effect, ok := emojiEffects[key]
+	if !ok {
+		// ZWJ で連結された絵文字は先頭の絵文字で判定する
+		effect, ok = emojiEffects[string(runes[i])]
+	}
+	if !ok {
+		return 0, models.AffectSignal{}, "", false
+	}
+
+	end, repeats := i+n, 1
+	for end < len(runes) && unicode.Is(extendedPictographic, runes[end]) {
+		m := emojiClusterLength(runes, end)
+		if emojiKey(runes[end:end+m]) != key {
+			break
+		}
+		end += m
+		repeats++
+	}
+
+	return end - i, effect.signal(models.AffectSignalEmoji, string(runes[i:end]), repeats), " ", true
+}
+
+// --- 顔文字 ---
+
+// isOpenParen / isCloseParen は半角・全角の括弧か判定
+func isOpenParen(r rune) bool  { return r == '(' || r == '（' }
+func isCloseParen(r rune) bool { return r == ')' || r == '）' }
+
+// parenWords は括弧で囲んだ1文字の感情表現 ((笑), (泣))
+var parenWords = map[string]struct {
+	kind   models.AffectSignalKind
+	effect signalEffect
+}{
+	"笑": {models.AffectSignalLaughter, signalEffect{models.EmotionJoy, 50, 0.1}},
+	"泣": {models.AffectSignalKaomoji, signalEffect{models.EmotionGrief, 60, 0}},
+	"怒": {models.AffectSignalKaomoji, signalEffect{models.EmotionAnger, 60, 0}},
+}
+
+// matchKaomoji は括弧で囲まれた顔文字 ((´；ω；`), (＾▽＾), ((( ；ﾟДﾟ))) ) を判定する
+func matchKaomoji(runes []rune, i int) (int, models.AffectSignal, string, bool) {
+	opens := 0
+	for i+opens < len(runes) && isOpenParen(runes[i+opens]) {
+		opens++
+	}
+	if opens == 0 {
+		return 0, models.AffectSignal{}, "", false
+	}
+
+	start, end := i+opens, -1
+	for j := start; j < len(runes) && j-start <= maxKaomojiLength; j++ {
+		if isOpenParen(runes[j]) {
+			break
+		}
+		if isCloseParen(runes[j]) {
+			end = j
+			break
+		}
+	}
+	if end <= start {
+		return 0, models.AffectSignal{}, "", false
+	}
+
+	// 開き括弧と同じ数まで閉じ括弧を含める
+	stop := end + 1
+	for c := 1; c < opens && stop < len(runes) && isCloseParen(runes[stop]); c++ {
+		stop++
+	}
+	inner := string(runes[start:end])
+	text := string(runes[i:stop])
+
+	if w, ok := parenWords[inner]; ok {
+		return stop - i, w.effect.signal(w.kind, text, 1), " ", true
+	}
+	if !isKaomojiCandidate(runes[start:end]) {
+		return 0, models.AffectSignal{}, "", false
+	}
+	effect, ok := classifyKaomoji(inner, opens > 1)
+	if !ok {
+		return 0, models.AffectSignal{}, "", false
+	}
+	return stop - i, effect.signal(models.AffectSignalKaomoji, text, 1), " ", true
+}
+
+// isKaomojiCandidate は括弧の中身が文 (ひらがな・漢字・英単語) ではなく顔文字になりうるか判定
+func isKaomojiCandidate(inner []rune) bool {
+	letters, katakana := 0, 0
+	for _, r := range inner {
+		if unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Han, r) && r != '益' && r != '皿' {
+			return false
+		}
+		if r < unicode.MaxASCII && unicode.IsLetter(r) {
+			letters++
+		} else {
+			letters = 0
+		}
+		if unicode.Is(unicode.Katakana, r) && r < fullwidthForms {
+			katakana++
+		} else {
+			katakana = 0
+		}
+		// 3文字以上続く英字・全角カタカナは語 (TODO, メモ) とみなす
+		if letters >= 3 || katakana >= 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// fullwidthForms は半角・全角形の先頭 (顔文字の目に使う半角カタカナの ﾟ などは語の文字として数えない)
+const fullwidthForms = '\uFF00'
+
+// classifyKaomoji は顔文字の目・口の形から感情を判定する (nested は「((( ；ﾟДﾟ)))」のように括弧が重なっている)
+// 【判定順】震え (恐れ) → 怒り → 愛情 → 涙 (悲しみ) → 驚き → 笑顔 (喜び)
+func classifyKaomoji(inner string, nested bool) (signalEffect, bool) {
+	switch {
+	case nested || strings.Contains(inner, "lll") || strings.Contains(inner, "|||") || strings.Contains(inner, "ｶﾞｸﾌﾞﾙ"):
+		return signalEffect{models.EmotionFear, 70, 0.2}, true
+	case strings.ContainsAny(inner, "#＃╬益皿💢") || strings.Contains(inner, "｀Д´") || strings.Contains(inner, "`Д´"):
+		return signalEffect{models.EmotionAnger, 75, 0.2}, true
+	case strings.ContainsAny(inner, "♡♥❤"):
+		return signalEffect{models.EmotionLove, 70, 0}, true
+	case countAny(inner, "；;") >= 2 || countAny(inner, "TＴ") >= 2 ||
+		strings.Contains(inner, "´Д｀") || strings.Contains(inner, "´Д`"):
+		return signalEffect{models.EmotionGrief, 70, 0}, true
+	case strings.Contains(inner, "・ω・｀") || strings.Contains(inner, "･ω･`"):
+		return signalEffect{models.EmotionGrief, 45, 0}, true // しょんぼり
+	case strings.ContainsAny(inner, "Д□⊙◎") || strings.Contains(inner, "ﾟoﾟ") || strings.Contains(inner, "゜o゜"):
+		return signalEffect{models.EmotionSurprise, 65, 0.2}, true
+	case strings.ContainsAny(inner, "^＾≧≦∀▽◕ᴗ"):
+		return signalEffect{models.EmotionJoy, 65, 0.1}, true
+	case strings.Contains(inner, "ω"):
+		return signalEffect{models.EmotionJoy, 40, 0}, true
+	}
+	return signalEffect{}, false
+}
+
+// countAny は s に含まれる chars のいずれかの文字の数を返す
+func countAny(s, chars string) int {
+	n := 0
+	for _, r := range s {
+		if strings.ContainsRune(chars, r) {
+			n++
+		}
+	}
+	return n
+}
+
+// emoticons は括弧で囲まない顔文字 (欧米式のエモティコンを含む。前方一致のため長いものを先に並べる)
+var emoticons = []struct {
+	text   string
+	effect signalEffect
+}{
+	{">:-(", signalEffect{models.EmotionAnger, 70, 0.1}},
+	{">:(", signalEffect{models.EmotionAnger, 70, 0.1}},
+	{":'-(", signalEffect{models.EmotionGrief, 70, 0}},
+	{":'(", signalEffect{models.EmotionGrief, 70, 0}},
+	{"</3", signalEffect{models.EmotionGrief, 65, 0}},
+	{"^_^", signalEffect{models.EmotionJoy, 60, 0}},
+	{"^o^", signalEffect{models.EmotionJoy, 65, 0.1}},
+	{"^-^", signalEffect{models.EmotionJoy, 60, 0}},
+	{"^^", signalEffect{models.EmotionJoy, 60, 0}},
+	{"T_T", signalEffect{models.EmotionGrief, 65, 0}},
+	{";_;", signalEffect{models.EmotionGrief, 65, 0}},
+	{"orz", signalEffect{models.EmotionGrief, 55, 0}},
+	{"OTL", signalEffect{models.EmotionGrief, 55, 0}},
+	{"-_-", signalEffect{models.EmotionAnger, 30, 0}},
+	{":-)", signalEffect{models.EmotionJoy, 50, 0}},
+	{":)", signalEffect{models.EmotionJoy, 50, 0}},
+	{":-D", signalEffect{models.EmotionJoy, 65, 0.1}},
+	{":D", signalEffect{models.EmotionJoy, 65, 0.1}},
+	{"XD", signalEffect{models.EmotionJoy, 65, 0.2}},
+	{"xD", signalEffect{models.EmotionJoy, 65, 0.2}},
+	{";-)", signalEffect{models.EmotionJoy, 45, 0}},
+	{";)", signalEffect{models.EmotionJoy, 45, 0}},
+	{":-P", signalEffect{models.EmotionJoy, 40, 0}},
+	{":P", signalEffect{models.EmotionJoy, 40, 0}},
+	{":p", signalEffect{models.EmotionJoy, 40, 0}},
+	{":-(", signalEffect{models.EmotionGrief, 55, 0}},
+	{":(", signalEffect{models.EmotionGrief, 55, 0}},
+	{":-O", signalEffect{models.EmotionSurprise, 60, 0.1}},
+	{":O", signalEffect{models.EmotionSurprise, 60, 0.1}},
+	{":o", signalEffect{models.EmotionSurprise, 60, 0.1}},
+	{"<3", signalEffect{models.EmotionLove, 65, 0}},
+}
+
+// isASCIIWordRune は英数字か判定
+func isASCIIWordRune(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// matchEmoticon は括弧で囲まない顔文字を判定する (英数字を含むものは語の一部でない場合のみ)
+func matchEmoticon(runes []rune, i int) (int, models.AffectSignal, string, bool) {
+	for _, e := range emoticons {
+		pattern := []rune(e.text)
+		n := len(pattern)
+		if i+n > len(runes) || string(runes[i:i+n]) != e.text {
+			continue
+		}
+		if isASCIIWordRune(pattern[0]) && i > 0 && isASCIIWordRune(runes[i-1]) {
+			continue
+		}
+		if isASCIIWordRune(pattern[n-1]) && i+n < len(runes) && isASCIIWordRune(runes[i+n]) {
+			continue
+		}
+		return n, e.effect.signal(models.AffectSignalKaomoji, e.text, 1), " ", true
+	}
+	return 0, models.AffectSignal{}, "", false
+}
+
+// --- 笑い ---
+
+// laughterEffect は笑いの表記の感情
+var laughterEffect = signalEffect{models.EmotionJoy, 50, 0.1}
+
+// englishLaughter は英語の笑いの表記 (小文字化した語全体に一致)
+var englishLaughter = regexp.MustCompile(`^(?:a?(?:ha){2,}h?|(?:he){2,}h?|lo+l+|lmf?ao+|rofl)$`)
+
+// isLaughterW は笑いを表す w (全角・半角) か判定
+func isLaughterW(r rune) bool {
+	return r == 'w' || r == 'W' || r == 'ｗ' || r == 'Ｗ'
+}
+
+// matchLaughter は笑いの表記 (ｗｗｗ, hahaha, lol) を判定する
+// w の連続は英単語・URL (www.example.com) の一部でない場合のみ
+func matchLaughter(runes []rune, i int) (int, models.AffectSignal, string, bool) {
+	if i > 0 && (isASCIIWordRune(runes[i-1]) || runes[i-1] == '.' || runes[i-1] == '/') {
+		return 0, models.AffectSignal{}, "", false
+	}
+
+	if isLaughterW(runes[i]) {
+		j := i
+		for j < len(runes) && isLaughterW(runes[j]) {
+			j++
+		}
+		if n := j - i; n >= 2 && (j == len(runes) || !isASCIIWordRune(runes[j]) && runes[j] != '.') {
+			return n, laughterEffect.signal(models.AffectSignalLaughter, string(runes[i:j]), n-1), " ", true
+		}
+	}
+
+	if runes[i] < unicode.MaxASCII && unicode.IsLetter(runes[i]) {
+		j := i
+		for j < len(runes) && isASCIIWordRune(runes[j]) {
+			j++
+		}
+		word := strings.ToLower(string(runes[i:j]))
+		if englishLaughter.MatchString(word) {
+			return j - i, laughterEffect.signal(models.AffectSignalLaughter, string(runes[i:j]), max(len(word)/2-1, 1)), " ", true
+		}
+	}
+
+	return 0, models.AffectSignal{}, "", false
+}
+
+// --- 記号の繰り返し ---
+
+// matchPunctuation は感嘆符・疑問符の繰り返し (！！！, ？？, ！？) を判定する
+// 【効果】感嘆符のみは入力全体の感情を強め、疑問符を含む場合は驚き (戸惑い) とする
+// 形態素解析には最後の1文字を残す (文の区切りとして扱われるように)
+func matchPunctuation(runes []rune, i int) (int, models.AffectSignal, string, bool) {
+	exclaims, questions := 0, 0
+	j := i
+loop:
+	for ; j < len(runes); j++ {
+		switch runes[j] {
+		case '!', '！':
+			exclaims++
+		case '?', '？':
+			questions++
+		case '‼':
+			exclaims += 2
+		case '⁉':
+			exclaims++
+			questions++
+		default:
+			break loop
+		}
+	}
+
+	total := exclaims + questions
+	if total < 2 {
+		return 0, models.AffectSignal{}, "", false
+	}
+	text := string(runes[i:j])
+	last := string(runes[j-1])
+
+	switch {
+	case exclaims > 0 && questions > 0:
+		// ！？ は強い驚き
+		return j - i, signalEffect{models.EmotionSurprise, 75, 0.2}.signal(models.AffectSignalQuestion, text, total-1), last, true
+	case exclaims > 0:
+		emphasis := math.Min(1+0.1*float64(total-1), 1.3)
+		arousal := math.Min(0.1*float64(total), 0.4)
+		return j - i, emphasisSignal(models.AffectSignalExclamation, text, emphasis, arousal), last, true
+	default:
+		// ？？ は戸惑い (弱い驚き)
+		return j - i, signalEffect{models.EmotionSurprise, 40, 0.1}.signal(models.AffectSignalQuestion, text, total-1), last, true
+	}
+}
+
+// --- 引き伸ばし ---
+
+// isWaveDash は引き伸ばしに使う波線か判定
+func isWaveDash(r rune) bool {
+	return r == '〜' || r == '～' || r == '~' || r == '〰'
+}
+
+// matchElongation は語の引き伸ばし (すごーーい, 嬉しい〜〜, sooo) を判定する
+// 長音符は仮名の後に2つ以上 (1つは通常の表記)、波線は文字の後に1つ以上、英語は同じ文字が3つ以上続く語
+func matchElongation(runes []rune, i int) (int, models.AffectSignal, string, bool) {
+	r := runes[i]
+	var prev rune
+	if i > 0 {
+		prev = runes[i-1]
+	}
+
+	if r == 'ー' || isWaveDash(r) {
+		j := i
+		for j < len(runes) && (runes[j] == 'ー' || isWaveDash(runes[j])) {
+			j++
+		}
+		n := j - i
+		switch {
+		case r == 'ー' && n >= 2 && unicode.In(prev, unicode.Hiragana, unicode.Katakana):
+		case isWaveDash(r) && unicode.IsLetter(prev):
+		default:
+			return 0, models.AffectSignal{}, "", false
+		}
+		return n, elongationSignal(string(runes[i:j]), n), string(r), true
+	}
+
+	// 英語: 語の先頭から判定し、語全体を手がかりの表記とする (語はそのまま形態素解析に渡す)
+	// 同じ文字だけの語 (www, zzz) は引き伸ばしではないため除く
+	if r < unicode.MaxASCII && unicode.IsLetter(r) && !isASCIIWordRune(prev) {
+		j, longest, run := i, 0, 0
+		for ; j < len(runes) && isASCIIWordRune(runes[j]); j++ {
+			if j > i && unicode.ToLower(runes[j]) == unicode.ToLower(runes[j-1]) {
+				run++
+			} else {
+				run = 1
+			}
+			longest = max(longest, run)
+		}
+		if longest >= 3 && longest < j-i {
+			word := string(runes[i:j])
+			return j - i, elongationSignal(word, longest-1), word, true
+		}
+	}
+
+	return 0, models.AffectSignal{}, "", false
+}
+
+// elongationSignal は引き伸ばした文字数 n に応じて感情を強める手がかりを返す
+func elongationSignal(text string, n int) models.AffectSignal {
+	extra := float64(max(n-1, 0))
+	return emphasisSignal(models.AffectSignalElongation, text, math.Min(1.1+0.05*extra, 1.25), math.Min(0.1+0.05*extra, 0.3))
+}
@@ -0,0 +1,164 @@
+package amygdala
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// signalSummary は手がかりを比較しやすい形にしたもの
+type signalSummary struct {
+	kind models.AffectSignalKind
+	text string
+	code models.EmotionCode // 感情コードを持たない強調表現は ""
+	val  int
+}
+
+func summarizeSignals(signals []models.AffectSignal) []signalSummary {
+	out := make([]signalSummary, len(signals))
+	for i, s := range signals {
+		out[i] = signalSummary{kind: s.Kind, text: s.Text}
+		if s.Emotion != nil {
+			out[i].code, out[i].val = s.Emotion.Code, s.Emotion.Value
+		}
+	}
+	return out
+}
+
+func TestExtractSignals(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		want     []signalSummary
+		wantRest string
+	}{
+		// 絵文字
+		{"絵文字", "最高😊", []signalSummary{{models.AffectSignalEmoji, "😊", models.EmotionJoy, 65}}, "最高 "},
+		{"絵文字の繰り返し", "😂😂😂", []signalSummary{{models.AffectSignalEmoji, "😂😂😂", models.EmotionJoy, 85}}, " "},
+		{"異体字セレクタ", "❤️", []signalSummary{{models.AffectSignalEmoji, "❤️", models.EmotionLove, 75}}, " "},
+		{"肌の色", "👍🏻", []signalSummary{{models.AffectSignalEmoji, "👍🏻", models.EmotionJoy, 45}}, " "},
+		// 顔文字
+		{"泣き", "(´；ω；`)", []signalSummary{{models.AffectSignalKaomoji, "(´；ω；`)", models.EmotionGrief, 70}}, " "},
+		{"怒り", "(#ﾟДﾟ)", []signalSummary{{models.AffectSignalKaomoji, "(#ﾟДﾟ)", models.EmotionAnger, 75}}, " "},
+		{"驚き", "(°Д°)", []signalSummary{{models.AffectSignalKaomoji, "(°Д°)", models.EmotionSurprise, 65}}, " "},
+		{"笑顔", "(^^)", []signalSummary{{models.AffectSignalKaomoji, "(^^)", models.EmotionJoy, 65}}, " "},
+		{"括弧なしの顔文字", "orz", []signalSummary{{models.AffectSignalKaomoji, "orz", models.EmotionGrief, 55}}, " "},
+		{"英語の顔文字", "I'm sad :(", []signalSummary{{models.AffectSignalKaomoji, ":(", models.EmotionGrief, 55}}, "I'm sad  "},
+		{"括弧書きは顔文字ではない", "明日(月曜)", nil, "明日(月曜)"},
+		// 笑い
+		{"ｗ", "ｗｗｗ", []signalSummary{{models.AffectSignalLaughter, "ｗｗｗ", models.EmotionJoy, 55}}, " "},
+		{"w", "面白いwww", []signalSummary{{models.AffectSignalLaughter, "www", models.EmotionJoy, 55}}, "面白い "},
+		{"(笑)", "ありがとう(笑)", []signalSummary{{models.AffectSignalLaughter, "(笑)", models.EmotionJoy, 50}}, "ありがとう "},
+		{"hahaha", "hahaha that was fun", []signalSummary{{models.AffectSignalLaughter, "hahaha", models.EmotionJoy, 55}}, "  that was fun"},
+		{"lol", "lol", []signalSummary{{models.AffectSignalLaughter, "lol", models.EmotionJoy, 50}}, " "},
+		{"URL の www は笑いではない", "www.example.com", nil, "www.example.com"},
+		// 記号の繰り返し
+		{"感嘆符", "This is great!!!", []signalSummary{{models.AffectSignalExclamation, "!!!", "", 0}}, "This is great!"},
+		{"感嘆符と疑問符", "本当！？", []signalSummary{{models.AffectSignalQuestion, "！？", models.EmotionSurprise, 75}}, "本当？"},
+		{"疑問符", "なんで？？", []signalSummary{{models.AffectSignalQuestion, "？？", models.EmotionSurprise, 40}}, "なんで？"},
+		{"単独の感嘆符", "はい！", nil, "はい！"},
+		// 引き伸ばし
+		{"長音", "すごーーい", []signalSummary{{models.AffectSignalElongation, "ーー", "", 0}}, "すごーい"},
+		{"単独の長音", "ラーメン", nil, "ラーメン"},
+		{"波線", "嬉しい〜〜", []signalSummary{{models.AffectSignalElongation, "〜〜", "", 0}}, "嬉しい〜"},
+		{"英語", "sooo happy", []signalSummary{{models.AffectSignalElongation, "sooo", "", 0}}, "sooo happy"},
+		{"英語の重字", "good", nil, "good"},
+		// 手がかりなし
+		{"なし", "hello", nil, "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.text, func(t *testing.T) {
+			signals, rest := extractSignals(tt.text)
+			got := summarizeSignals(signals)
+			if len(got) != len(tt.want) {
+				t.Fatalf("extractSignals(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("extractSignals(%q)[%d] = %v, want %v", tt.text, i, got[i], tt.want[i])
+				}
+			}
+			if rest != tt.wantRest {
+				t.Errorf("extractSignals(%q) rest = %q, want %q", tt.text, rest, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestExtractSignals_Emphasis(t *testing.T) {
+	// 記号が多いほど強調・覚醒が強まるが、上限を超えない
+	short, _ := extractSignals("!!")
+	long, _ := extractSignals("!!!!!!!!!!")
+	if len(short) != 1 || len(long) != 1 {
+		t.Fatalf("extractSignals = %v, %v, want one signal each", short, long)
+	}
+	if short[0].Emphasis <= 1 || short[0].Emphasis >= long[0].Emphasis {
+		t.Errorf("Emphasis = %v (!!), %v (!!!!!!!!!!), want 1 < short < long", short[0].Emphasis, long[0].Emphasis)
+	}
+	if long[0].Emphasis > maxEmphasis || long[0].Arousal > 0.4 {
+		t.Errorf("long signal = %+v, want capped emphasis and arousal", long[0])
+	}
+}
+
+func TestAssess_Signals(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want models.EmotionMap
+	}{
+		// 手がかりの感情が加わる
+		{"絵文字のみ", "😂😂😂", models.EmotionMap{models.EmotionJoy: 85}},
+		{"顔文字のみ", "(´；ω；`)", models.EmotionMap{models.EmotionGrief: 70}},
+		{"語と顔文字", "ありがとう(笑)", models.EmotionMap{models.EmotionLove: 60, models.EmotionJoy: 50}},
+		// 強調表現は語の感情を強める
+		{"感嘆符で強調", "This is great!!!", models.EmotionMap{models.EmotionJoy: 90}},
+		{"長音で強調", "すごーーい", models.EmotionMap{models.EmotionSurprise: 81}},
+		{"英語の引き伸ばし", "sooo happy", models.EmotionMap{models.EmotionJoy: 98}},
+		// 顔文字の記号は辞書と照合しない
+		{"顔文字の文字", "(T_T)", models.EmotionMap{models.EmotionGrief: 70}},
+		// 強調表現だけでは感情は生じない
+		{"強調のみ", "やったー！！！", models.EmotionMap{models.EmotionNeutral: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.text, func(t *testing.T) {
+			if got := assessCodes(t, a, tt.text); !maps.Equal(got, tt.want) {
+				t.Errorf("Assess(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssess_SignalAffect(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 強調表現は感情を生じなくても覚醒度を上げ、手がかりとして報告される
+	got := a.Assess("やったー！！！")
+	if got.Affect.Arousal <= 0 {
+		t.Errorf("Assess(やったー！！！).Affect.Arousal = %v, want > 0", got.Affect.Arousal)
+	}
+	if len(got.Signals) != 1 || got.Signals[0].Kind != models.AffectSignalExclamation {
+		t.Errorf("Assess(やったー！！！).Signals = %+v, want one exclamation", got.Signals)
+	}
+
+	// 感嘆符で覚醒度が上がる
+	calm, excited := a.Assess("This is great"), a.Assess("This is great!!!")
+	if excited.Affect.Arousal <= calm.Affect.Arousal {
+		t.Errorf("Arousal = %v (!!!), %v (plain), want higher with exclamations", excited.Affect.Arousal, calm.Affect.Arousal)
+	}
+
+	// 手がかりがなければ報告しない
+	if got := a.Assess("嬉しい"); got.Signals != nil {
+		t.Errorf("Assess(嬉しい).Signals = %+v, want nil", got.Signals)
+	}
+}
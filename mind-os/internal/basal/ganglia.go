@@ -16,17 +16,60 @@ type BasalGanglia struct {
 	decayRate     float64 // 自然減衰率
 	minMotivation float64 // 最小意欲値
 	maxMotivation float64 // 最大意欲値
+	alpha         float64 // 報酬予測誤差に対する意欲の感度
+	beta          float64 // 期待報酬値の学習率
+	baseline      float64 // 刺激がない時に戻る意欲 (気質: 活発さ)
+}
+
+// 学習パラメータの既定値
+const (
+	DefaultAlpha     = 0.5  // 感度係数
+	DefaultBeta      = 0.3  // 学習率
+	DefaultDecayRate = 0.95 // 自然減衰率 (5%)
+)
+
+// Option は BasalGanglia の設定を変更する関数
+type Option func(*BasalGanglia)
+
+// WithLearningRates は意欲の感度 α と期待報酬値の学習率 β (0-1) を設定する
+func WithLearningRates(alpha, beta float64) Option {
+	return func(bg *BasalGanglia) {
+		bg.alpha = alpha
+		bg.beta = beta
+	}
+}
+
+// WithBaseline は刺激がない時に戻る意欲 (0-100) を設定する (初期値・リセット時の値も基準値になる)
+func WithBaseline(motivation float64) Option {
+	return func(bg *BasalGanglia) {
+		bg.baseline = motivation
+		bg.Motivation = motivation
+	}
+}
+
+// WithDecayRate は自然減衰率 (0-1, 1回の減衰で中立からの差に掛ける係数) を設定する
+func WithDecayRate(rate float64) Option {
+	return func(bg *BasalGanglia) {
+		bg.decayRate = rate
+	}
 }
 
 // New は新しい BasalGanglia インスタンスを作成
-func New() *BasalGanglia {
-	return &BasalGanglia{
+func New(opts ...Option) *BasalGanglia {
+	bg := &BasalGanglia{
 		Motivation:      50.0, // 初期値: 中立
 		PredictedReward: 50.0, // 初期期待値: 中立
-		decayRate:       0.95, // 自然減衰率 (5%)
+		decayRate:       DefaultDecayRate,
 		minMotivation:   0.0,
 		maxMotivation:   100.0,
+		alpha:           DefaultAlpha,
+		beta:            DefaultBeta,
+		baseline:        50.0,
+	}
+	for _, opt := range opts {
+		opt(bg)
 	}
+	return bg
 }
 
 // UpdateMotivation は報酬予測誤差に基づいて意欲と期待値を更新
@@ -48,14 +91,14 @@ func (bg *BasalGanglia) UpdateMotivation(actualReward float64) {
 
 	// 1. 意欲(Motivation/Dopamine)の更新
 	// 【数式】M_{t+1} = M_t + α × δ
-	// α = 0.5 (感度係数)
-	bg.Motivation += predictionError * 0.5
+	// α = 0.5 (感度係数, 既定値)
+	bg.Motivation += predictionError * bg.alpha
 
 	// 2. 期待値(Value Function)の更新
 	// 【数式】V_{t+1} = V_t + β × δ
-	// β = 0.3 (学習率)
+	// β = 0.3 (学習率, 既定値)
 	// 将来の予測を現実に近づける（TD学習的な振る舞い）
-	bg.PredictedReward += predictionError * 0.3
+	bg.PredictedReward += predictionError * bg.beta
 
 	bg.clampValues()
 }
@@ -75,6 +118,22 @@ func (bg *BasalGanglia) GetPredictedReward() float64 {
 	return bg.PredictedReward
 }
 
+// Snapshot は意欲と期待報酬値を精度を落とさずに返す（永続化用）
+func (bg *BasalGanglia) Snapshot() (motivation float64, predictedReward float64) {
+	bg.mu.RLock()
+	defer bg.mu.RUnlock()
+	return bg.Motivation, bg.PredictedReward
+}
+
+// Restore は永続化された意欲と期待報酬値を復元する
+func (bg *BasalGanglia) Restore(motivation float64, predictedReward float64) {
+	bg.mu.Lock()
+	defer bg.mu.Unlock()
+	bg.Motivation = motivation
+	bg.PredictedReward = predictedReward
+	bg.clampValues()
+}
+
 // SetMotivation は意欲レベルを直接設定（テスト用）
 func (bg *BasalGanglia) SetMotivation(value int) {
 	bg.mu.Lock()
@@ -88,13 +147,13 @@ func (bg *BasalGanglia) ApplyDecay() {
 	bg.mu.Lock()
 	defer bg.mu.Unlock()
 
-	// 50（中立）に向かって減衰
-	if bg.Motivation > 50 {
-		diff := bg.Motivation - 50.0
-		bg.Motivation = 50.0 + (diff * bg.decayRate)
-	} else if bg.Motivation < 50 {
-		diff := 50.0 - bg.Motivation
-		bg.Motivation = 50.0 - (diff * bg.decayRate)
+	// 基準値（既定: 50 中立）に向かって減衰
+	if bg.Motivation > bg.baseline {
+		diff := bg.Motivation - bg.baseline
+		bg.Motivation = bg.baseline + (diff * bg.decayRate)
+	} else if bg.Motivation < bg.baseline {
+		diff := bg.baseline - bg.Motivation
+		bg.Motivation = bg.baseline - (diff * bg.decayRate)
 	}
 
 	bg.clampValues()
@@ -157,6 +216,6 @@ func (bg *BasalGanglia) clampValues() {
 func (bg *BasalGanglia) Reset() {
 	bg.mu.Lock()
 	defer bg.mu.Unlock()
-	bg.Motivation = 50.0
+	bg.Motivation = bg.baseline
 	bg.PredictedReward = 50.0
 }
@@ -16,6 +16,26 @@ func TestNew(t *testing.T) {
 	}
 }
 
+// TestNew_WithLearningRates は設定による学習率の変更をテスト
+func TestNew_WithLearningRates(t *testing.T) {
+	bg := New(WithLearningRates(1.0, 0), WithDecayRate(0.5))
+
+	// α=1: 報酬予測誤差がそのまま意欲に反映される, β=0: 期待値は学習しない
+	bg.UpdateMotivation(80.0)
+	if bg.Motivation != 80 {
+		t.Errorf("Motivation = %f, want 80", bg.Motivation)
+	}
+	if bg.PredictedReward != 50 {
+		t.Errorf("PredictedReward = %f, want 50", bg.PredictedReward)
+	}
+
+	// 減衰率 0.5: 中立(50)からの差が半分になる
+	bg.ApplyDecay()
+	if bg.Motivation != 65 {
+		t.Errorf("Motivation after decay = %f, want 65", bg.Motivation)
+	}
+}
+
 // TestGetMotivation は意欲取得をテスト
 func TestGetMotivation(t *testing.T) {
 	bg := New()
@@ -3,8 +3,11 @@ package config
 import (
 	"fmt"
 	"log"
+	"log/slog"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -17,28 +20,84 @@ type Config struct {
 	Port        string
 	Mode        string // debug, release, test
 
+	// 終了処理の制限時間 (秒): リクエストの処理待ち・睡眠(記憶の固定化)・状態保存・DBクローズの合計
+	ShutdownTimeoutSeconds int
+
 	// データベース設定
 	DBPath string
 
+	// マルチテナント設定
+	MaxActiveBrains int // メモリ上に同時に保持する脳の最大数
+
 	// セキュリティ設定
-	CORSAllowedOrigins []string
-	APIKey             string
+	CORSAllowedOrigins   []string // 許可するオリジン ("*" は全て、"https://*.example.com" はサブドメイン)
+	CORSAllowedMethods   []string
+	CORSAllowedHeaders   []string
+	CORSExposedHeaders   []string // ブラウザのスクリプトから参照できるレスポンスヘッダー
+	CORSAllowCredentials bool
+	CORSMaxAgeSeconds    int           // プリフライトの結果をブラウザがキャッシュする時間 (秒)
+	APIKey               string        // 管理者権限を持つAPIキー (後方互換)
+	APIKeys              []APIKeyGrant // スコープ付きのAPIキー (API_KEYS="key:scope,...")
+	SwaggerPublic        bool          // Swagger UI を認証なしで公開するか
+	apiKeysErr           error         // API_KEYS の解析エラー
+	TrustedProxies       []string      // X-Forwarded-For を信頼するプロキシ (IP/CIDR)。空の場合は接続元IPを使う
+
+	// レート制限設定 (APIキー、キーがなければクライアントIPごと)
+	RateLimitEnabled        bool
+	RateLimitReadPerMinute  int // 参照系 (GET) の1分あたりのリクエスト数
+	RateLimitReadBurst      int // 参照系の連続リクエスト数の上限
+	RateLimitWritePerMinute int // 入力など状態を変える操作の1分あたりのリクエスト数 (WebSocket のメッセージを含む)
+	RateLimitWriteBurst     int // 状態を変える操作の連続リクエスト数の上限
 
 	// デバッグ設定
-	DebugMode bool
-	LogLevel  string
+	DebugMode bool   // ログを debug レベルにし、出力元のソース位置を付ける
+	LogLevel  string // ログレベル (debug, info, warn, error)
+
+	// メトリクス設定 (Prometheus)
+	MetricsEnabled bool // /metrics を提供するか
+	MetricsPublic  bool // /metrics を認証なしで公開するか (false の場合は read スコープが必要)
 
 	// 脳パラメータ設定
-	STMMaxSize             int
-	LTMMaxSize             int
-	ConsolidationThreshold float64
+	STMMaxSize             int     // 短期記憶の最大件数
+	LTMMaxSize             int     // 長期記憶の最大件数
+	ConsolidationThreshold float64 // 長期記憶へ固定化する重みの閾値 (0-1)
+
+	// 忘却曲線設定
+	MemoryStabilityHours float64 // 想起0回・感情なしの記憶の安定度 (時間)
+	MemoryForgetFloor    float64 // この重みを下回った長期記憶は削除される
 
 	// ホルモン設定
-	HormoneDecayRate float64
+	HormoneDecayRate float64 // 1時間あたりの Cortisol/Oxytocin の減衰量
+
+	// 前頭前皮質 (理性) 設定
+	PFCInitialSanity       int     // 理性値の初期値 (0-100)
+	PFCControlThreshold    int     // この理性値を下回ると感情を制御できない (0-100)
+	PFCNegativeSuppression float64 // 理性が最大の時にネガティブ感情を抑制する割合 (0-1)
+	PFCPositiveBoost       float64 // 理性が最大の時にポジティブ感情を増幅する割合 (0-1)
+
+	// 大脳基底核 (意欲) 設定
+	BasalAlpha     float64 // 報酬予測誤差に対する意欲の感度 α (0-1)
+	BasalBeta      float64 // 期待報酬値の学習率 β (0-1)
+	BasalDecayRate float64 // 意欲の自然減衰率 (0-1, 1回の減衰で中立からの差に掛ける係数)
+
+	// パーソナリティ設定
+	ProfilesDir    string // パーソナリティプロファイル (*.yaml, *.yml, *.json) を読み込むディレクトリ (空の場合は default のみ)
+	DefaultProfile string // プロファイル未指定で脳を作成する時に使うプロファイル名
+
+	// 感情辞書 (扁桃体) 設定
+	LexiconBase          string   // 基本辞書のファイル (*.csv, *.tsv, *.json。空の場合は組み込みの辞書)
+	LexiconDomain        []string // 分野別の辞書のファイル (指定順に重ねる)
+	LexiconCharactersDir string   // キャラクター別の辞書のディレクトリ (ファイル名 = プロファイル名)
 
 	// 概日リズム設定
 	DayTimeStart   int
 	NightTimeStart int
+
+	// 自律活動スケジューラ設定
+	SchedulerEnabled       bool // 無刺激時の減衰・睡眠・マインドワンダリングを自動で行うか
+	SchedulerTickSeconds   int  // スケジューラの巡回間隔 (秒)
+	DecayIntervalMinutes   int  // 意欲・ホルモンを減衰させる間隔 (分)
+	IdleWanderAfterMinutes int  // 刺激がない状態がこの時間続くとマインドワンダリングを行う (分)
 }
 
 // LoadConfig は環境変数から設定を読み込む
@@ -50,30 +109,86 @@ func LoadConfig() *Config {
 		Port:        getEnv("PORT", "8081"),
 		Mode:        getEnv("GIN_MODE", "release"),
 
+		ShutdownTimeoutSeconds: getEnvAsInt("SHUTDOWN_TIMEOUT_SECONDS", 15),
+
 		// データベース設定
 		DBPath: getEnv("DB_PATH", "mind.db"),
 
+		// マルチテナント設定
+		MaxActiveBrains: getEnvAsInt("MAX_ACTIVE_BRAINS", 100),
+
 		// セキュリティ設定
-		CORSAllowedOrigins: getEnvAsSlice("CORS_ALLOWED_ORIGINS", []string{"http://localhost:3000"}),
-		APIKey:             getEnv("API_KEY", ""),
+		CORSAllowedOrigins:   getEnvAsSlice("CORS_ALLOWED_ORIGINS", []string{"http://localhost:3000"}),
+		CORSAllowedMethods:   getEnvAsSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PATCH", "DELETE", "OPTIONS"}),
+		CORSAllowedHeaders:   getEnvAsSlice("CORS_ALLOWED_HEADERS", []string{"Content-Type", "Authorization", "X-API-Key", "X-Brain-ID", "If-None-Match", "Last-Event-ID"}),
+		CORSExposedHeaders:   getEnvAsSlice("CORS_EXPOSED_HEADERS", []string{"ETag", "Deprecation", "Sunset", "Warning", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"}),
+		CORSAllowCredentials: getEnvAsBool("CORS_ALLOW_CREDENTIALS", false),
+		CORSMaxAgeSeconds:    getEnvAsInt("CORS_MAX_AGE_SECONDS", 600),
+		APIKey:               getEnv("API_KEY", ""),
+		SwaggerPublic:        getEnvAsBool("SWAGGER_PUBLIC", true),
+		TrustedProxies:       getEnvAsSlice("TRUSTED_PROXIES", nil),
+
+		// レート制限設定
+		RateLimitEnabled:        getEnvAsBool("RATE_LIMIT_ENABLED", true),
+		RateLimitReadPerMinute:  getEnvAsInt("RATE_LIMIT_READ_PER_MINUTE", 600),
+		RateLimitReadBurst:      getEnvAsInt("RATE_LIMIT_READ_BURST", 60),
+		RateLimitWritePerMinute: getEnvAsInt("RATE_LIMIT_WRITE_PER_MINUTE", 60),
+		RateLimitWriteBurst:     getEnvAsInt("RATE_LIMIT_WRITE_BURST", 10),
 
 		// デバッグ設定
 		DebugMode: getEnvAsBool("DEBUG_MODE", false),
 		LogLevel:  getEnv("LOG_LEVEL", "info"),
 
+		// メトリクス設定
+		MetricsEnabled: getEnvAsBool("METRICS_ENABLED", true),
+		MetricsPublic:  getEnvAsBool("METRICS_PUBLIC", false),
+
 		// 脳パラメータ設定
 		STMMaxSize:             getEnvAsInt("STM_MAX_SIZE", 100),
 		LTMMaxSize:             getEnvAsInt("LTM_MAX_SIZE", 1000),
 		ConsolidationThreshold: getEnvAsFloat("CONSOLIDATION_THRESHOLD", 0.6),
 
+		// 忘却曲線設定
+		MemoryStabilityHours: getEnvAsFloat("MEMORY_STABILITY_HOURS", 24.0),
+		MemoryForgetFloor:    getEnvAsFloat("MEMORY_FORGET_FLOOR", 0.05),
+
 		// ホルモン設定
 		HormoneDecayRate: getEnvAsFloat("HORMONE_DECAY_RATE", 10.0),
 
+		// 前頭前皮質 (理性) 設定
+		PFCInitialSanity:       getEnvAsInt("PFC_INITIAL_SANITY", 80),
+		PFCControlThreshold:    getEnvAsInt("PFC_CONTROL_THRESHOLD", 30),
+		PFCNegativeSuppression: getEnvAsFloat("PFC_NEGATIVE_SUPPRESSION", 0.5),
+		PFCPositiveBoost:       getEnvAsFloat("PFC_POSITIVE_BOOST", 0.1),
+
+		// 大脳基底核 (意欲) 設定
+		BasalAlpha:     getEnvAsFloat("BASAL_ALPHA", 0.5),
+		BasalBeta:      getEnvAsFloat("BASAL_BETA", 0.3),
+		BasalDecayRate: getEnvAsFloat("BASAL_DECAY_RATE", 0.95),
+
+		// パーソナリティ設定
+		ProfilesDir:    getEnv("PROFILES_DIR", ""),
+		DefaultProfile: getEnv("DEFAULT_PROFILE", "default"),
+
+		// 感情辞書 (扁桃体) 設定
+		LexiconBase:          getEnv("LEXICON_BASE", ""),
+		LexiconDomain:        getEnvAsSlice("LEXICON_DOMAIN", nil),
+		LexiconCharactersDir: getEnv("LEXICON_CHARACTERS_DIR", ""),
+
 		// 概日リズム設定
 		DayTimeStart:   getEnvAsInt("DAY_TIME_START", 6),
 		NightTimeStart: getEnvAsInt("NIGHT_TIME_START", 22),
+
+		// 自律活動スケジューラ設定
+		SchedulerEnabled:       getEnvAsBool("SCHEDULER_ENABLED", true),
+		SchedulerTickSeconds:   getEnvAsInt("SCHEDULER_TICK_SECONDS", 60),
+		DecayIntervalMinutes:   getEnvAsInt("DECAY_INTERVAL_MINUTES", 10),
+		IdleWanderAfterMinutes: getEnvAsInt("IDLE_WANDER_AFTER_MINUTES", 30),
 	}
 
+	// スコープ付きAPIキーの解析 (書式の誤りは validate で報告)
+	cfg.APIKeys, cfg.apiKeysErr = parseAPIKeyGrants(getEnvAsSlice("API_KEYS", nil))
+
 	// 必須項目の検証
 	if err := cfg.validate(); err != nil {
 		// 起動阻止 (Fail Fast)
@@ -91,8 +206,8 @@ func (c *Config) validate() error {
 
 	// 1. APIキーの強制（プロダクションモードの場合）
 	// 空文字チェック
-	if c.Mode == "release" && strings.TrimSpace(c.APIKey) == "" {
-		errs = append(errs, "API_KEY is required in release mode")
+	if c.Mode == "release" && len(c.APIKeyGrants()) == 0 {
+		errs = append(errs, "API_KEY or API_KEYS is required in release mode")
 	}
 
 	// 2. ポート番号の検証
@@ -111,12 +226,230 @@ func (c *Config) validate() error {
 		errs = append(errs, fmt.Sprintf("Invalid DB_PATH extension: %s (expected .db, .sqlite, .sqlite3)", c.DBPath))
 	}
 
+	// 4. 同時保持する脳の数の検証
+	if c.MaxActiveBrains < 1 {
+		errs = append(errs, fmt.Sprintf("Invalid MAX_ACTIVE_BRAINS: %d (must be >= 1)", c.MaxActiveBrains))
+	}
+
+	// 5. 忘却曲線パラメータの検証
+	if c.MemoryStabilityHours <= 0 {
+		errs = append(errs, fmt.Sprintf("Invalid MEMORY_STABILITY_HOURS: %v (must be > 0)", c.MemoryStabilityHours))
+	}
+	if c.MemoryForgetFloor < 0 || c.MemoryForgetFloor >= 1 {
+		errs = append(errs, fmt.Sprintf("Invalid MEMORY_FORGET_FLOOR: %v (must be in [0, 1))", c.MemoryForgetFloor))
+	}
+
+	// 6. 概日リズムの時間帯の検証
+	if c.DayTimeStart < 0 || c.DayTimeStart > 23 || c.NightTimeStart < 0 || c.NightTimeStart > 23 || c.DayTimeStart == c.NightTimeStart {
+		errs = append(errs, fmt.Sprintf("Invalid DAY_TIME_START/NIGHT_TIME_START: %d/%d (must be distinct hours in 0-23)", c.DayTimeStart, c.NightTimeStart))
+	}
+
+	// 7. スケジューラ間隔の検証
+	if c.SchedulerTickSeconds < 1 {
+		errs = append(errs, fmt.Sprintf("Invalid SCHEDULER_TICK_SECONDS: %d (must be >= 1)", c.SchedulerTickSeconds))
+	}
+	if c.DecayIntervalMinutes < 1 {
+		errs = append(errs, fmt.Sprintf("Invalid DECAY_INTERVAL_MINUTES: %d (must be >= 1)", c.DecayIntervalMinutes))
+	}
+	if c.IdleWanderAfterMinutes < 1 {
+		errs = append(errs, fmt.Sprintf("Invalid IDLE_WANDER_AFTER_MINUTES: %d (must be >= 1)", c.IdleWanderAfterMinutes))
+	}
+
+	// 8. 終了処理の制限時間の検証
+	if c.ShutdownTimeoutSeconds < 1 {
+		errs = append(errs, fmt.Sprintf("Invalid SHUTDOWN_TIMEOUT_SECONDS: %d (must be >= 1)", c.ShutdownTimeoutSeconds))
+	}
+
+	// 9. スコープ付きAPIキーの書式の検証
+	if c.apiKeysErr != nil {
+		errs = append(errs, c.apiKeysErr.Error())
+	}
+
+	// 10. CORS設定の検証
+	for _, origin := range c.CORSAllowedOrigins {
+		if !isValidCORSOrigin(origin) {
+			errs = append(errs, fmt.Sprintf("Invalid CORS_ALLOWED_ORIGINS entry: %q (expected *, scheme://host[:port] or scheme://*.domain[:port])", origin))
+		}
+	}
+	if c.CORSAllowCredentials && slices.Contains(c.CORSAllowedOrigins, "*") {
+		errs = append(errs, "CORS_ALLOW_CREDENTIALS cannot be used with CORS_ALLOWED_ORIGINS=*")
+	}
+	if c.CORSMaxAgeSeconds < 0 {
+		errs = append(errs, fmt.Sprintf("Invalid CORS_MAX_AGE_SECONDS: %d (must be >= 0)", c.CORSMaxAgeSeconds))
+	}
+
+	// 11. レート制限の検証
+	if c.RateLimitEnabled {
+		limits := []struct {
+			name  string
+			value int
+		}{
+			{"RATE_LIMIT_READ_PER_MINUTE", c.RateLimitReadPerMinute},
+			{"RATE_LIMIT_READ_BURST", c.RateLimitReadBurst},
+			{"RATE_LIMIT_WRITE_PER_MINUTE", c.RateLimitWritePerMinute},
+			{"RATE_LIMIT_WRITE_BURST", c.RateLimitWriteBurst},
+		}
+		for _, l := range limits {
+			if l.value < 1 {
+				errs = append(errs, fmt.Sprintf("Invalid %s: %d (must be >= 1)", l.name, l.value))
+			}
+		}
+	}
+
+	// 12. ログレベルの検証
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		errs = append(errs, fmt.Sprintf("Invalid LOG_LEVEL: %q (expected debug, info, warn or error)", c.LogLevel))
+	}
+
+	// 13. 脳パラメータの検証
+	if c.STMMaxSize < 1 {
+		errs = append(errs, fmt.Sprintf("Invalid STM_MAX_SIZE: %d (must be >= 1)", c.STMMaxSize))
+	}
+	if c.LTMMaxSize < 1 {
+		errs = append(errs, fmt.Sprintf("Invalid LTM_MAX_SIZE: %d (must be >= 1)", c.LTMMaxSize))
+	}
+	if strings.TrimSpace(c.DefaultProfile) == "" {
+		errs = append(errs, "DEFAULT_PROFILE must not be empty")
+	}
+	if c.HormoneDecayRate < 0 {
+		errs = append(errs, fmt.Sprintf("Invalid HORMONE_DECAY_RATE: %v (must be >= 0)", c.HormoneDecayRate))
+	}
+	for _, p := range []struct {
+		name  string
+		value int
+	}{
+		{"PFC_INITIAL_SANITY", c.PFCInitialSanity},
+		{"PFC_CONTROL_THRESHOLD", c.PFCControlThreshold},
+	} {
+		if p.value < 0 || p.value > 100 {
+			errs = append(errs, fmt.Sprintf("Invalid %s: %d (must be in [0, 100])", p.name, p.value))
+		}
+	}
+	for _, p := range []struct {
+		name  string
+		value float64
+	}{
+		{"CONSOLIDATION_THRESHOLD", c.ConsolidationThreshold},
+		{"PFC_NEGATIVE_SUPPRESSION", c.PFCNegativeSuppression},
+		{"PFC_POSITIVE_BOOST", c.PFCPositiveBoost},
+		{"BASAL_ALPHA", c.BasalAlpha},
+		{"BASAL_BETA", c.BasalBeta},
+		{"BASAL_DECAY_RATE", c.BasalDecayRate},
+	} {
+		if p.value < 0 || p.value > 1 {
+			errs = append(errs, fmt.Sprintf("Invalid %s: %v (must be in [0, 1])", p.name, p.value))
+		}
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("configuration validation failed:\n - %s", strings.Join(errs, "\n - "))
 	}
 	return nil
 }
 
+// SlogLevel はログレベルを返す (DEBUG_MODE の場合は debug)
+func (c *Config) SlogLevel() slog.Level {
+	if c.DebugMode {
+		return slog.LevelDebug
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// isValidCORSOrigin はオリジンの書式を検証する
+// "*"、"scheme://host[:port]"、またはサブドメインを表す "scheme://*.domain[:port]" を受け付ける
+func isValidCORSOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+	if err != nil || u.Scheme == "" || u.Hostname() == "" {
+		return false
+	}
+	// オリジンはパス・クエリ・ユーザー情報を持たない
+	return u.Path == "" && u.RawQuery == "" && u.Fragment == "" && u.User == nil && !strings.Contains(u.Host, "*")
+}
+
+// Scope はAPIキーの権限
+// 上位のスコープは下位のスコープの操作を全て含む (ScopeAdmin ⊃ ScopeWrite ⊃ ScopeRead)
+type Scope int
+
+const (
+	ScopeRead  Scope = iota + 1 // 状態・記憶の参照
+	ScopeWrite                  // 入力・フィードバック・記憶の編集など状態を変える操作
+	ScopeAdmin                  // 脳の作成・削除、記憶の削除、意欲のリセットなどの管理操作
+)
+
+// ParseScope はスコープ名 (read, write, admin) を Scope に変換
+func ParseScope(name string) (Scope, bool) {
+	switch name {
+	case "read":
+		return ScopeRead, true
+	case "write":
+		return ScopeWrite, true
+	case "admin":
+		return ScopeAdmin, true
+	default:
+		return 0, false
+	}
+}
+
+// String はスコープ名を返す
+func (s Scope) String() string {
+	switch s {
+	case ScopeRead:
+		return "read"
+	case ScopeWrite:
+		return "write"
+	case ScopeAdmin:
+		return "admin"
+	default:
+		return fmt.Sprintf("Scope(%d)", int(s))
+	}
+}
+
+// APIKeyGrant はAPIキーと付与されたスコープ
+type APIKeyGrant struct {
+	Key   string
+	Scope Scope
+}
+
+// APIKeyGrants は有効な全てのAPIキーを返す
+// API_KEY は admin スコープのキーとして扱う
+func (c *Config) APIKeyGrants() []APIKeyGrant {
+	grants := make([]APIKeyGrant, 0, len(c.APIKeys)+1)
+	if key := strings.TrimSpace(c.APIKey); key != "" {
+		grants = append(grants, APIKeyGrant{Key: key, Scope: ScopeAdmin})
+	}
+	return append(grants, c.APIKeys...)
+}
+
+// parseAPIKeyGrants は "key:scope" 形式のリストを解析する
+func parseAPIKeyGrants(entries []string) ([]APIKeyGrant, error) {
+	grants := make([]APIKeyGrant, 0, len(entries))
+	for i, entry := range entries {
+		if entry == "" {
+			continue
+		}
+		key, name, ok := strings.Cut(entry, ":")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			// キー自体はエラーに含めない
+			return nil, fmt.Errorf("Invalid API_KEYS entry #%d (expected key:scope)", i+1)
+		}
+		scope, ok := ParseScope(name)
+		if !ok {
+			return nil, fmt.Errorf("Invalid API_KEYS entry #%d: unknown scope %q (expected read, write or admin)", i+1, name)
+		}
+		grants = append(grants, APIKeyGrant{Key: key, Scope: scope})
+	}
+	return grants, nil
+}
+
 // getEnv は環境変数を取得し、存在しない場合はデフォルト値を返すヘルパー
 func getEnv(key, fallback string) string {
 	if value, exists := os.LookupEnv(key); exists {
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"log/slog"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -10,6 +12,8 @@ func TestLoadConfig_Defaults(t *testing.T) {
 	os.Unsetenv("SERVICE_NAME")
 	os.Unsetenv("PORT")
 	os.Unsetenv("GIN_MODE")
+	// 既定の release モードではAPIキーが必須
+	t.Setenv("API_KEY", "test-key")
 
 	cfg := LoadConfig()
 
@@ -61,3 +65,121 @@ func TestGetEnvAsInt(t *testing.T) {
 		t.Errorf("getEnvAsInt should return default 999, got %d", valDefault)
 	}
 }
+
+func TestParseAPIKeyGrants(t *testing.T) {
+	grants, err := parseAPIKeyGrants([]string{"viewer-key:read", "bot-key:write", "", "ops-key:admin"})
+	if err != nil {
+		t.Fatalf("parseAPIKeyGrants error: %v", err)
+	}
+	want := []APIKeyGrant{
+		{Key: "viewer-key", Scope: ScopeRead},
+		{Key: "bot-key", Scope: ScopeWrite},
+		{Key: "ops-key", Scope: ScopeAdmin},
+	}
+	if len(grants) != len(want) {
+		t.Fatalf("got %d grants, want %d", len(grants), len(want))
+	}
+	for i := range want {
+		if grants[i] != want[i] {
+			t.Errorf("grants[%d] = %+v, want %+v", i, grants[i], want[i])
+		}
+	}
+
+	for _, invalid := range []string{"no-scope", ":read", "key:root"} {
+		if _, err := parseAPIKeyGrants([]string{invalid}); err == nil {
+			t.Errorf("parseAPIKeyGrants(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestParseScope(t *testing.T) {
+	for _, s := range []Scope{ScopeRead, ScopeWrite, ScopeAdmin} {
+		if got, ok := ParseScope(s.String()); !ok || got != s {
+			t.Errorf("ParseScope(%q) = %v, %v, want %v", s.String(), got, ok, s)
+		}
+	}
+	if _, ok := ParseScope("root"); ok {
+		t.Error(`ParseScope("root") ok = true, want false`)
+	}
+}
+
+func TestAPIKeyGrants_IncludesLegacyKeyAsAdmin(t *testing.T) {
+	cfg := &Config{
+		APIKey:  "legacy-key",
+		APIKeys: []APIKeyGrant{{Key: "viewer-key", Scope: ScopeRead}},
+	}
+
+	grants := cfg.APIKeyGrants()
+	if len(grants) != 2 {
+		t.Fatalf("got %d grants, want 2", len(grants))
+	}
+	if grants[0] != (APIKeyGrant{Key: "legacy-key", Scope: ScopeAdmin}) {
+		t.Errorf("API_KEY should be granted admin scope, got %+v", grants[0])
+	}
+}
+
+func TestIsValidCORSOrigin(t *testing.T) {
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"*", true},
+		{"http://localhost:3000", true},
+		{"https://app.example.com", true},
+		{"https://*.example.com", true},
+		{"https://*.example.com:8443", true},
+		{"localhost:3000", false},
+		{"https://example.com/path", false},
+		{"https://*example.com", false},
+		{"https://app.*.example.com", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isValidCORSOrigin(tt.origin); got != tt.want {
+			t.Errorf("isValidCORSOrigin(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestValidate_BrainParameters(t *testing.T) {
+	t.Setenv("API_KEY", "test-key")
+	cfg := LoadConfig()
+	if err := cfg.validate(); err != nil {
+		t.Fatalf("default config should be valid: %v", err)
+	}
+
+	cfg.ConsolidationThreshold = 5
+	cfg.PFCControlThreshold = 101
+	cfg.BasalAlpha = -0.1
+	cfg.LogLevel = "verbose"
+
+	err := cfg.validate()
+	if err == nil {
+		t.Fatal("validate() should fail")
+	}
+	for _, name := range []string{"CONSOLIDATION_THRESHOLD", "PFC_CONTROL_THRESHOLD", "BASAL_ALPHA", "LOG_LEVEL"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error should mention %s: %v", name, err)
+		}
+	}
+}
+
+func TestSlogLevel(t *testing.T) {
+	tests := []struct {
+		level string
+		debug bool
+		want  slog.Level
+	}{
+		{"info", false, slog.LevelInfo},
+		{"WARN", false, slog.LevelWarn},
+		{"error", false, slog.LevelError},
+		{"error", true, slog.LevelDebug}, // DEBUG_MODE が優先
+	}
+	for _, tt := range tests {
+		cfg := &Config{LogLevel: tt.level, DebugMode: tt.debug}
+		if got := cfg.SlogLevel(); got != tt.want {
+			t.Errorf("SlogLevel(%q, debug=%v) = %v, want %v", tt.level, tt.debug, got, tt.want)
+		}
+	}
+}
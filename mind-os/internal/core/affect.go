@@ -0,0 +1,34 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// コアアフェクトの動態
+const (
+	coreAffectBlendRate = 0.4              // 1回の刺激でコアアフェクトが刺激の VAD に近づく割合
+	coreAffectHalfLife  = 30 * time.Minute // 刺激がない場合に中立 (原点) へ戻る半減期
+)
+
+// updateCoreAffectLocked は刺激の VAD をコアアフェクトに反映する (呼び出し側でロックを保持していること)
+// 【神経科学的意味】コアアフェクトは個々の感情カテゴリーの背後にある持続的な快・覚醒・統制感の状態で、
+// 刺激のたびに少しずつ引き寄せられ、刺激がなければ中立へ戻っていく
+// 【数式】CoreAffect = CoreAffect + (Stimulus - CoreAffect) * BlendRate
+func (b *Brain) updateCoreAffectLocked(stimulus models.Affect, now time.Time) {
+	b.decayCoreAffectLocked(now)
+	delta := stimulus.Add(b.coreAffect.Scale(-1)).Scale(coreAffectBlendRate)
+	b.coreAffect = b.coreAffect.Add(delta).Clamp()
+}
+
+// decayCoreAffectLocked は経過時間に応じてコアアフェクトを中立へ戻す (呼び出し側でロックを保持していること)
+// 【数式】CoreAffect = CoreAffect * 0.5^(経過時間 / 半減期)
+func (b *Brain) decayCoreAffectLocked(now time.Time) {
+	elapsed := now.Sub(b.coreAffectAt)
+	if elapsed > 0 {
+		b.coreAffect = b.coreAffect.Scale(math.Pow(0.5, float64(elapsed)/float64(coreAffectHalfLife)))
+	}
+	b.coreAffectAt = now
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -13,17 +14,26 @@ import (
 	"github.com/umekku/mind-os/internal/cortex"
 	"github.com/umekku/mind-os/internal/hippocampus"
 	"github.com/umekku/mind-os/internal/hypothalamus"
+	"github.com/umekku/mind-os/internal/models"
+	"github.com/umekku/mind-os/internal/personality"
 	"github.com/umekku/mind-os/internal/pfc"
 	"github.com/umekku/mind-os/internal/store"
 	"github.com/umekku/mind-os/internal/thalamus"
 )
 
+// ErrBrainClosed は Close 済みの脳を操作しようとした場合のエラー
+// Registry が退避・削除した脳への参照を保持していた呼び出し側は、Registry から取得し直すこと
+var ErrBrainClosed = errors.New("brain is closed")
+
 // Brain は脳全体を統合する構造体
 // 【役割】各脳機能モジュール（扁桃体、海馬、前頭前皮質など）の保持とライフサイクル管理
 // 【構造】スレッドセーフな操作のためのMutexと、各サブシステムへのポインタを持つ
 type Brain struct {
 	mu sync.RWMutex
 
+	ID      string // 脳ID (テナント識別子)
+	Profile string // パーソナリティプロファイル名
+
 	// 脳の構成体
 	Amygdala     *amygdala.Amygdala        // 扁桃体 - 反射的感情生成
 	Hippocampus  *hippocampus.Hippocampus  // 海馬 - 記憶管理（STM/LTM）
@@ -36,36 +46,45 @@ type Brain struct {
 	Broca        *cortex.BrocaArea         // ブローカ野 - 言語生成
 
 	// インフラ
-	DB *store.DB // データベース接続
+	DB     *store.DB // データベース接続
+	Events *EventBus // 状態変化の通知 (SSE などの購読者向け)
+
+	// 感情ごとの感受性 (プロファイルで指定された係数, 指定がない感情は 1)
+	sensitivity map[models.EmotionCode]float64
+
+	// コアアフェクト (VAD) と最後に更新・減衰させた時刻
+	coreAffect   models.Affect
+	coreAffectAt time.Time
+
+	// 自律活動の記録 (Scheduler が参照する)
+	lastStimulus time.Time // 最後に感覚入力を受けた時刻
+	lastDecay    time.Time // 最後に時間経過による減衰を適用した時刻
+	lastSleep    time.Time // 最後に睡眠(記憶の固定化)を行った時刻
+	lastDaydream time.Time // 最後にマインドワンダリングを行った時刻
+	closed       bool      // Close 済みか (以降の入力・状態の変更・永続化は行わない)
+
+	// 最後に通知した状態 (変化がない場合は通知しない)
+	lastPublished BrainState
+	published     bool
 }
 
 // New は新しい Brain インスタンスを作成
 // 【処理内容】
 // 1. データベース接続の確立
-// 2. configに基づく各脳機能モジュールの初期化
+// 2. configに基づく各脳機能モジュールの初期化 (組み込みの default プロファイル)
 // 3. 依存関係の注入
 func New(cfg *config.Config) *Brain {
-	dbPath := cfg.DBPath
-	if dbPath == "" {
-		dbPath = "mind.db"
-	}
-
-	// 絶対パスに変換（ディレクトリ作成のため）
-	absPath, err := filepath.Abs(dbPath)
-	if err != nil {
-		slog.Warn("Failed to resolve absolute path", "error", err)
-		absPath = dbPath
-	}
-
-	db, err := store.NewDB(absPath)
-	if err != nil {
-		slog.Warn("Failed to initialize DB. Running in memory-only mode.", "error", err)
-		db = nil
-	} else {
-		slog.Info("Connected to mind database", "path", dbPath)
-	}
+	return NewWithStore(store.DefaultBrainID, personality.FromConfig(cfg), nil, cfg, openStore(cfg))
+}
 
-	am, err := amygdala.New()
+// NewWithStore は既存のデータベース接続を用いて Brain インスタンスを作成
+// 【用途】Registry から脳IDごとに絞り込んだ store を注入して複数の脳を生成する
+// db が nil の場合はメモリのみモード、lexicon が nil の場合は組み込みの感情辞書で動作する
+// 扁桃体は感情辞書のうち profile と同じ名前のキャラクター別の辞書レイヤーを参照する
+// 気質に関わるパラメータ (ホルモン・意欲・理性・共感・順応・感受性・口調) は profile から、
+// 記憶容量や概日リズムは cfg から設定する (値の範囲は読み込み時に検証済み)
+func NewWithStore(id string, profile personality.Profile, lexicon *amygdala.Lexicon, cfg *config.Config, db *store.DB) *Brain {
+	am, err := amygdala.New(amygdala.WithLexicon(lexicon), amygdala.WithCharacter(profile.Name))
 	if err != nil {
 		slog.Error("Failed to initialize Amygdala", "error", err)
 		os.Exit(1)
@@ -79,36 +98,151 @@ func New(cfg *config.Config) *Brain {
 
 	// 起動時の初期化ログ
 	slog.Info("Brain initializing modules",
+		"brain_id", id,
+		"profile", profile.Name,
 		"STM_MAX", cfg.STMMaxSize,
 		"LTM_MAX", cfg.LTMMaxSize,
 		"THRESHOLD", cfg.ConsolidationThreshold,
 	)
+	slog.Debug("Brain module parameters",
+		"brain_id", id,
+		"profile", profile.Name,
+		"hormone_decay_rate", profile.Hormones.DecayRate,
+		"baseline_cortisol", profile.Hormones.BaselineCortisol,
+		"baseline_oxytocin", profile.Hormones.BaselineOxytocin,
+		"day_time_start", cfg.DayTimeStart,
+		"night_time_start", cfg.NightTimeStart,
+		"pfc_initial_sanity", profile.Sanity.Initial,
+		"pfc_control_threshold", profile.Sanity.ControlThreshold,
+		"pfc_negative_suppression", profile.Sanity.NegativeSuppression,
+		"pfc_positive_boost", profile.Sanity.PositiveBoost,
+		"basal_baseline", profile.Motivation.Baseline,
+		"basal_alpha", profile.Motivation.Alpha,
+		"basal_beta", profile.Motivation.Beta,
+		"basal_decay_rate", profile.Motivation.DecayRate,
+	)
 
 	// 海馬の初期化
-	hc := hippocampus.New(db)
+	curve := store.DefaultForgettingCurve()
+	curve.BaseStability = time.Duration(cfg.MemoryStabilityHours * float64(time.Hour))
+	curve.Floor = cfg.MemoryForgetFloor
+	hc := hippocampus.New(db,
+		hippocampus.WithForgettingCurve(curve),
+		hippocampus.WithSTMMaxSize(cfg.STMMaxSize),
+		hippocampus.WithLTMMaxSize(cfg.LTMMaxSize),
+		hippocampus.WithConsolidationThreshold(cfg.ConsolidationThreshold),
+	)
+
+	// 大脳基底核 (意欲)・前頭前皮質 (理性)・視床下部 (ホルモン) の初期化
+	bg := basal.New(
+		basal.WithBaseline(profile.Motivation.Baseline),
+		basal.WithLearningRates(profile.Motivation.Alpha, profile.Motivation.Beta),
+		basal.WithDecayRate(profile.Motivation.DecayRate),
+	)
+	prefrontal := pfc.New(
+		pfc.WithInitialSanity(profile.Sanity.Initial),
+		pfc.WithControlThreshold(profile.Sanity.ControlThreshold),
+		pfc.WithSuppressionFactors(profile.Sanity.NegativeSuppression, profile.Sanity.PositiveBoost),
+	)
+	homeostasis := hypothalamus.NewHomeostasis(
+		hypothalamus.WithBaseline(profile.Hormones.BaselineCortisol, profile.Hormones.BaselineOxytocin),
+		hypothalamus.WithDecayRate(profile.Hormones.DecayRate),
+		hypothalamus.WithDayNight(cfg.DayTimeStart, cfg.NightTimeStart),
+	)
 
-	return &Brain{
+	// 視床 (順応)・ミラーニューロン (共感)・ブローカ野 (口調) の初期化
+	th := thalamus.New(thalamus.WithSimilarityThreshold(profile.Habituation.SimilarityThreshold))
+	mirror := cortex.New(am, cortex.WithEmpathy(profile.Empathy.Initial, profile.Empathy.Min, profile.Empathy.Max))
+	broca := cortex.NewBrocaArea(cortex.WithTemplates(models.LanguageJapanese, profile.Templates))
+
+	now := time.Now()
+	b := &Brain{
+		ID:           id,
+		Profile:      profile.Name,
 		Amygdala:     am,
 		Hippocampus:  hc,
-		BasalGanglia: basal.New(),
-		PFC:          pfc.New(),
-		Hypothalamus: hypothalamus.NewHomeostasis(), // ホルモン減衰率・内部定数使用
-		Thalamus:     thalamus.New(),
-		Mirror:       cortex.New(am),
+		BasalGanglia: bg,
+		PFC:          prefrontal,
+		Hypothalamus: homeostasis,
+		Thalamus:     th,
+		Mirror:       mirror,
 		Wernicke:     wernicke,
-		Broca:        cortex.NewBrocaArea(),
+		Broca:        broca,
 		DB:           db,
+		Events:       NewEventBus(DefaultEventBuffer),
+		sensitivity:  profile.EmotionSensitivity,
+		coreAffectAt: now,
+		lastStimulus: now,
+		lastDecay:    now,
 	}
+
+	// 前回終了時の神経化学的状態を復元
+	b.restoreState()
+
+	return b
+}
+
+// openStore は設定に基づいてデータベース接続を確立する
+// 接続に失敗した場合は nil を返し、メモリのみモードで動作させる
+func openStore(cfg *config.Config) *store.DB {
+	dbPath := cfg.DBPath
+	if dbPath == "" {
+		dbPath = "mind.db"
+	}
+
+	// 絶対パスに変換（ディレクトリ作成のため）
+	absPath, err := filepath.Abs(dbPath)
+	if err != nil {
+		slog.Warn("Failed to resolve absolute path", "error", err)
+		absPath = dbPath
+	}
+
+	db, err := store.NewDB(absPath)
+	if err != nil {
+		slog.Warn("Failed to initialize DB. Running in memory-only mode.", "error", err)
+		return nil
+	}
+
+	slog.Info("Connected to mind database", "path", dbPath)
+	return db
 }
 
 // Close はリソースを解放
-// 【処理内容】データベース接続などのクリーンアップを行う
+// 【処理内容】睡眠処理で短期記憶を長期記憶へ固定化し、最終状態のスナップショットを保存してから
+// データベース接続などのクリーンアップを行う。2回目以降の呼び出しは何もしない
 func (b *Brain) Close() error {
+	return b.close(true)
+}
+
+// close は Brain を閉じる
+// consolidate が false の場合は睡眠処理を行わない (削除される脳など)
+func (b *Brain) close(consolidate bool) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	// 終了時に長期記憶への保存（睡眠処理）を行うのが望ましい
-	// b.Sleep()
+	if b.closed {
+		return nil
+	}
+
+	// 終了前の睡眠: 短期記憶を長期記憶へ固定化
+	// (最終状態の保存を終えてから closed にする。以降は状態の変更も保存も行わない)
+	if consolidate {
+		result := b.sleepLocked()
+		slog.Info("Brain consolidated before close",
+			"brain_id", b.ID,
+			"consolidated", result.ConsolidatedCount,
+			"forgotten", result.ForgottenCount,
+			"stm_count", result.STMCount,
+			"ltm_count", result.LTMCount,
+		)
+	} else {
+		// 最終状態を保存
+		b.persistStateLocked()
+	}
+	b.closed = true
+
+	// 購読者へのストリームを終了
+	b.Events.Close()
 
 	if b.DB != nil {
 		if err := b.DB.Close(); err != nil {
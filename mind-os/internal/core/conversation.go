@@ -0,0 +1,119 @@
+package core
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/umekku/mind-os/internal/cortex"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+const (
+	// conversationHistorySize は会話に保持するターン数
+	conversationHistorySize = 20
+
+	// conversationTopicLimit は文脈として言語野に渡す話題の最大数
+	conversationTopicLimit = 5
+)
+
+// Speaker は発話者
+type Speaker string
+
+const (
+	SpeakerUser  Speaker = "user"  // 相手の発話・刺激
+	SpeakerBrain Speaker = "brain" // 脳の応答
+)
+
+// Turn は会話の1ターン
+type Turn struct {
+	Speaker  Speaker
+	Type     models.SignalType // 相手の入力の種類 (chat / physical)
+	Text     string
+	Concepts []string // ウェルニッケ野が抽出した概念 (文脈による補完後)
+	Intent   string
+	Time     time.Time
+}
+
+// Conversation は1つの会話セッションの文脈
+// 【神経科学的意味】ワーキングメモリ上に保持される会話の流れ。
+// 指示語や省略の理解（ウェルニッケ野）、同じ応答の繰り返しの回避（ブローカ野）、
+// 同じ話の繰り返しへの慣れ（視床）に用いる
+// 【注意】ゴルーチン間で共有しないこと (1つの接続から順に使う)
+type Conversation struct {
+	ID        string
+	StartedAt time.Time
+
+	turns     []Turn // 直近のターン (古い順)
+	turnCount int    // 開始からの相手の発話数
+}
+
+// NewConversation は新しい会話を開始する
+func NewConversation() *Conversation {
+	return &Conversation{
+		ID:        uuid.NewString(),
+		StartedAt: time.Now(),
+	}
+}
+
+// Turns は直近のターンを古い順に返す
+func (c *Conversation) Turns() []Turn {
+	return slices.Clone(c.turns)
+}
+
+// TurnCount は開始からの相手の発話数を返す
+func (c *Conversation) TurnCount() int {
+	return c.turnCount
+}
+
+// record はターンを履歴に追加する
+func (c *Conversation) record(turn Turn) {
+	if turn.Speaker == SpeakerUser {
+		c.turnCount++
+	}
+	c.turns = append(c.turns, turn)
+	if len(c.turns) > conversationHistorySize {
+		c.turns = c.turns[len(c.turns)-conversationHistorySize:]
+	}
+}
+
+// utterances は相手の会話入力のテキストを古い順に返す (視床の順応判定用)
+func (c *Conversation) utterances() []string {
+	texts := make([]string, 0, len(c.turns))
+	for _, t := range c.turns {
+		if t.Speaker == SpeakerUser && t.Type == models.SignalChat {
+			texts = append(texts, t.Text)
+		}
+	}
+	return texts
+}
+
+// discourse は言語野に渡す会話の文脈を返す
+func (c *Conversation) discourse() cortex.Discourse {
+	var d cortex.Discourse
+	for i := len(c.turns) - 1; i >= 0; i-- {
+		t := c.turns[i]
+		if t.Speaker == SpeakerBrain && d.LastReply == "" {
+			d.LastReply = t.Text
+		}
+		for _, concept := range t.Concepts {
+			if len(d.Topics) < conversationTopicLimit && !slices.Contains(d.Topics, concept) {
+				d.Topics = append(d.Topics, concept)
+			}
+		}
+	}
+	return d
+}
+
+// Converse は会話の文脈を踏まえて入力を処理し、やり取りを会話の履歴に記録する
+// 【処理内容】ProcessInput と同じパイプラインで、視床・ウェルニッケ野・ブローカ野に会話の履歴を渡す
+// Close 済みの脳は入力を処理せず ErrBrainClosed を返す
+func (b *Brain) Converse(conv *Conversation, input models.SensoryInput) (models.MindStateResponse, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return models.MindStateResponse{}, ErrBrainClosed
+	}
+	return b.processInputLocked(input, conv), nil
+}
@@ -6,18 +6,63 @@ import (
 	"github.com/umekku/mind-os/internal/models"
 )
 
+// 気分傾向 (マインドワンダリングで想起される記憶の偏りを決める)
+const (
+	MoodPositive = "positive" // ポジティブな記憶を思い出しやすい
+	MoodNegative = "negative" // ネガティブな記憶を思い出しやすい (抑うつ的反芻)
+	MoodNeutral  = "neutral"  // 偏りなし
+)
+
+// DaydreamOptions はデイドリームの実行条件
+type DaydreamOptions struct {
+	Duration time.Duration // 非アクティブ時間 (1時間ごとに1件、最大5件を回想)
+	Seed     *int64        // 記憶選択の乱数シード (nil の場合はランダム)
+	MoodBias string        // 気分傾向の上書き (空の場合は理性値と意欲から判定)
+}
+
+// HormoneDelta は反芻によるホルモンの変化量
+type HormoneDelta struct {
+	Cortisol float64
+	Oxytocin float64
+}
+
+// Recollection はマインドワンダリングで想起された1件の記憶
+type Recollection struct {
+	Memory         models.RuneMemory     // 再固定化後の記憶
+	Summary        string                // 思考ログ用の要約
+	EmotionsBefore []models.EmotionValue // 再固定化前の感情
+	WeightBefore   float64               // 再固定化前の重み
+	HormoneDelta   HormoneDelta          // この記憶の反芻によるホルモン変化
+}
+
+// DaydreamResult はデイドリームの結果
+type DaydreamResult struct {
+	Log           string                   // 思考ログ (テキスト)
+	MoodTendency  string                   // 記憶選択に使用した気分傾向
+	Seed          int64                    // 記憶選択に使用した乱数シード (再現用)
+	Recollections []Recollection           // 想起された記憶 (想起順)
+	HormoneDelta  HormoneDelta             // 反芻全体によるホルモン変化
+	State         models.MindStateResponse // デイドリーム後の脳の状態
+}
+
 // Daydream はデイドリーム（白昼夢）処理を実行
-// 指定された時間分、マインドワンダリングを行い、結果をレスポンスに含める
-func (b *Brain) Daydream(duration time.Duration) models.MindStateResponse {
+// 指定された時間分、マインドワンダリングを行い、想起した記憶と脳の状態を返す
+// Close 済みの脳は ErrBrainClosed を返す
+func (b *Brain) Daydream(opts DaydreamOptions) (DaydreamResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return DaydreamResult{}, ErrBrainClosed
+	}
+
 	// マインドワンダリングを実行
-	daydreamLog := b.WanderMind(duration)
+	result := b.wanderMindLocked(opts)
 
 	// 現在の状態を取得してレスポンスを生成
 	// 空の感情で generateMindState を呼び出す
-	response := b.generateMindState([]models.EmotionValue{})
-
-	// DaydreamLogを追加
-	response.DaydreamLog = daydreamLog
+	result.State = b.generateMindState([]models.EmotionValue{})
+	result.State.DaydreamLog = result.Log
 
-	return response
+	return result, nil
 }
@@ -10,14 +10,23 @@ import (
 )
 
 // WanderMind はデフォルトモードネットワーク(DMN)による自発的思考
-// 非アクティブ時間中に記憶を回想し、感情・気分に影響を与える
+// 非アクティブ時間中に記憶を回想し、感情・気分に影響を与える (Close 済みの脳は何もしない)
 func (b *Brain) WanderMind(duration time.Duration) string {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.closed {
+		return ""
+	}
+
+	return b.wanderMindLocked(DaydreamOptions{Duration: duration}).Log
+}
+
+// wanderMindLocked はマインドワンダリングの本体 (呼び出し側でロックを保持していること)
+func (b *Brain) wanderMindLocked(opts DaydreamOptions) DaydreamResult {
 	// 経過時間に基づいて回想する記憶の数を決定
 	// 1時間ごとに1件、最大5件
-	hours := int(duration.Hours())
+	hours := int(opts.Duration.Hours())
 	recallCount := hours
 	if recallCount > 5 {
 		recallCount = 5
@@ -26,13 +35,24 @@ func (b *Brain) WanderMind(duration time.Duration) string {
 		recallCount = 1
 	}
 
+	seed := time.Now().UnixNano()
+	if opts.Seed != nil {
+		seed = *opts.Seed
+	}
+	result := DaydreamResult{Seed: seed, Recollections: []Recollection{}}
+	b.lastDaydream = time.Now()
+
 	// 記憶が存在しない場合は何もしない
 	if b.Hippocampus == nil {
-		return "（記憶がないため、何も思い出せなかった）"
+		result.Log = "（記憶がないため、何も思い出せなかった）"
+		return result
 	}
 
-	// 現在の気分状態を取得
-	currentMood := b.getCurrentMoodTendency()
+	// 現在の気分状態を取得 (指定があれば上書き)
+	result.MoodTendency = opts.MoodBias
+	if result.MoodTendency == "" {
+		result.MoodTendency = b.getCurrentMoodTendency()
+	}
 
 	// 現在の感情状態を取得（再固定化用）
 	// PFCから最近の感情を推定
@@ -42,25 +62,49 @@ func (b *Brain) WanderMind(duration time.Duration) string {
 	var thoughtLog strings.Builder
 	thoughtLog.WriteString("【マインドワンダリング】\n")
 
-	// 記憶を回想（再固定化を適用）
-	memories := b.recallMemoriesWithReconsolidation(recallCount, currentMood, currentEmotions)
+	// 記憶を選択
+	rng := rand.New(rand.NewSource(seed))
+	memories := b.selectMemoriesForRecall(rng, recallCount, result.MoodTendency)
 
 	if len(memories) == 0 {
 		thoughtLog.WriteString("（まだ記憶が形成されていない...）")
-		return thoughtLog.String()
+		result.Log = thoughtLog.String()
+		return result
 	}
 
 	// 各記憶について反芻
 	for i, memory := range memories {
+		recollection := Recollection{
+			EmotionsBefore: memory.Emotions,
+			WeightBefore:   memory.Weight,
+		}
+
+		// 想起により記憶が不安定化し、現在の感情で再固定化される
+		b.Hippocampus.ReconsolidateMemory(&memory, currentEmotions)
+
 		// 記憶の内容を要約
-		summary := summarizeMemory(memory)
-		thoughtLog.WriteString(fmt.Sprintf("%d. %s\n", i+1, summary))
+		recollection.Summary = summarizeMemory(memory)
+		thoughtLog.WriteString(fmt.Sprintf("%d. %s\n", i+1, recollection.Summary))
 
 		// 記憶の感情価を現在の状態に微量加算（反芻効果）
-		b.ruminateOnMemory(memory)
+		recollection.HormoneDelta = b.ruminateOnMemory(memory)
+		recollection.Memory = memory
+
+		result.HormoneDelta.Cortisol += recollection.HormoneDelta.Cortisol
+		result.HormoneDelta.Oxytocin += recollection.HormoneDelta.Oxytocin
+		result.Recollections = append(result.Recollections, recollection)
+	}
+
+	thoughts := make([]string, len(result.Recollections))
+	for i, r := range result.Recollections {
+		thoughts[i] = r.Summary
 	}
 
-	return thoughtLog.String()
+	b.persistStateLocked()
+	b.publishLocked(EventDaydream, thoughts...)
+
+	result.Log = thoughtLog.String()
+	return result
 }
 
 // getCurrentMoodTendency は現在の気分傾向を取得
@@ -71,11 +115,11 @@ func (b *Brain) getCurrentMoodTendency() string {
 	motivation := b.BasalGanglia.GetMotivation()
 
 	if sanity < 30 || motivation < 30 {
-		return "negative" // 抑うつ的
+		return MoodNegative // 抑うつ的
 	} else if sanity > 70 && motivation > 70 {
-		return "positive" // ポジティブ
+		return MoodPositive // ポジティブ
 	}
-	return "neutral" // 中立
+	return MoodNeutral // 中立
 }
 
 // weightedMemory は重み付き記憶
@@ -93,7 +137,7 @@ func calculateMemoryWeight(memory models.RuneMemory, moodTendency string) float6
 	emotionBonus := 0.0
 	for _, emotion := range memory.Emotions {
 		switch moodTendency {
-		case "negative":
+		case MoodNegative:
 			// ネガティブな気分の時はネガティブな記憶を思い出しやすい
 			if emotion.Code == models.EmotionAnger ||
 				emotion.Code == models.EmotionFear ||
@@ -101,7 +145,7 @@ func calculateMemoryWeight(memory models.RuneMemory, moodTendency string) float6
 				emotion.Code == models.EmotionGrief {
 				emotionBonus += float64(emotion.Value) * 0.02
 			}
-		case "positive":
+		case MoodPositive:
 			// ポジティブな気分の時はポジティブな記憶を思い出しやすい
 			if emotion.Code == models.EmotionJoy ||
 				emotion.Code == models.EmotionLove ||
@@ -115,7 +159,7 @@ func calculateMemoryWeight(memory models.RuneMemory, moodTendency string) float6
 }
 
 // weightedRandomSelection は重み付きランダム選択
-func weightedRandomSelection(weighted []weightedMemory, count int) []models.RuneMemory {
+func weightedRandomSelection(rng *rand.Rand, weighted []weightedMemory, count int) []models.RuneMemory {
 	if len(weighted) == 0 {
 		return nil
 	}
@@ -134,7 +178,7 @@ func weightedRandomSelection(weighted []weightedMemory, count int) []models.Rune
 	if totalWeight <= 0 {
 		// 重みがない場合はランダムに選択
 		selected := make([]models.RuneMemory, 0, count)
-		indices := rand.Perm(len(weighted))
+		indices := rng.Perm(len(weighted))
 		for i := 0; i < count; i++ {
 			selected = append(selected, weighted[indices[i]].memory)
 		}
@@ -148,7 +192,7 @@ func weightedRandomSelection(weighted []weightedMemory, count int) []models.Rune
 
 	for i := 0; i < count && len(remaining) > 0; i++ {
 		// ルーレット選択
-		r := rand.Float64() * totalWeight
+		r := rng.Float64() * totalWeight
 		cumulative := 0.0
 
 		for j, w := range remaining {
@@ -167,7 +211,10 @@ func weightedRandomSelection(weighted []weightedMemory, count int) []models.Rune
 }
 
 // ruminateOnMemory は記憶を反芻し、現在の感情状態に影響を与える
-func (b *Brain) ruminateOnMemory(memory models.RuneMemory) {
+// ホルモンの変化量 (上下限による頭打ちを考慮した実際の差分) を返す
+func (b *Brain) ruminateOnMemory(memory models.RuneMemory) HormoneDelta {
+	cortisolBefore, oxytocinBefore := b.Hypothalamus.GetStatus()
+
 	// 記憶の感情を微量（10%）だけ現在の状態に加算
 	for _, emotion := range memory.Emotions {
 		ruminationValue := int(float64(emotion.Value) * 0.1)
@@ -182,14 +229,20 @@ func (b *Brain) ruminateOnMemory(memory models.RuneMemory) {
 			b.Hypothalamus.Update(0, float64(ruminationValue))
 		}
 	}
+
+	cortisol, oxytocin := b.Hypothalamus.GetStatus()
+	return HormoneDelta{
+		Cortisol: cortisol - cortisolBefore,
+		Oxytocin: oxytocin - oxytocinBefore,
+	}
 }
 
 // summarizeMemory は記憶を要約
 func summarizeMemory(memory models.RuneMemory) string {
-	// テキストの最初30文字を取得
+	// テキストの最初30文字を取得 (マルチバイト文字を途中で切らないよう rune 単位で数える)
 	text := memory.Text
-	if len(text) > 30 {
-		text = text[:30] + "..."
+	if runes := []rune(text); len(runes) > 30 {
+		text = string(runes[:30]) + "..."
 	}
 
 	// 主要な感情を取得
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"math/rand"
+
 	"github.com/umekku/mind-os/internal/models"
 )
 
@@ -21,10 +23,10 @@ func (b *Brain) getCurrentEmotions() []models.EmotionValue {
 	return recentMemories[0].Emotions
 }
 
-// recallMemoriesWithReconsolidation は記憶を回想し、再固定化を適用
-func (b *Brain) recallMemoriesWithReconsolidation(count int, moodTendency string, currentEmotions []models.EmotionValue) []models.RuneMemory {
-	// Hippocampusから全記憶を取得（再固定化適用）
-	allMemories := b.Hippocampus.GetRecentContextWithReconsolidation(currentEmotions)
+// selectMemoriesForRecall は気分に応じた重み付き抽選で回想する記憶を選ぶ
+// 再固定化は選ばれた記憶にのみ、想起の時点で適用する
+func (b *Brain) selectMemoriesForRecall(rng *rand.Rand, count int, moodTendency string) []models.RuneMemory {
+	allMemories := b.Hippocampus.GetRecentContext()
 
 	if len(allMemories) == 0 {
 		return nil
@@ -41,7 +43,7 @@ func (b *Brain) recallMemoriesWithReconsolidation(count int, moodTendency string
 	}
 
 	// 重み付き抽選で記憶を選抜
-	selected := weightedRandomSelection(weightedMemories, count)
+	selected := weightedRandomSelection(rng, weightedMemories, count)
 
 	return selected
 }
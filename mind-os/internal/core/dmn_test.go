@@ -0,0 +1,39 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+func TestSummarizeMemory(t *testing.T) {
+	joy := []models.EmotionValue{{Code: models.EmotionJoy, Value: 80}}
+
+	tests := []struct {
+		name     string
+		text     string
+		emotions []models.EmotionValue
+		want     string
+	}{
+		{"短い日本語", "今日は楽しかった", joy, "「今日は楽しかった」を思い出した（喜び）"},
+		{"30文字ちょうど", strings.Repeat("あ", 30), nil, "「" + strings.Repeat("あ", 30) + "」を思い出した（中立）"},
+		// 3バイトの文字を30バイトで切ると文字が壊れる
+		{"30文字を超える日本語", strings.Repeat("あ", 31), nil, "「" + strings.Repeat("あ", 30) + "...」を思い出した（中立）"},
+		{"英語と日本語の混在", "I went to 東京タワー with my friends and it was wonderful", joy, "「I went to 東京タワー with my friend...」を思い出した（喜び）"},
+		{"絵文字", strings.Repeat("😊", 40), joy, "「" + strings.Repeat("😊", 30) + "...」を思い出した（喜び）"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarizeMemory(models.RuneMemory{Text: tt.text, Emotions: tt.emotions})
+			if !utf8.ValidString(got) {
+				t.Fatalf("summarizeMemory(%q) = %q, not valid UTF-8", tt.text, got)
+			}
+			if got != tt.want {
+				t.Errorf("summarizeMemory(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
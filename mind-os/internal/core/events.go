@@ -0,0 +1,168 @@
+package core
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultEventBuffer は購読者ごとのイベントバッファの既定サイズ
+const DefaultEventBuffer = 32
+
+// EventType は状態変化を引き起こした処理の種類
+type EventType string
+
+const (
+	EventSensoryInput EventType = "sensory_input" // 感覚入力の処理
+	EventSleep        EventType = "sleep"         // 睡眠 (記憶の固定化)
+	EventStress       EventType = "stress"        // ストレスの適用
+	EventRest         EventType = "rest"          // 休息
+	EventFeedback     EventType = "feedback"      // 報酬/罰のフィードバック
+	EventMotivation   EventType = "motivation"    // 感情報酬・意欲のリセット
+	EventDecay        EventType = "decay"         // 時間経過による減衰
+	EventDaydream     EventType = "daydream"      // マインドワンダリング
+	EventMemory       EventType = "memory"        // 記憶の追加・編集・削除
+)
+
+// BrainEvent は脳の状態変化の通知
+type BrainEvent struct {
+	ID      uint64     // 脳ごとの通し番号
+	Type    EventType  // 状態変化を引き起こした処理
+	BrainID string     // 脳ID
+	Time    time.Time  // 発生時刻
+	State   BrainState // 変化後の状態
+
+	Thoughts []string // 自発的に浮かんだ考え (マインドワンダリングで回想した記憶の要約など)
+}
+
+// EventBus は脳の状態変化を購読者に配信する
+// 【設計】配信はノンブロッキング。購読者ごとにバッファを持ち、バッファが溢れた (処理が追いつかない) 購読者は
+// 切断して他の購読者や脳の処理を遅延させない
+type EventBus struct {
+	mu     sync.Mutex
+	subs   map[*Subscription]struct{}
+	buffer int
+	seq    uint64
+	closed bool
+}
+
+// Subscription はイベントの購読
+type Subscription struct {
+	ch      chan BrainEvent
+	bus     *EventBus
+	dropped bool // bus.mu で保護
+}
+
+// NewEventBus は新しい EventBus を作成
+// buffer は購読者ごとに保持できる未処理イベントの数
+func NewEventBus(buffer int) *EventBus {
+	if buffer < 1 {
+		buffer = DefaultEventBuffer
+	}
+	return &EventBus{
+		subs:   make(map[*Subscription]struct{}),
+		buffer: buffer,
+	}
+}
+
+// Subscribe はイベントの購読を開始する
+// 不要になったら Close を呼ぶこと。バスが閉じている場合はすでに閉じた購読を返す
+func (bus *EventBus) Subscribe() *Subscription {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	sub := &Subscription{ch: make(chan BrainEvent, bus.buffer), bus: bus}
+	if bus.closed {
+		close(sub.ch)
+		return sub
+	}
+	bus.subs[sub] = struct{}{}
+	return sub
+}
+
+// Publish はイベントに通し番号を付けて全ての購読者に配信する
+func (bus *EventBus) Publish(e BrainEvent) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	if bus.closed {
+		return
+	}
+
+	bus.seq++
+	e.ID = bus.seq
+
+	for sub := range bus.subs {
+		select {
+		case sub.ch <- e:
+		default:
+			// 処理が追いつかない購読者は切断する
+			sub.dropped = true
+			bus.removeLocked(sub)
+			slog.Warn("Dropped slow event subscriber", "brain_id", e.BrainID, "buffer", bus.buffer)
+		}
+	}
+}
+
+// Close は全ての購読を終了し、以降の配信を停止する
+func (bus *EventBus) Close() {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	if bus.closed {
+		return
+	}
+	bus.closed = true
+	for sub := range bus.subs {
+		bus.removeLocked(sub)
+	}
+}
+
+// removeLocked は購読者を取り除き、チャネルを閉じる (bus.mu を保持した状態で呼ぶこと)
+func (bus *EventBus) removeLocked(sub *Subscription) {
+	if _, ok := bus.subs[sub]; !ok {
+		return
+	}
+	delete(bus.subs, sub)
+	close(sub.ch)
+}
+
+// Events はイベントを受信するチャネルを返す
+// 購読の終了 (Close・切断・バスの終了) によりチャネルは閉じられる
+func (s *Subscription) Events() <-chan BrainEvent {
+	return s.ch
+}
+
+// Dropped は処理が追いつかずに切断されたかを返す
+func (s *Subscription) Dropped() bool {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	return s.dropped
+}
+
+// Close は購読を終了する
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.removeLocked(s)
+}
+
+// publishLocked は状態が前回の通知から変化していればイベントを配信する (b.mu を保持した状態で呼ぶこと)
+// 考え (thoughts) を伴う場合は状態が変化していなくても配信する
+func (b *Brain) publishLocked(eventType EventType, thoughts ...string) {
+	state := b.stateLocked()
+	if b.published && state == b.lastPublished && len(thoughts) == 0 {
+		return
+	}
+	b.published = true
+	b.lastPublished = state
+
+	b.Events.Publish(BrainEvent{
+		Type:    eventType,
+		BrainID: b.ID,
+		Time:    time.Now(),
+		State:   state,
+
+		Thoughts: thoughts,
+	})
+}
@@ -0,0 +1,138 @@
+package core
+
+import "testing"
+
+// receive はバッファ済みのイベントを全て受け取る (チャネルが閉じていれば closed を返す)
+func receive(sub *Subscription) (events []BrainEvent, closed bool) {
+	for {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				return events, true
+			}
+			events = append(events, e)
+		default:
+			return events, false
+		}
+	}
+}
+
+func TestEventBus_DropSlowSubscriber(t *testing.T) {
+	bus := NewEventBus(2)
+	slow := bus.Subscribe()
+	fast := bus.Subscribe()
+
+	var received []BrainEvent
+	for i := 0; i < 5; i++ {
+		bus.Publish(BrainEvent{Type: EventDecay, BrainID: "alice"})
+		// fast は配信のたびに受け取る
+		events, closed := receive(fast)
+		if closed {
+			t.Fatalf("fast subscriber closed after %d events", i+1)
+		}
+		received = append(received, events...)
+	}
+
+	// バッファ (2件) を超えた3件目で slow を切断する
+	if !slow.Dropped() {
+		t.Error("slow.Dropped() = false, want true")
+	}
+	events, closed := receive(slow)
+	if len(events) != 2 || !closed {
+		t.Errorf("slow received %d events (closed %v), want 2 then closed", len(events), closed)
+	}
+
+	// 他の購読者には全て届き、通し番号は連続する
+	if fast.Dropped() {
+		t.Error("fast.Dropped() = true, want false")
+	}
+	if len(received) != 5 {
+		t.Fatalf("fast received %d events, want 5", len(received))
+	}
+	for i, e := range received {
+		if e.ID != uint64(i+1) {
+			t.Errorf("received[%d].ID = %d, want %d", i, e.ID, i+1)
+		}
+	}
+
+	// 購読側から閉じた場合は切断扱いにしない
+	fast.Close()
+	if _, closed := receive(fast); !closed || fast.Dropped() {
+		t.Errorf("after Close: closed = %v, Dropped() = %v, want true, false", closed, fast.Dropped())
+	}
+}
+
+func TestEventBus_Close(t *testing.T) {
+	bus := NewEventBus(4)
+	sub := bus.Subscribe()
+	bus.Publish(BrainEvent{Type: EventSleep})
+
+	bus.Close()
+
+	// 閉じる前に配信したイベントを受け取った後、チャネルは閉じている
+	events, closed := receive(sub)
+	if len(events) != 1 || !closed {
+		t.Errorf("received %d events (closed %v), want 1 then closed", len(events), closed)
+	}
+	if sub.Dropped() {
+		t.Error("Dropped() = true after bus Close, want false")
+	}
+
+	// 閉じた後の配信は何もしない
+	bus.Publish(BrainEvent{Type: EventSleep})
+	bus.Close()
+
+	// 閉じた後の購読はすでに閉じている
+	late := bus.Subscribe()
+	if events, closed := receive(late); len(events) != 0 || !closed {
+		t.Errorf("late subscriber received %d events (closed %v), want 0 and closed", len(events), closed)
+	}
+	sub.Close()
+	late.Close()
+}
+
+func TestBrain_PublishOnlyOnChange(t *testing.T) {
+	r := newTestRegistry(t, 10)
+	brain, err := r.Create("alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := brain.Events.Subscribe()
+	defer sub.Close()
+
+	publish := func(eventType EventType, thoughts ...string) []BrainEvent {
+		brain.mu.Lock()
+		brain.publishLocked(eventType, thoughts...)
+		brain.mu.Unlock()
+		events, _ := receive(sub)
+		return events
+	}
+
+	// 最初の通知は常に配信する
+	if got := publish(EventDecay); len(got) != 1 {
+		t.Fatalf("first publish delivered %d events, want 1", len(got))
+	}
+
+	// 状態が変わらなければ配信しない
+	if got := publish(EventDecay); len(got) != 0 {
+		t.Errorf("unchanged state delivered %+v, want none", got)
+	}
+
+	// 考えを伴う場合は状態が変わらなくても配信する
+	got := publish(EventDaydream, "昨日のこと")
+	if len(got) != 1 || got[0].Type != EventDaydream || len(got[0].Thoughts) != 1 {
+		t.Errorf("publish with thoughts delivered %+v, want one daydream event", got)
+	}
+
+	// 状態が変われば配信する
+	if err := brain.ApplyStress(80); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = receive(sub)
+	if len(got) != 1 || got[0].Type != EventStress {
+		t.Errorf("ApplyStress delivered %+v, want one stress event", got)
+	}
+	if got := publish(EventDecay); len(got) != 0 {
+		t.Errorf("unchanged state after stress delivered %+v, want none", got)
+	}
+}
@@ -26,7 +26,7 @@ func addEmotion(emotions *[]models.EmotionValue, code models.EmotionCode, value
 
 // generateMindState はマインドステートレスポンスを生成
 // 【役割】現在の脳の状態を統合してクライアント向けレスポンスを作成
-// 【処理内容】性格傾向、気分安定度、ホルモン状態、概日リズム効果を統合
+// 【処理内容】性格傾向、気分安定度、ホルモン状態、概日リズム効果、コアアフェクトを統合
 func (b *Brain) generateMindState(emotions []models.EmotionValue) models.MindStateResponse {
 	// 性格傾向を計算（直近の記憶から）
 	personalityBias := b.calculatePersonalityBias()
@@ -51,6 +51,8 @@ func (b *Brain) generateMindState(emotions []models.EmotionValue) models.MindSta
 		PersonalityBias: personalityBias,
 		Motivation:      cappedMotivation,
 		Sanity:          float64(b.PFC.GetSanity()) / 100.0,
+		CoreAffect:      b.coreAffect,
+		CoreEmotion:     b.coreAffect.Emotion(),
 		Cortisol:        cortisol,
 		Oxytocin:        oxytocin,
 		PredictedReward: predictedReward,
@@ -1,42 +1,58 @@
 package core
 
 import (
+	"time"
+
 	"github.com/umekku/mind-os/internal/models"
 )
 
 // Sleep は睡眠処理を実行（記憶の整理）
 // 【神経科学的意味】睡眠中に短期記憶を長期記憶に固定化し、不要な記憶を忘却
-// 【処理内容】Hippocampusの記憶固定化プロセスを実行
-func (b *Brain) Sleep() SleepResult {
+// 【処理内容】Hippocampusの記憶固定化プロセスと、長期記憶への忘却曲線の適用を実行
+// Close 済みの脳は ErrBrainClosed を返す
+func (b *Brain) Sleep() (SleepResult, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	stmCountBefore := b.Hippocampus.GetSTMCount()
-	ltmCountBefore := b.Hippocampus.GetLTMCount()
-
-	// 海馬: 記憶の固定化
-	b.Hippocampus.SleepAndConsolidate()
+	if b.closed {
+		return SleepResult{}, ErrBrainClosed
+	}
+	return b.sleepLocked(), nil
+}
 
-	ltmCountAfter := b.Hippocampus.GetLTMCount()
+// sleepLocked は睡眠処理の本体 (呼び出し側でロックを保持していること)
+func (b *Brain) sleepLocked() SleepResult {
+	// 海馬: 記憶の固定化と忘却
+	report := b.Hippocampus.SleepAndConsolidate()
+	b.lastSleep = time.Now()
 
-	consolidated := ltmCountAfter - ltmCountBefore
-	forgotten := stmCountBefore - consolidated
+	b.persistStateLocked()
+	b.publishLocked(EventSleep)
 
-	return SleepResult{
-		ConsolidatedCount: consolidated,
-		ForgottenCount:    forgotten,
+	result := SleepResult{
+		ConsolidatedCount: report.Consolidated,
+		ForgottenCount:    report.Forgotten,
+		FadedCount:        report.Faded,
+		RemovedCount:      report.Removed,
 		STMCount:          b.Hippocampus.GetSTMCount(),
 		LTMCount:          b.Hippocampus.GetLTMCount(),
 	}
+	b.recordSleepLocked(result)
+
+	return result
 }
 
 // UpdateMotivation はフィードバックにより意欲を更新
 // 【神経科学的意味】外部からの報酬/罰により大脳基底核の意欲を更新
 // 【処理内容】報酬予測誤差(RPE)に基づいて意欲レベルを調整
-func (b *Brain) UpdateMotivation(isPositive bool) int {
+func (b *Brain) UpdateMotivation(isPositive bool) (int, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.closed {
+		return 0, ErrBrainClosed
+	}
+
 	var reward float64
 	if isPositive {
 		reward = 100.0
@@ -45,27 +61,102 @@ func (b *Brain) UpdateMotivation(isPositive bool) int {
 	}
 
 	b.BasalGanglia.UpdateMotivation(reward)
-	return b.BasalGanglia.GetMotivation()
+	b.persistStateLocked()
+	b.publishLocked(EventFeedback)
+	return b.BasalGanglia.GetMotivation(), nil
+}
+
+// GetMotivation は現在の意欲を取得
+func (b *Brain) GetMotivation() MotivationState {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.motivationStateLocked()
+}
+
+// RewardFromEmotion は感情価を報酬として意欲に反映
+// 【神経科学的意味】扁桃体・眼窩前頭皮質からの快/不快シグナルを報酬予測誤差として処理
+// 【処理内容】感情価 (0-100) を報酬として大脳基底核の意欲を更新
+func (b *Brain) RewardFromEmotion(emotionValue int) (MotivationState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return MotivationState{}, ErrBrainClosed
+	}
+	b.BasalGanglia.RewardFromEmotion(float64(emotionValue))
+	b.persistStateLocked()
+	b.publishLocked(EventMotivation)
+	return b.motivationStateLocked(), nil
+}
+
+// DecayMotivation は時間経過による意欲の減衰を適用
+// 【神経科学的意味】刺激がない状態ではトニックドーパミンが自然減少し、意欲はベースラインに戻る
+func (b *Brain) DecayMotivation() (MotivationState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return MotivationState{}, ErrBrainClosed
+	}
+	b.BasalGanglia.ApplyDecay()
+	b.persistStateLocked()
+	b.publishLocked(EventDecay)
+	return b.motivationStateLocked(), nil
+}
+
+// ResetMotivation は意欲と報酬予測を初期値に戻す
+func (b *Brain) ResetMotivation() (MotivationState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return MotivationState{}, ErrBrainClosed
+	}
+	b.BasalGanglia.Reset()
+	b.persistStateLocked()
+	b.publishLocked(EventMotivation)
+	return b.motivationStateLocked(), nil
+}
+
+// motivationStateLocked は意欲の状態を返す (呼び出し側でロックを保持していること)
+func (b *Brain) motivationStateLocked() MotivationState {
+	return MotivationState{
+		Motivation: b.BasalGanglia.GetMotivation(),
+		Level:      b.BasalGanglia.GetMotivationLevel(),
+	}
 }
 
 // ApplyStress はストレスを適用
 // 【神経科学的意味】外部ストレス要因により前頭前皮質の理性値を低下
 // 【処理内容】PFCの理性値を減少させ、感情制御能力を低下させる
-func (b *Brain) ApplyStress(stressLevel int) {
+func (b *Brain) ApplyStress(stressLevel int) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.closed {
+		return ErrBrainClosed
+	}
 	b.PFC.ApplyStress(stressLevel)
+	b.persistStateLocked()
+	b.publishLocked(EventStress)
+	return nil
 }
 
 // Rest は休息を適用
 // 【神経科学的意味】休息により前頭前皮質の理性値を回復
 // 【処理内容】PFCの理性値を増加させ、感情制御能力を向上させる
-func (b *Brain) Rest(restQuality int) {
+func (b *Brain) Rest(restQuality int) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.closed {
+		return ErrBrainClosed
+	}
 	b.PFC.Rest(restQuality)
+	b.persistStateLocked()
+	b.publishLocked(EventRest)
+	return nil
 }
 
 // GetState は現在の脳の状態を取得
@@ -75,11 +166,21 @@ func (b *Brain) GetState() BrainState {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
+	return b.stateLocked()
+}
+
+// stateLocked は現在の脳の状態を返す (呼び出し側でロックを保持していること)
+func (b *Brain) stateLocked() BrainState {
+	cortisol, oxytocin := b.Hypothalamus.GetStatus()
 	return BrainState{
+		Profile:         b.Profile,
 		Motivation:      b.BasalGanglia.GetMotivation(),
 		MotivationLevel: b.BasalGanglia.GetMotivationLevel(),
 		Sanity:          b.PFC.GetSanity(),
 		SanityLevel:     b.PFC.GetSanityLevel(),
+		Cortisol:        cortisol,
+		Oxytocin:        oxytocin,
+		CoreAffect:      b.coreAffect,
 		STMCount:        b.Hippocampus.GetSTMCount(),
 		LTMCount:        b.Hippocampus.GetLTMCount(),
 	}
@@ -99,18 +200,30 @@ func (b *Brain) GetRecentMemories() []models.RuneMemory {
 // 【用途】睡眠処理でどれだけの記憶が固定化/忘却されたかを報告
 type SleepResult struct {
 	ConsolidatedCount int // LTMに固定化された記憶数
-	ForgottenCount    int // 忘却された記憶数
+	ForgottenCount    int // 固定化されずに忘却された短期記憶数
+	FadedCount        int // 忘却曲線により重みが減衰した長期記憶数
+	RemovedCount      int // 重みが下限を下回り削除された長期記憶数
 	STMCount          int // 残っている短期記憶数
 	LTMCount          int // 総長期記憶数
 }
 
+// MotivationState は意欲の状態
+type MotivationState struct {
+	Motivation int    // 意欲値 (0-100)
+	Level      string // 意欲レベル (文字列表現)
+}
+
 // BrainState は脳の状態
 // 【用途】現在の脳の主要パラメータを表現
 type BrainState struct {
-	Motivation      int    // 意欲値 (0-100)
-	MotivationLevel string // 意欲レベル (文字列表現)
-	Sanity          int    // 理性値 (0-100)
-	SanityLevel     string // 理性レベル (文字列表現)
-	STMCount        int    // 短期記憶数
-	LTMCount        int    // 長期記憶数
+	Profile         string        // パーソナリティプロファイル名
+	Motivation      int           // 意欲値 (0-100)
+	MotivationLevel string        // 意欲レベル (文字列表現)
+	Sanity          int           // 理性値 (0-100)
+	SanityLevel     string        // 理性レベル (文字列表現)
+	Cortisol        float64       // ストレスホルモン (0-100)
+	Oxytocin        float64       // 愛着ホルモン (0-100)
+	CoreAffect      models.Affect // コアアフェクト (VAD)
+	STMCount        int           // 短期記憶数
+	LTMCount        int           // 長期記憶数
 }
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"github.com/umekku/mind-os/internal/models"
+	"github.com/umekku/mind-os/internal/store"
+)
+
+// SearchMemories は記憶を全文検索する
+// 【役割】オペレーターが「この脳が何を覚えているか」をトピックから探すための検索
+// 【処理内容】
+// 短期記憶(STM)はメモリ上で、長期記憶(LTM)はDBの全文検索インデックスで同じ条件により絞り込む。
+// 結果は STM (新しい順) → LTM (関連度順) の順に連結し、その上でページングする。
+func (b *Brain) SearchMemories(q store.MemorySearchQuery) (store.MemorySearchResult, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = store.DefaultSearchLimit
+	}
+
+	// STMの一致 (新しい順)
+	stm := b.Hippocampus.SnapshotSTM()
+	stmMatches := make([]models.RuneMemory, 0)
+	for i := len(stm) - 1; i >= 0; i-- {
+		if q.Matches(stm[i]) {
+			stmMatches = append(stmMatches, stm[i])
+		}
+	}
+
+	result := store.MemorySearchResult{Total: len(stmMatches)}
+
+	// ページ範囲に含まれるSTM
+	if q.Offset < len(stmMatches) {
+		end := min(q.Offset+limit, len(stmMatches))
+		result.Memories = append(result.Memories, stmMatches[q.Offset:end]...)
+	}
+
+	// メモリのみモード、またはSTMのみを対象とする検索
+	if b.DB == nil || q.Type == models.MemorySTM {
+		return result, nil
+	}
+
+	// 残りの枠をLTMで埋める
+	ltmQuery := q
+	ltmQuery.Offset = max(q.Offset-len(stmMatches), 0)
+	ltmQuery.Limit = limit - len(result.Memories)
+	if ltmQuery.Limit == 0 {
+		// 件数のみ取得
+		ltmQuery.Limit = 1
+	}
+
+	ltm, err := b.DB.SearchMemories(ltmQuery)
+	if err != nil {
+		return store.MemorySearchResult{}, err
+	}
+
+	result.Total += ltm.Total
+	if len(result.Memories) < limit {
+		result.Memories = append(result.Memories, ltm.Memories...)
+	}
+
+	return result, nil
+}
+
+// AddMemory は体験をそのまま短期記憶として記銘する
+// 【処理内容】扁桃体で感情評価を行い、感情価による重み付きで海馬のSTMに追加する
+// (感覚入力パイプラインと異なり、理性による抑制や応答生成は行わない)
+func (b *Brain) AddMemory(text string) (models.RuneMemory, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return models.RuneMemory{}, ErrBrainClosed
+	}
+	emotions := b.Amygdala.Assess(text).Emotions
+	memory := b.Hippocampus.AddMemory(text, emotions)
+	b.persistStateLocked()
+	b.publishLocked(EventMemory)
+
+	return memory, nil
+}
+
+// GetMemory はUUIDで記憶を取得する (存在しない場合は nil)
+func (b *Brain) GetMemory(uuid string) *models.RuneMemory {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.Hippocampus.GetMemoryByUUID(uuid)
+}
+
+// ListMemories はフィルタ・並び順・カーソルを指定して記憶の一覧を取得する
+// 続きがある場合は次ページのカーソルを返す
+func (b *Brain) ListMemories(q store.MemoryListQuery) ([]models.RuneMemory, *store.MemoryCursor, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.Hippocampus.ListMemories(q)
+}
+
+// MemoryPatch は記憶の部分更新の内容 (nil のフィールドは変更しない)
+type MemoryPatch struct {
+	Tags   *[]string
+	Weight *float64
+}
+
+// PatchMemory は記憶のタグと重みを更新する
+// 記憶が存在しない場合は store.ErrMemoryNotFound を返す
+func (b *Brain) PatchMemory(uuid string, patch MemoryPatch) (*models.RuneMemory, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrBrainClosed
+	}
+
+	memory := b.Hippocampus.GetMemoryByUUID(uuid)
+	if memory == nil {
+		return nil, store.ErrMemoryNotFound
+	}
+
+	if patch.Tags != nil {
+		memory.Tags = *patch.Tags
+	}
+	if patch.Weight != nil {
+		memory.Weight = *patch.Weight
+	}
+
+	if err := b.Hippocampus.UpdateMemory(*memory); err != nil {
+		return nil, err
+	}
+	b.persistStateLocked()
+	b.publishLocked(EventMemory)
+
+	return memory, nil
+}
+
+// ForgetMemory は要求に応じて記憶を忘却（削除）する
+// 記憶が存在しない場合は store.ErrMemoryNotFound を返す
+func (b *Brain) ForgetMemory(uuid string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrBrainClosed
+	}
+
+	if err := b.Hippocampus.ForgetMemory(uuid); err != nil {
+		return err
+	}
+	b.persistStateLocked()
+	b.publishLocked(EventMemory)
+	return nil
+}
+
+// ForgetMemories は複数の記憶を忘却し、削除した件数を返す
+func (b *Brain) ForgetMemories(uuids []string) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return 0, ErrBrainClosed
+	}
+
+	forgotten, err := b.Hippocampus.ForgetMemories(uuids)
+	if forgotten > 0 {
+		b.persistStateLocked()
+		b.publishLocked(EventMemory)
+	}
+	return forgotten, err
+}
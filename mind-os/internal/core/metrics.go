@@ -0,0 +1,185 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// 脳の活動のカウンター (脳IDごと)
+var (
+	inputsProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mindos_sensory_inputs_total",
+		Help: "Sensory inputs processed, by signal type.",
+	}, []string{"brain_id", "signal_type"})
+	emotionsDetected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mindos_emotions_detected_total",
+		Help: "Emotions generated from sensory inputs (before prefrontal regulation), by emotion code.",
+	}, []string{"brain_id", "code"})
+	sleepCycles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mindos_sleep_cycles_total",
+		Help: "Sleep cycles (memory consolidation) run.",
+	}, []string{"brain_id"})
+	memoriesConsolidated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mindos_memories_consolidated_total",
+		Help: "Short-term memories consolidated into long-term memory during sleep.",
+	}, []string{"brain_id"})
+	memoriesForgotten = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mindos_memories_forgotten_total",
+		Help: "Memories forgotten during sleep: short-term memories not consolidated (stm) and long-term memories below the forgetting floor (ltm).",
+	}, []string{"brain_id", "memory"})
+)
+
+// brainCounters は脳IDをラベルに持つカウンター (脳の削除時に系列を削除する)
+var brainCounters = []*prometheus.CounterVec{
+	inputsProcessed, emotionsDetected, sleepCycles, memoriesConsolidated, memoriesForgotten,
+}
+
+// RegisterMetrics は脳のメトリクスを登録する
+// 【処理内容】活動のカウンターと、収集時にメモリ上の脳から読み取る状態のゲージを登録する
+// 退避された脳のゲージは出力しない (再ロードされると再び出力される)
+func RegisterMetrics(reg prometheus.Registerer, registry *Registry) error {
+	var errs []error
+	for _, c := range brainCounters {
+		errs = append(errs, reg.Register(c))
+	}
+	errs = append(errs, reg.Register(registryCollector{registry: registry}))
+	return errors.Join(errs...)
+}
+
+// recordInputLocked は入力の処理をカウントする (呼び出し側でロックを保持していること)
+func (b *Brain) recordInputLocked(signalType models.SignalType, emotions []models.EmotionValue) {
+	if signalType == "" {
+		signalType = models.SignalChat
+	}
+	inputsProcessed.WithLabelValues(b.ID, string(signalType)).Inc()
+	for _, e := range emotions {
+		if e.Value > 0 {
+			emotionsDetected.WithLabelValues(b.ID, string(e.Code)).Inc()
+		}
+	}
+}
+
+// recordSleepLocked は睡眠の結果をカウントする (呼び出し側でロックを保持していること)
+func (b *Brain) recordSleepLocked(result SleepResult) {
+	sleepCycles.WithLabelValues(b.ID).Inc()
+	memoriesConsolidated.WithLabelValues(b.ID).Add(float64(result.ConsolidatedCount))
+	memoriesForgotten.WithLabelValues(b.ID, "stm").Add(float64(result.ForgottenCount))
+	memoriesForgotten.WithLabelValues(b.ID, "ltm").Add(float64(result.RemovedCount))
+}
+
+// deleteBrainMetrics は削除された脳のカウンターの系列を削除する
+func deleteBrainMetrics(id string) {
+	for _, c := range brainCounters {
+		c.DeletePartialMatch(prometheus.Labels{"brain_id": id})
+	}
+}
+
+// brainGauges は収集時点の脳の状態
+type brainGauges struct {
+	id              string
+	cortisol        float64
+	oxytocin        float64
+	melatonin       float64
+	serotonin       float64
+	motivation      float64
+	predictedReward float64
+	sanity          float64
+	empathy         float64
+	satiation       float64
+	stm             int
+	ltm             int
+}
+
+// gauges は現在の状態を読み取る (収集中に閉じられた脳の場合は false)
+func (b *Brain) gauges() (brainGauges, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return brainGauges{}, false
+	}
+
+	hormones := b.Hypothalamus.Snapshot()
+	return brainGauges{
+		id:              b.ID,
+		cortisol:        hormones.Cortisol,
+		oxytocin:        hormones.Oxytocin,
+		melatonin:       hormones.Melatonin,
+		serotonin:       hormones.Serotonin,
+		motivation:      float64(b.BasalGanglia.GetMotivation()),
+		predictedReward: b.BasalGanglia.GetPredictedReward(),
+		sanity:          float64(b.PFC.GetSanity()),
+		empathy:         b.Mirror.GetEmpathyLevel(),
+		satiation:       b.Thalamus.GetSatiationLevel(),
+		stm:             b.Hippocampus.GetSTMCount(),
+		ltm:             b.Hippocampus.GetLTMCount(),
+	}, true
+}
+
+// 脳の状態のゲージ (収集時に読み取る)
+var (
+	hormoneDesc = prometheus.NewDesc("mindos_hormone_level",
+		"Hormone level (0-100).", []string{"brain_id", "hormone"}, nil)
+	motivationDesc = prometheus.NewDesc("mindos_motivation",
+		"Motivation (basal ganglia tonic dopamine, 0-100).", []string{"brain_id"}, nil)
+	predictedRewardDesc = prometheus.NewDesc("mindos_predicted_reward",
+		"Reward predicted by the basal ganglia (0-100).", []string{"brain_id"}, nil)
+	sanityDesc = prometheus.NewDesc("mindos_sanity",
+		"Sanity (prefrontal cortex self-control, 0-100).", []string{"brain_id"}, nil)
+	empathyDesc = prometheus.NewDesc("mindos_empathy_level",
+		"Empathy level of the mirror neuron system (0-1).", []string{"brain_id"}, nil)
+	satiationDesc = prometheus.NewDesc("mindos_thalamus_satiation_level",
+		"Sensory satiation (habituation) of the thalamus (0-1).", []string{"brain_id"}, nil)
+	memoriesDesc = prometheus.NewDesc("mindos_memories",
+		"Memories held, by memory store (stm, ltm).", []string{"brain_id", "memory"}, nil)
+	brainsActiveDesc = prometheus.NewDesc("mindos_brains_active",
+		"Brains loaded in memory.", nil, nil)
+)
+
+// registryCollector はメモリ上の脳の状態をゲージとして出力する prometheus.Collector
+type registryCollector struct {
+	registry *Registry
+}
+
+// Describe は prometheus.Collector の実装
+func (c registryCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		hormoneDesc, motivationDesc, predictedRewardDesc, sanityDesc, empathyDesc, satiationDesc, memoriesDesc, brainsActiveDesc,
+	} {
+		ch <- d
+	}
+}
+
+// Collect は prometheus.Collector の実装
+func (c registryCollector) Collect(ch chan<- prometheus.Metric) {
+	active := c.registry.Active()
+	gauge := func(desc *prometheus.Desc, value float64, labelValues ...string) {
+		m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, value, labelValues...)
+		if err != nil {
+			// ラベルの誤りは収集エラーとして報告する
+			m = prometheus.NewInvalidMetric(desc, err)
+		}
+		ch <- m
+	}
+
+	gauge(brainsActiveDesc, float64(len(active)))
+	for _, b := range active {
+		g, ok := b.gauges()
+		if !ok {
+			continue
+		}
+		gauge(hormoneDesc, g.cortisol, g.id, "cortisol")
+		gauge(hormoneDesc, g.oxytocin, g.id, "oxytocin")
+		gauge(hormoneDesc, g.melatonin, g.id, "melatonin")
+		gauge(hormoneDesc, g.serotonin, g.id, "serotonin")
+		gauge(motivationDesc, g.motivation, g.id)
+		gauge(predictedRewardDesc, g.predictedReward, g.id)
+		gauge(sanityDesc, g.sanity, g.id)
+		gauge(empathyDesc, g.empathy, g.id)
+		gauge(satiationDesc, g.satiation, g.id)
+		gauge(memoriesDesc, float64(g.stm), g.id, "stm")
+		gauge(memoriesDesc, float64(g.ltm), g.id, "ltm")
+	}
+}
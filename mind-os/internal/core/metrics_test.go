@@ -0,0 +1,97 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// gatherValue は収集したメトリクスのうち、ラベルが全て一致する系列の値を返す (系列がなければ false)
+func gatherValue(t *testing.T, reg *prometheus.Registry, name string, labels map[string]string) (float64, bool) {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+	series:
+		for _, m := range f.GetMetric() {
+			for _, lp := range m.GetLabel() {
+				if want, ok := labels[lp.GetName()]; ok && lp.GetValue() != want {
+					continue series
+				}
+			}
+			if m.GetCounter() != nil {
+				return m.GetCounter().GetValue(), true
+			}
+			return m.GetGauge().GetValue(), true
+		}
+	}
+	return 0, false
+}
+
+func TestRegisterMetrics(t *testing.T) {
+	r := newTestRegistry(t, 10)
+	reg := prometheus.NewRegistry()
+	if err := RegisterMetrics(reg, r); err != nil {
+		t.Fatal(err)
+	}
+
+	// 他のテストと系列が混ざらない脳ID
+	const id = "metrics-brain"
+	brain, err := r.Create(id, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, text := range []string{"嬉しい", "楽しい"} {
+		if _, err := brain.ProcessInput(models.SensoryInput{Type: models.SignalChat, InputText: text}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := brain.Sleep(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		metric string
+		labels map[string]string
+		want   float64
+	}{
+		{"入力", "mindos_sensory_inputs_total", map[string]string{"brain_id": id, "signal_type": "chat"}, 2},
+		{"睡眠", "mindos_sleep_cycles_total", map[string]string{"brain_id": id}, 1},
+		{"読み込み中の脳", "mindos_brains_active", nil, 1},
+		{"意欲", "mindos_motivation", map[string]string{"brain_id": id}, float64(brain.GetMotivation().Motivation)},
+		{"理性", "mindos_sanity", map[string]string{"brain_id": id}, float64(brain.PFC.GetSanity())},
+		{"ホルモン", "mindos_hormone_level", map[string]string{"brain_id": id, "hormone": "cortisol"}, brain.Hypothalamus.Snapshot().Cortisol},
+		{"短期記憶", "mindos_memories", map[string]string{"brain_id": id, "memory": "stm"}, float64(brain.Hippocampus.GetSTMCount())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := gatherValue(t, reg, tt.metric, tt.labels); !ok || got != tt.want {
+				t.Errorf("%s%v = %v (found %v), want %v", tt.metric, tt.labels, got, ok, tt.want)
+			}
+		})
+	}
+
+	// 削除された脳の系列は出力しない
+	if err := r.Delete(id); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"mindos_sensory_inputs_total", "mindos_sleep_cycles_total", "mindos_motivation"} {
+		if got, ok := gatherValue(t, reg, name, map[string]string{"brain_id": id}); ok {
+			t.Errorf("%s of deleted brain = %v, want no series", name, got)
+		}
+	}
+
+	// 同じレジストリへの二重登録はエラーを返す (panic しない)
+	var already prometheus.AlreadyRegisteredError
+	if err := RegisterMetrics(reg, r); !errors.As(err, &already) {
+		t.Errorf("second RegisterMetrics() error = %v, want AlreadyRegisteredError", err)
+	}
+}
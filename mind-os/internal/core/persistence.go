@@ -0,0 +1,98 @@
+package core
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/umekku/mind-os/internal/hypothalamus"
+	"github.com/umekku/mind-os/internal/store"
+	"github.com/umekku/mind-os/internal/thalamus"
+)
+
+// snapshotLocked は現在の神経化学的状態のスナップショットを作成
+// 【対象】ホルモン、意欲・期待報酬、理性、視床の順応状態、共感レベル、短期記憶、コアアフェクト
+// b.mu を保持した状態で呼ぶこと
+func (b *Brain) snapshotLocked() store.BrainStateSnapshot {
+	hormones := b.Hypothalamus.Snapshot()
+	motivation, predictedReward := b.BasalGanglia.Snapshot()
+	adaptation := b.Thalamus.Snapshot()
+
+	return store.BrainStateSnapshot{
+		Cortisol:          hormones.Cortisol,
+		Oxytocin:          hormones.Oxytocin,
+		Melatonin:         hormones.Melatonin,
+		Serotonin:         hormones.Serotonin,
+		HormonesUpdatedAt: hormones.LastUpdated,
+		Motivation:        motivation,
+		PredictedReward:   predictedReward,
+		Sanity:            b.PFC.GetSanity(),
+		LastInputText:     adaptation.LastInputText,
+		RepetitionCount:   adaptation.RepetitionCount,
+		SatiationLevel:    adaptation.SatiationLevel,
+		EmpathyLevel:      b.Mirror.GetEmpathyLevel(),
+		STM:               b.Hippocampus.SnapshotSTM(),
+		CoreAffect:        b.coreAffect,
+		SavedAt:           time.Now(),
+	}
+}
+
+// persistStateLocked は状態変化後にスナップショットをDBへ書き込む
+// メモリのみモード・Close 済みの脳では何もしない。b.mu を保持した状態で呼ぶこと
+// (退避・削除された脳の状態で、新しくロードされた脳の状態を上書きしないため)
+func (b *Brain) persistStateLocked() {
+	if b.DB == nil || b.closed {
+		return
+	}
+
+	if err := b.DB.SaveBrainState(b.snapshotLocked()); err != nil {
+		slog.Warn("Failed to persist brain state", "brain_id", b.ID, "error", err)
+	}
+}
+
+// restoreState は保存されたスナップショットから状態を復元
+// 【神経科学的意味】停止中の経過時間はホルモンの自然減衰として反映する（眠っていた間に落ち着く）
+// 生成直後（他から参照される前）に呼ぶこと
+func (b *Brain) restoreState() {
+	if b.DB == nil {
+		return
+	}
+
+	s, err := b.DB.LoadBrainState()
+	if err != nil {
+		slog.Warn("Failed to load brain state", "brain_id", b.ID, "error", err)
+		return
+	}
+	if s == nil {
+		return
+	}
+
+	b.Hypothalamus.Restore(hypothalamus.HormoneSnapshot{
+		Cortisol:    s.Cortisol,
+		Oxytocin:    s.Oxytocin,
+		Melatonin:   s.Melatonin,
+		Serotonin:   s.Serotonin,
+		LastUpdated: s.HormonesUpdatedAt,
+	})
+	b.BasalGanglia.Restore(s.Motivation, s.PredictedReward)
+	b.PFC.SetSanity(s.Sanity)
+	b.Thalamus.Restore(thalamus.AdaptationState{
+		LastInputText:   s.LastInputText,
+		RepetitionCount: s.RepetitionCount,
+		SatiationLevel:  s.SatiationLevel,
+	})
+	b.Mirror.SetEmpathyLevel(s.EmpathyLevel)
+	b.Hippocampus.RestoreSTM(s.STM)
+	b.coreAffect = s.CoreAffect
+	b.coreAffectAt = s.SavedAt
+
+	// 停止中の経過時間分のホルモン・コアアフェクトの減衰
+	b.Hypothalamus.Decay()
+	b.decayCoreAffectLocked(time.Now())
+
+	slog.Info("Brain state restored",
+		"brain_id", b.ID,
+		"saved_at", s.SavedAt,
+		"downtime", time.Since(s.SavedAt).Round(time.Second).String(),
+		"stm_count", len(s.STM),
+	)
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/umekku/mind-os/internal/hypothalamus"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+func TestBrain_RestoreState(t *testing.T) {
+	r := newTestRegistry(t, 10)
+	brain, err := r.Create("alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 興奮した状態を作って保存する
+	brain.Hypothalamus.Restore(hypothalamus.HormoneSnapshot{
+		Cortisol: 80, Oxytocin: 60, Melatonin: 30, Serotonin: 70, LastUpdated: time.Now(),
+	})
+	brain.mu.Lock()
+	brain.coreAffect = models.Affect{Valence: -0.8, Arousal: 0.6, Dominance: -0.4}
+	brain.coreAffectAt = time.Now()
+	brain.mu.Unlock()
+	if err := brain.ApplyStress(40); err != nil {
+		t.Fatal(err)
+	}
+
+	// 退避時に最終状態が保存される
+	if err := r.Evict("alice"); err != nil {
+		t.Fatal(err)
+	}
+	view := r.db.ForBrain("alice")
+	saved, err := view.LoadBrainState()
+	if err != nil || saved == nil {
+		t.Fatalf("LoadBrainState() = %v, %v", saved, err)
+	}
+
+	// 時計を1時間進める (保存時刻を1時間前にずらす)
+	const downtime = time.Hour
+	saved.HormonesUpdatedAt = saved.HormonesUpdatedAt.Add(-downtime)
+	saved.SavedAt = saved.SavedAt.Add(-downtime)
+	if err := view.SaveBrainState(*saved); err != nil {
+		t.Fatal(err)
+	}
+
+	// 期待値: 停止中の経過時間分の Homeostasis.Decay
+	restoredAt := time.Now()
+	want := hypothalamus.NewHomeostasis(hypothalamus.WithDecayRate(r.cfg.HormoneDecayRate))
+	want.Restore(hypothalamus.HormoneSnapshot{
+		Cortisol: saved.Cortisol, Oxytocin: saved.Oxytocin, LastUpdated: saved.HormonesUpdatedAt,
+	})
+	want.TimeProvider = func() time.Time { return restoredAt }
+	want.Decay()
+	wantCortisol, wantOxytocin := want.GetStatus()
+	wantAffect := saved.CoreAffect.Scale(math.Pow(0.5, float64(restoredAt.Sub(saved.SavedAt))/float64(coreAffectHalfLife)))
+
+	fresh, err := r.Get("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hormones := fresh.Hypothalamus.Snapshot()
+	if !approxEqual(hormones.Cortisol, wantCortisol, 0.01) || !approxEqual(hormones.Oxytocin, wantOxytocin, 0.01) {
+		t.Errorf("restored hormones = cortisol %.2f, oxytocin %.2f, want %.2f, %.2f (saved %.2f, %.2f)",
+			hormones.Cortisol, hormones.Oxytocin, wantCortisol, wantOxytocin, saved.Cortisol, saved.Oxytocin)
+	}
+	if hormones.Cortisol >= saved.Cortisol {
+		t.Errorf("cortisol did not decay during downtime: %.2f -> %.2f", saved.Cortisol, hormones.Cortisol)
+	}
+	if hormones.Melatonin != saved.Melatonin || hormones.Serotonin != saved.Serotonin {
+		t.Errorf("restored melatonin/serotonin = %.2f/%.2f, want %.2f/%.2f",
+			hormones.Melatonin, hormones.Serotonin, saved.Melatonin, saved.Serotonin)
+	}
+
+	fresh.mu.Lock()
+	affect := fresh.coreAffect
+	fresh.mu.Unlock()
+	if !approxEqual(affect.Valence, wantAffect.Valence, 0.001) || !approxEqual(affect.Arousal, wantAffect.Arousal, 0.001) ||
+		!approxEqual(affect.Dominance, wantAffect.Dominance, 0.001) {
+		t.Errorf("restored core affect = %+v, want %+v", affect, wantAffect)
+	}
+
+	// 減衰しない状態はそのまま復元される
+	motivation, predicted := fresh.BasalGanglia.Snapshot()
+	if motivation != saved.Motivation || predicted != saved.PredictedReward || fresh.PFC.GetSanity() != saved.Sanity {
+		t.Errorf("restored motivation %.2f/%.2f, sanity %d, want %.2f/%.2f, %d",
+			motivation, predicted, fresh.PFC.GetSanity(), saved.Motivation, saved.PredictedReward, saved.Sanity)
+	}
+
+	// 続けて退避・再ロードしても、停止中の減衰を二重に適用しない
+	if err := r.Evict("alice"); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := r.Get("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	again := reloaded.Hypothalamus.Snapshot()
+	if !approxEqual(again.Cortisol, hormones.Cortisol, 0.01) || !approxEqual(again.Oxytocin, hormones.Oxytocin, 0.01) {
+		t.Errorf("hormones after second reload = %.2f/%.2f, want %.2f/%.2f",
+			again.Cortisol, again.Oxytocin, hormones.Cortisol, hormones.Oxytocin)
+	}
+	if got := reloaded.PFC.GetSanity(); got != saved.Sanity {
+		t.Errorf("sanity after second reload = %d, want %d", got, saved.Sanity)
+	}
+}
+
+// approxEqual は a と b の差が tolerance 以内か
+func approxEqual(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
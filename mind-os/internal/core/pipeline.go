@@ -2,12 +2,19 @@ package core
 
 import (
 	"log/slog"
+	"slices"
 	"time"
 
+	"github.com/umekku/mind-os/internal/amygdala"
 	"github.com/umekku/mind-os/internal/cortex"
+	"github.com/umekku/mind-os/internal/hippocampus"
+	"github.com/umekku/mind-os/internal/language"
 	"github.com/umekku/mind-os/internal/models"
 )
 
+// recallLimit は1回の入力で想起する記憶の最大数
+const recallLimit = 3
+
 // ProcessInput は入力を脳全体で処理
 // 【神経科学的意味】感覚入力から感情・認知・記憶・言語までの統合処理パイプライン
 // 【処理フロー】
@@ -15,15 +22,34 @@ import (
 // 2. 視床フィルタリング（順応・ゲイン計算）
 // 3. 感情生成（扁桃体） / 共感プロセス（ミラーニューロン）
 // 4. ホルモン更新（視床下部）
-// 5. 意欲更新（大脳基底核）
+// 5. 意欲更新（大脳基底核）・コアアフェクト (VAD) 更新
 // 6. 感情調整（前頭前皮質）
-// 7. 記憶保存（海馬）
-// 8. 言語理解（ウェルニッケ野）
+// 7. 言語理解（ウェルニッケ野）
+// 8. 連想想起・記憶保存（海馬）
 // 9. 言語生成（ブローカ野）
-func (b *Brain) ProcessInput(input models.SensoryInput) models.MindStateResponse {
+// Close 済みの脳は入力を処理せず ErrBrainClosed を返す
+func (b *Brain) ProcessInput(input models.SensoryInput) (models.MindStateResponse, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.closed {
+		return models.MindStateResponse{}, ErrBrainClosed
+	}
+	return b.processInputLocked(input, nil), nil
+}
+
+// processInputLocked は入力処理パイプラインの本体 (呼び出し側でロックを保持していること)
+// conv が nil でなければ会話の履歴を視床・言語野に渡し、今回のやり取りを履歴に記録する
+func (b *Brain) processInputLocked(input models.SensoryInput, conv *Conversation) models.MindStateResponse {
+	b.lastStimulus = time.Now()
+
+	var history []string
+	var discourse cortex.Discourse
+	if conv != nil {
+		history = conv.utterances()
+		discourse = conv.discourse()
+	}
+
 	// 1. 時間経過処理 (ホルモン減衰)
 	b.Hypothalamus.Decay()
 
@@ -31,53 +57,108 @@ func (b *Brain) ProcessInput(input models.SensoryInput) models.MindStateResponse
 	b.Hypothalamus.UpdateCircadianRhythm(time.Now())
 
 	// 2. 視床フィルタリング (順応・ゲイン計算)
-	gain, err := b.Thalamus.Filter(input)
+	gain, err := b.Thalamus.FilterInContext(input, history)
 	if err != nil {
 		slog.Warn("Thalamus filter error", "error", err)
 		gain = 1.0 // エラー時のゲインは1.0（影響なし）にする
 	}
 
 	var rawEmotions []models.EmotionValue
+	var stimulus models.Affect
+	var signals []models.AffectSignal
 	text := input.InputText
+	lang := inputLanguage(input)
+
+	// 処理の根拠 (要求された場合のみ記録する)
+	var explanation *models.Explanation
+	if input.Explain {
+		explanation = &models.Explanation{ThalamusGain: gain}
+	}
 
 	if input.Type == models.SignalPhysical {
 		// 物理的刺激処理: 扁桃体分析をスキップし、信号値を直接感情に変換
 		rawEmotions = b.processPhysicalSignal(input.SignalValue, gain)
+		stimulus = models.AffectFromEmotions(rawEmotions)
+		recordStage(explanation, "physical", rawEmotions)
 	} else {
 		// 会話（デフォルト）: 扁桃体によるテキスト解析
-		rawEmotions = b.processChatInput(text, gain)
+		rawEmotions, stimulus, signals = b.processChatInput(text, lang, gain, explanation)
 	}
 
+	b.recordInputLocked(input.Type, rawEmotions)
+
+	// 4.5. コアアフェクト (VAD) を刺激の方向へ引き寄せる
+	b.updateCoreAffectLocked(stimulus, time.Now())
+
 	// 5. 前頭前皮質: 理性による感情の調整
 	// 視床下部のホルモン状態を取得
 	cortisol, oxytocin := b.Hypothalamus.GetStatus()
-	controlledEmotions := b.PFC.Arbitrate(rawEmotions, cortisol, oxytocin)
+	var controlledEmotions []models.EmotionValue
+	if explanation != nil {
+		controlledEmotions, explanation.Arbitration = b.PFC.ArbitrateExplained(rawEmotions, cortisol, oxytocin)
+		recordStage(explanation, "pfc", controlledEmotions)
+		explanation.Emotions = controlledEmotions
+	} else {
+		controlledEmotions = b.PFC.Arbitrate(rawEmotions, cortisol, oxytocin)
+	}
+
+	// 6. 言語理解（ウェルニッケ野）
+	concepts, intent := b.Wernicke.ComprehendInContext(text, lang, discourse)
 
-	// 6. 海馬: 記憶として保存
-	b.Hippocampus.AddEpisode(text, controlledEmotions)
+	// 7. 海馬: 概念と現在の感情を手がかりに関連する記憶を想起
+	// 今回の入力自体が想起されないよう、記憶の保存より先に行う
+	recalled := b.recallAssociatedMemories(concepts, controlledEmotions)
 
-	// 7. 言語理解（ウェルニッケ野）
-	concepts, intent := b.Wernicke.Comprehend(text)
+	// 7.5. 海馬: 記憶として保存（概念をタグとして付与）
+	b.Hippocampus.AddEpisodeIn(lang, text, controlledEmotions, concepts...)
 
 	// 8. レスポンスを生成
 	response := b.generateMindState(controlledEmotions)
+	response.RecalledMemories = recalled
+	response.Language = lang
+	response.AffectSignals = signals
+	response.Explanation = explanation
 
 	// 9. 言語生成（ブローカ野）
 	// Chat入力の場合のみテキスト応答を生成
 	if input.Type == models.SignalChat {
-		replyText := b.Broca.GenerateResponse(
+		replyText := b.Broca.GenerateResponseInContext(
+			lang,
 			controlledEmotions,
 			response.Motivation,
 			response.Sanity,
 			concepts,
 			intent,
+			recalled,
+			discourse,
 		)
 		response.ReplyText = replyText
 	}
 
+	// 9.5. 会話の履歴に記録
+	if conv != nil {
+		now := time.Now()
+		conv.record(Turn{Speaker: SpeakerUser, Type: input.Type, Text: text, Concepts: concepts, Intent: intent, Time: now})
+		if response.ReplyText != "" {
+			conv.record(Turn{Speaker: SpeakerBrain, Text: response.ReplyText, Time: now})
+		}
+	}
+
+	// 10. 状態の永続化
+	b.persistStateLocked()
+	b.publishLocked(EventSensoryInput)
+
 	return response
 }
 
+// inputLanguage は入力の言語を返す (指定がなければテキストから判定)
+func inputLanguage(input models.SensoryInput) models.Language {
+	if input.Language != "" {
+		return input.Language
+	}
+	return language.Detect(input.InputText)
+}
+
 // processPhysicalSignal は物理的刺激を処理
 // 【処理内容】信号値を直接感情に変換し、ホルモンと意欲を更新
 func (b *Brain) processPhysicalSignal(signalValue int, gain float64) []models.EmotionValue {
@@ -88,13 +169,13 @@ func (b *Brain) processPhysicalSignal(signalValue int, gain float64) []models.Em
 
 	if val > 0 {
 		// 正の値 -> Joy (快感) -> 愛着(Oxytocin)
-		// Gainを適用
-		addEmotion(&rawEmotions, models.EmotionJoy, int(val*gain))
-		affection = val * gain
+		// Gainと気質の感受性を適用
+		affection = val * gain * b.emotionSensitivity(models.EmotionJoy)
+		addEmotion(&rawEmotions, models.EmotionJoy, int(affection))
 	} else if val < 0 {
 		// 負の値 -> Disgust (不快感) -> ストレス(Cortisol)
-		addEmotion(&rawEmotions, models.EmotionDisgust, int(-val*gain))
-		stressor = -val * gain
+		stressor = -val * gain * b.emotionSensitivity(models.EmotionDisgust)
+		addEmotion(&rawEmotions, models.EmotionDisgust, int(stressor))
 	} else {
 		addEmotion(&rawEmotions, models.EmotionNeutral, 10)
 	}
@@ -112,14 +193,25 @@ func (b *Brain) processPhysicalSignal(signalValue int, gain float64) []models.Em
 }
 
 // processChatInput はチャット入力を処理
-// 【処理内容】テキストから感情を生成し、共感プロセスを適用
-func (b *Brain) processChatInput(text string, gain float64) []models.EmotionValue {
+// 【処理内容】言語 lang のテキストから感情を生成し、共感プロセスを適用
+// 戻り値の VAD は扁桃体が評価した語の VAD に視床のゲインを適用したもの (コアアフェクトの更新に使う)
+// 扁桃体が語彙以外から拾った感情の手がかり (絵文字・顔文字など) もデバッグ用に返す
+// explanation が nil でなければ、扁桃体の評価・情動伝染・感度の調整の根拠を記録する
+func (b *Brain) processChatInput(text string, lang models.Language, gain float64, explanation *models.Explanation) ([]models.EmotionValue, models.Affect, []models.AffectSignal) {
 	// 3. 感情生成 (Amygdala)
-	rawEmotions := b.Amygdala.Assess(text)
+	var assessment amygdala.Assessment
+	if explanation != nil {
+		assessment = b.Amygdala.ExplainIn(text, lang)
+		explanation.Assessment = assessment.Trace
+		recordStage(explanation, "amygdala", assessment.Emotions)
+	} else {
+		assessment = b.Amygdala.AssessIn(text, lang)
+	}
+	rawEmotions := assessment.Emotions
 
 	// 3.5. 共感プロセス（ミラーニューロンシステム）
 	// ユーザーの感情を推定
-	userEmotion, err := b.Mirror.SimulateUserEmotion(text)
+	userEmotion, err := b.Mirror.SimulateUserEmotion(text, lang)
 	if err != nil {
 		slog.Warn("Mirror neuron simulation error", "error", err)
 	}
@@ -131,24 +223,56 @@ func (b *Brain) processChatInput(text string, gain float64) []models.EmotionValu
 	// 情動伝染: ユーザーの感情をAI自身の感情にブレンド
 	empathyLevel := b.Mirror.GetEmpathyLevel()
 	rawEmotions = cortex.BlendEmotions(userEmotion, rawEmotions, empathyLevel)
+	if explanation != nil {
+		explanation.Empathy = &models.EmpathyTrace{
+			UserEmotion:  userEmotion,
+			EmpathyLevel: empathyLevel,
+			Contagion:    cortex.Contagion(userEmotion, empathyLevel),
+		}
+		recordStage(explanation, "empathy", rawEmotions)
+	}
 
 	// 概日リズムの効果を取得
 	_, emotionalSensitivity, _ := b.Hypothalamus.GetCircadianEffects()
 
-	// Gainと概日リズムの感情感度を感情値に適用
+	// Gain・気質の感受性・概日リズムの感情感度を感情値に適用
+	// (BlendEmotions は伝染がなければ扁桃体の結果をそのまま返すため、複製してから書き換える)
+	rawEmotions = slices.Clone(rawEmotions)
+	var sensitivityTrace *models.SensitivityTrace
+	if explanation != nil {
+		sensitivityTrace = &models.SensitivityTrace{Gain: gain, CircadianSensitivity: emotionalSensitivity}
+	}
 	for i := range rawEmotions {
 		// 夜間は感情的になる（Grief, Sadness, Love への感度上昇など）
-		sensitivity := gain
-		if rawEmotions[i].Code == models.EmotionGrief ||
+		temperament := b.emotionSensitivity(rawEmotions[i].Code)
+		sensitivity := gain * temperament
+		circadian := rawEmotions[i].Code == models.EmotionGrief ||
 			rawEmotions[i].Code == models.EmotionLove ||
-			rawEmotions[i].Code == models.EmotionFear {
+			rawEmotions[i].Code == models.EmotionFear
+		if circadian {
 			sensitivity *= emotionalSensitivity
 		}
 
+		before := rawEmotions[i].Value
 		rawEmotions[i].Value = int(float64(rawEmotions[i].Value) * sensitivity)
 		if rawEmotions[i].Value > 100 {
 			rawEmotions[i].Value = 100
 		}
+
+		if sensitivityTrace != nil {
+			sensitivityTrace.Adjustments = append(sensitivityTrace.Adjustments, models.SensitivityAdjustment{
+				Code:        rawEmotions[i].Code,
+				Before:      before,
+				Multiplier:  sensitivity,
+				Temperament: temperament,
+				Circadian:   circadian,
+				After:       rawEmotions[i].Value,
+			})
+		}
+	}
+	if explanation != nil {
+		explanation.Sensitivity = sensitivityTrace
+		recordStage(explanation, "sensitivity", rawEmotions)
 	}
 
 	// 感情からストレス/愛着を算出
@@ -180,5 +304,51 @@ func (b *Brain) processChatInput(text string, gain float64) []models.EmotionValu
 		b.BasalGanglia.UpdateMotivation(avgEmotion)
 	}
 
-	return rawEmotions
+	return rawEmotions, assessment.Affect.Scale(gain).Clamp(), assessment.Signals
+}
+
+// recordStage は処理段階の直後の感情を根拠に記録する (explanation が nil なら何もしない)
+// 後の段階で感情が書き換えられても記録が変わらないよう、複製して記録する
+func recordStage(explanation *models.Explanation, stage string, emotions []models.EmotionValue) {
+	if explanation == nil {
+		return
+	}
+	explanation.Stages = append(explanation.Stages, models.EmotionStageTrace{Stage: stage, Emotions: slices.Clone(emotions)})
+}
+
+// emotionSensitivity は気質による感情の感受性を返す (プロファイルで指定がなければ 1)
+// 【神経科学的意味】同じ刺激でも、扁桃体の反応性の個人差によって感じ方の強さが異なる
+func (b *Brain) emotionSensitivity(code models.EmotionCode) float64 {
+	if s, ok := b.sensitivity[code]; ok {
+		return s
+	}
+	return 1.0
+}
+
+// recallAssociatedMemories は入力の概念と感情を手がかりに記憶を想起する
+// 【神経科学的意味】話題が再び出た時に、関連する過去のエピソードが呼び起こされる
+// 想起された記憶は再固定化され、現在の感情で色付けされる
+// 返す感情は再固定化後 (保存された内容と同じ) で、スコアは想起の時点 (再固定化前) の関連度
+func (b *Brain) recallAssociatedMemories(concepts []string, emotions []models.EmotionValue) []models.RecalledMemory {
+	results := b.Hippocampus.Recall(hippocampus.Cue{
+		Concepts: concepts,
+		Emotions: emotions,
+	}, recallLimit)
+
+	recalled := make([]models.RecalledMemory, 0, len(results))
+	for _, r := range results {
+		memory := r.Memory
+		b.Hippocampus.ReconsolidateMemory(&memory, emotions)
+
+		recalled = append(recalled, models.RecalledMemory{
+			UUID:            memory.UUID,
+			Text:            memory.Text,
+			Type:            memory.Type,
+			Emotions:        memory.Emotions,
+			Score:           r.Score,
+			MatchedConcepts: r.MatchedConcepts,
+		})
+	}
+
+	return recalled
 }
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+func TestBrain_RecalledMemoriesAfterReconsolidation(t *testing.T) {
+	r := newTestRegistry(t, 10)
+	brain, err := r.Create("alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+	original := models.RuneMemory{
+		UUID:       "cat-memory",
+		Text:       "猫と遊んで楽しかった",
+		Emotions:   []models.EmotionValue{{Code: models.EmotionJoy, Value: 80}},
+		Weight:     0.6,
+		Type:       models.MemoryLTM,
+		CreatedAt:  weekAgo,
+		LastAccess: weekAgo,
+		Tags:       []string{"Jo", "猫"},
+	}
+	if err := brain.DB.SaveMemory(original); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := brain.ProcessInput(models.SensoryInput{Type: models.SignalChat, InputText: "猫がいて嬉しい"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := slices.IndexFunc(resp.RecalledMemories, func(m models.RecalledMemory) bool { return m.UUID == original.UUID })
+	if i < 0 {
+		t.Fatalf("RecalledMemories = %+v, want cat-memory", resp.RecalledMemories)
+	}
+
+	// レスポンスの感情は再固定化後に保存された内容と一致する
+	stored, err := brain.DB.GetMemoryByUUID(original.UUID)
+	if err != nil || stored == nil {
+		t.Fatalf("GetMemoryByUUID() = %v, %v", stored, err)
+	}
+	if stored.RecallCount != 1 || slices.Equal(stored.Emotions, original.Emotions) {
+		t.Fatalf("stored memory was not reconsolidated: %+v", stored)
+	}
+	if got := resp.RecalledMemories[i].Emotions; !slices.Equal(got, stored.Emotions) {
+		t.Errorf("recalled emotions = %+v, want reconsolidated %+v", got, stored.Emotions)
+	}
+}
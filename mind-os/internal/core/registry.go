@@ -0,0 +1,498 @@
+package core
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/umekku/mind-os/internal/amygdala"
+	"github.com/umekku/mind-os/internal/config"
+	"github.com/umekku/mind-os/internal/personality"
+	"github.com/umekku/mind-os/internal/store"
+)
+
+// Registry 操作のエラー
+var (
+	ErrBrainNotFound  = errors.New("brain not found")
+	ErrBrainExists    = errors.New("brain already exists")
+	ErrInvalidBrainID = errors.New("invalid brain id: must match [A-Za-z0-9_-]{1,64}")
+
+	// ErrProfileNotFound はプロファイルが見つからない場合のエラー (personality.ErrNotFound と同じ値)
+	ErrProfileNotFound = personality.ErrNotFound
+)
+
+// brainIDPattern は脳IDとして許可する文字列のパターン
+var brainIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// Registry は脳IDごとに独立した Brain インスタンスを管理する
+// 【役割】キャラクターやエンドユーザーごとにホルモン・意欲・理性・記憶を分離する
+// 【構造】データベース接続は全ての脳で共有し、記憶は脳IDで分離して保存する
+// メモリ上に保持する脳の数は MaxActiveBrains で制限し、超過時は最も使われていない脳を退避(evict)する
+// 【並行性】退避・削除する脳は r.mu を保持したまま一覧から外し、睡眠処理と Close は r.mu を解放してから行う
+// (Close の間も他の脳の Get/Create/List を止めない)。Close が終わるまで同じIDの脳はロードしない
+type Registry struct {
+	mu sync.Mutex
+
+	cfg            *config.Config
+	db             *store.DB // 共有データベース接続 (nilの場合はメモリのみモード)
+	brains         map[string]*registryEntry
+	closing        map[string]chan struct{} // 退避・削除の途中の脳ID (Close が終わると閉じる)
+	maxActive      int
+	profiles       *personality.Catalog // パーソナリティプロファイルの一覧
+	defaultProfile string               // プロファイル未指定で作成する脳のプロファイル名
+	lexicon        *amygdala.Lexicon    // 全ての脳で共有する感情辞書
+
+	// メモリのみモードで作成済みの脳 (DBの brains テーブルの代替)
+	memoryOnly map[string]BrainInfo
+}
+
+// registryEntry はメモリ上に保持している脳とその最終利用時刻
+type registryEntry struct {
+	brain    *Brain
+	lastUsed time.Time
+}
+
+// detachedBrain は一覧から外し、r.mu の外で閉じる脳
+type detachedBrain struct {
+	id          string
+	brain       *Brain // メモリ上になかった場合は nil (削除のみ行う)
+	consolidate bool   // 閉じる前に睡眠処理を行うか
+	done        chan struct{}
+}
+
+// BrainInfo は脳の一覧表示用の情報
+type BrainInfo struct {
+	ID        string    // 脳ID
+	CreatedAt time.Time // 作成日時
+	Profile   string    // パーソナリティプロファイル名
+	Active    bool      // メモリ上にロードされているか
+}
+
+// NewRegistry は新しい Registry を作成
+// 既定の脳 (store.DefaultBrainID) は常に利用可能な状態で、cfg.DefaultProfile のプロファイルで登録される
+// profiles が nil の場合は設定から作る組み込みの default プロファイルのみを、
+// lexicon が nil の場合は組み込みの感情辞書を使う
+func NewRegistry(cfg *config.Config, profiles *personality.Catalog, lexicon *amygdala.Lexicon) *Registry {
+	maxActive := cfg.MaxActiveBrains
+	if maxActive < 1 {
+		maxActive = 1
+	}
+
+	if profiles == nil {
+		profiles = personality.NewCatalog(personality.FromConfig(cfg))
+	}
+	if lexicon == nil {
+		lexicon = amygdala.NewLexicon()
+	}
+	defaultProfile := cfg.DefaultProfile
+	if defaultProfile == "" {
+		defaultProfile = personality.DefaultName
+	}
+
+	r := &Registry{
+		cfg:            cfg,
+		db:             openStore(cfg),
+		brains:         make(map[string]*registryEntry),
+		closing:        make(map[string]chan struct{}),
+		maxActive:      maxActive,
+		profiles:       profiles,
+		defaultProfile: defaultProfile,
+		lexicon:        lexicon,
+		memoryOnly:     make(map[string]BrainInfo),
+	}
+
+	if r.db != nil {
+		if err := r.db.CreateBrain(store.DefaultBrainID, defaultProfile); err != nil {
+			slog.Warn("Failed to register default brain", "error", err)
+		}
+	}
+
+	return r
+}
+
+// ValidateBrainID は脳IDの形式を検証
+func ValidateBrainID(id string) error {
+	if !brainIDPattern.MatchString(id) {
+		return ErrInvalidBrainID
+	}
+	return nil
+}
+
+// Get は脳IDに対応する Brain を返す
+// メモリ上にない場合はデータベースからロードする。既定の脳は存在しなければ自動作成する
+func (r *Registry) Get(id string) (*Brain, error) {
+	if err := ValidateBrainID(id); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.waitClosingLocked(id)
+
+	if entry, ok := r.brains[id]; ok {
+		entry.lastUsed = time.Now()
+		r.mu.Unlock()
+		return entry.brain, nil
+	}
+
+	if id != store.DefaultBrainID {
+		exists, err := r.existsLocked(id)
+		if err != nil {
+			r.mu.Unlock()
+			return nil, err
+		}
+		if !exists {
+			r.mu.Unlock()
+			return nil, ErrBrainNotFound
+		}
+	}
+
+	brain, evicted, err := r.loadLocked(id, "")
+	r.mu.Unlock()
+	r.closeDetached(evicted...)
+	return brain, err
+}
+
+// Create は新しい脳をプロファイルを指定して作成し、返す
+// profile が空の場合は既定のプロファイルを使う。存在しないプロファイルの場合は ErrProfileNotFound
+func (r *Registry) Create(id, profile string) (*Brain, error) {
+	if err := ValidateBrainID(id); err != nil {
+		return nil, err
+	}
+	if profile == "" {
+		profile = r.defaultProfile
+	}
+	if _, err := r.profiles.Get(profile); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.waitClosingLocked(id)
+
+	if _, ok := r.brains[id]; ok {
+		r.mu.Unlock()
+		return nil, ErrBrainExists
+	}
+	exists, err := r.existsLocked(id)
+	if err != nil {
+		r.mu.Unlock()
+		return nil, err
+	}
+	if exists {
+		r.mu.Unlock()
+		return nil, ErrBrainExists
+	}
+
+	slog.Info("Brain created", "brain_id", id, "profile", profile)
+	brain, evicted, err := r.loadLocked(id, profile)
+	r.mu.Unlock()
+	r.closeDetached(evicted...)
+	return brain, err
+}
+
+// List は登録済みの脳を一覧で返す
+func (r *Registry) List() ([]BrainInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	infos := make(map[string]BrainInfo)
+	if r.db != nil {
+		records, err := r.db.ListBrains()
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range records {
+			infos[rec.ID] = BrainInfo{ID: rec.ID, CreatedAt: rec.CreatedAt, Profile: rec.Profile}
+		}
+	}
+	for id, info := range r.memoryOnly {
+		infos[id] = info
+	}
+	for id, entry := range r.brains {
+		info := infos[id]
+		info.ID = id
+		info.Profile = entry.brain.Profile
+		info.Active = true
+		infos[id] = info
+	}
+
+	result := make([]BrainInfo, 0, len(infos))
+	for _, info := range infos {
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result, nil
+}
+
+// Profiles はパーソナリティプロファイルの一覧を返す
+func (r *Registry) Profiles() *personality.Catalog {
+	return r.profiles
+}
+
+// Active はメモリ上にロードされている脳を脳ID順で返す
+// 【用途】Scheduler が自律活動の対象とする (最終利用時刻は更新しない)
+func (r *Registry) Active() []*Brain {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	brains := make([]*Brain, 0, len(r.brains))
+	for _, entry := range r.brains {
+		brains = append(brains, entry.brain)
+	}
+	sort.Slice(brains, func(i, j int) bool {
+		return brains[i].ID < brains[j].ID
+	})
+	return brains
+}
+
+// Evict は脳をメモリから退避する
+// 退避前に睡眠処理を行い、短期記憶を長期記憶へ固定化する
+func (r *Registry) Evict(id string) error {
+	r.mu.Lock()
+	if _, ok := r.brains[id]; !ok {
+		r.mu.Unlock()
+		return ErrBrainNotFound
+	}
+	d := r.detachLocked(id, true)
+	r.mu.Unlock()
+
+	r.closeDetached(d)
+	return nil
+}
+
+// Delete は脳とその全ての記憶を削除する
+func (r *Registry) Delete(id string) error {
+	if err := ValidateBrainID(id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.waitClosingLocked(id)
+
+	_, active := r.brains[id]
+	exists, err := r.existsLocked(id)
+	if err != nil {
+		r.mu.Unlock()
+		return err
+	}
+	if !active && !exists {
+		r.mu.Unlock()
+		return ErrBrainNotFound
+	}
+
+	// 削除する脳の記憶は固定化しない
+	// メモリ上になくても、削除が終わるまで同じIDの脳をロードさせないために一覧から外した扱いにする
+	d := r.detachLocked(id, false)
+	delete(r.memoryOnly, id)
+	r.mu.Unlock()
+
+	// 脳を閉じて最終状態を書き終えてから行を削除する (閉じた脳はそれ以降書き込まない)
+	defer r.finishDetached(d)
+	if d.brain != nil {
+		if err := d.brain.close(false); err != nil {
+			slog.Warn("Failed to close deleted brain", "brain_id", id, "error", err)
+		}
+	}
+	if r.db != nil {
+		if err := r.db.DeleteBrain(id); err != nil {
+			return err
+		}
+	}
+	deleteBrainMetrics(id)
+
+	slog.Info("Brain deleted", "brain_id", id)
+	return nil
+}
+
+// Close は全ての脳を睡眠させて解放し、共有データベース接続を閉じる
+func (r *Registry) Close() error {
+	r.mu.Lock()
+	detached := make([]*detachedBrain, 0, len(r.brains))
+	for id := range r.brains {
+		detached = append(detached, r.detachLocked(id, true))
+	}
+	r.mu.Unlock()
+
+	var errs []error
+	for _, d := range detached {
+		if err := d.brain.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close brain %s: %w", d.id, err))
+		}
+		r.finishDetached(d)
+	}
+
+	// 他の呼び出しで退避・削除の途中の脳が閉じ終わるのを待つ
+	r.mu.Lock()
+	for len(r.closing) > 0 {
+		for id := range r.closing {
+			r.waitClosingLocked(id)
+			break
+		}
+	}
+	r.mu.Unlock()
+
+	if r.db != nil {
+		if err := r.db.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close database: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown は制限時間内に Close を行う
+// 制限時間を過ぎた場合は完了を待たずに ctx のエラーを返す (終了処理自体はバックグラウンドで継続する)
+func (r *Registry) Shutdown(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Close()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// existsLocked は脳が登録済みか判定 (r.mu を保持した状態で呼ぶこと)
+func (r *Registry) existsLocked(id string) (bool, error) {
+	if r.db == nil {
+		_, ok := r.memoryOnly[id]
+		return ok, nil
+	}
+	return r.db.BrainExists(id)
+}
+
+// loadLocked は脳をメモリ上に生成する (r.mu を保持した状態で呼ぶこと)
+// profile が空の場合は登録済みのプロファイル (未登録なら既定のプロファイル) を使う
+// 保持数が上限に達している場合は最も使われていない脳を一覧から外して返す
+// (呼び出し側は r.mu を解放してから closeDetached で閉じること)
+func (r *Registry) loadLocked(id, profile string) (*Brain, []*detachedBrain, error) {
+	if profile == "" {
+		name, err := r.registeredProfileLocked(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		profile = name
+	}
+	p, err := r.profiles.Get(profile)
+	if err != nil {
+		// 登録後にプロファイルファイルが削除された場合などは既定のプロファイルで起動する
+		slog.Warn("Brain profile not found, using default profile",
+			"brain_id", id, "profile", profile, "default_profile", r.defaultProfile)
+		if p, err = r.profiles.Get(r.defaultProfile); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var evicted []*detachedBrain
+	for len(r.brains) >= r.maxActive {
+		evicted = append(evicted, r.detachLocked(r.leastRecentlyUsedLocked(), true))
+	}
+
+	var db *store.DB
+	if r.db != nil {
+		if err := r.db.CreateBrain(id, profile); err != nil {
+			return nil, evicted, err
+		}
+		db = r.db.ForBrain(id)
+	} else if _, ok := r.memoryOnly[id]; !ok {
+		r.memoryOnly[id] = BrainInfo{ID: id, CreatedAt: time.Now(), Profile: profile}
+	}
+
+	brain := NewWithStore(id, p, r.lexicon, r.cfg, db)
+	r.brains[id] = &registryEntry{brain: brain, lastUsed: time.Now()}
+
+	return brain, evicted, nil
+}
+
+// registeredProfileLocked は登録済みの脳のプロファイル名を返す (未登録なら既定のプロファイル名)
+// (r.mu を保持した状態で呼ぶこと)
+func (r *Registry) registeredProfileLocked(id string) (string, error) {
+	if r.db == nil {
+		if info, ok := r.memoryOnly[id]; ok {
+			return info.Profile, nil
+		}
+		return r.defaultProfile, nil
+	}
+
+	profile, err := r.db.BrainProfile(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return r.defaultProfile, nil
+	}
+	return profile, err
+}
+
+// detachLocked は脳を一覧から外し、閉じ終わるまで同じIDをロードさせない印を付ける (r.mu を保持した状態で呼ぶこと)
+// 脳を閉じるのは呼び出し側が r.mu を解放してから (closeDetached)
+func (r *Registry) detachLocked(id string, consolidate bool) *detachedBrain {
+	d := &detachedBrain{id: id, consolidate: consolidate, done: make(chan struct{})}
+	if entry, ok := r.brains[id]; ok {
+		d.brain = entry.brain
+		delete(r.brains, id)
+	}
+	r.closing[id] = d.done
+	return d
+}
+
+// closeDetached は一覧から外した脳を睡眠させてから閉じる (r.mu を保持せずに呼ぶこと)
+// 閉じた脳は以降の入力を ErrBrainClosed で拒否するため、退避前に取得した参照からは状態を変更・保存できない
+func (r *Registry) closeDetached(detached ...*detachedBrain) {
+	for _, d := range detached {
+		if d.brain != nil {
+			if err := d.brain.close(d.consolidate); err != nil {
+				slog.Warn("Failed to close evicted brain", "brain_id", d.id, "error", err)
+			}
+			slog.Info("Brain evicted", "brain_id", d.id)
+		}
+		r.finishDetached(d)
+	}
+}
+
+// finishDetached は脳を閉じ終えたことを記録し、同じIDのロードを待っている呼び出しを再開させる
+func (r *Registry) finishDetached(d *detachedBrain) {
+	r.mu.Lock()
+	if r.closing[d.id] == d.done {
+		delete(r.closing, d.id)
+	}
+	r.mu.Unlock()
+	close(d.done)
+}
+
+// waitClosingLocked は脳IDが退避・削除の途中であれば閉じ終わるまで待つ
+// (r.mu を保持した状態で呼ぶこと。待つ間は r.mu を解放する)
+func (r *Registry) waitClosingLocked(id string) {
+	for {
+		done, ok := r.closing[id]
+		if !ok {
+			return
+		}
+		r.mu.Unlock()
+		<-done
+		r.mu.Lock()
+	}
+}
+
+// leastRecentlyUsedLocked は最も長く使われていない脳のIDを返す (r.mu を保持した状態で呼ぶこと)
+func (r *Registry) leastRecentlyUsedLocked() string {
+	var (
+		oldestID   string
+		oldestTime time.Time
+	)
+	for id, entry := range r.brains {
+		if oldestID == "" || entry.lastUsed.Before(oldestTime) {
+			oldestID = id
+			oldestTime = entry.lastUsed
+		}
+	}
+	return oldestID
+}
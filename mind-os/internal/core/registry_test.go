@@ -0,0 +1,215 @@
+package core
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/umekku/mind-os/internal/config"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// newTestConfig はテスト用の設定を作る (データベースは一時ディレクトリに作成)
+func newTestConfig(t *testing.T, maxActive int) *config.Config {
+	t.Helper()
+	return &config.Config{
+		DBPath:                 filepath.Join(t.TempDir(), "mind.db"),
+		MaxActiveBrains:        maxActive,
+		DefaultProfile:         "default",
+		STMMaxSize:             100,
+		LTMMaxSize:             1000,
+		ConsolidationThreshold: 0.6,
+		MemoryStabilityHours:   24,
+		MemoryForgetFloor:      0.05,
+		HormoneDecayRate:       10,
+		PFCInitialSanity:       80,
+		PFCControlThreshold:    30,
+		PFCNegativeSuppression: 0.5,
+		PFCPositiveBoost:       0.1,
+		BasalAlpha:             0.5,
+		BasalBeta:              0.3,
+		BasalDecayRate:         0.95,
+		DayTimeStart:           6,
+		NightTimeStart:         22,
+	}
+}
+
+func newTestRegistry(t *testing.T, maxActive int) *Registry {
+	t.Helper()
+	r := NewRegistry(newTestConfig(t, maxActive), nil, nil)
+	if r.db == nil {
+		t.Fatal("NewRegistry: database was not opened")
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+var chatInput = models.SensoryInput{Type: models.SignalChat, InputText: "嬉しい"}
+
+func TestRegistry_EvictWhileInUse(t *testing.T) {
+	r := newTestRegistry(t, 1)
+
+	stale, err := r.Create("alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stale.ProcessInput(chatInput); err != nil {
+		t.Fatalf("ProcessInput() error = %v", err)
+	}
+	if _, err := stale.UpdateMotivation(true); err != nil {
+		t.Fatalf("UpdateMotivation() error = %v", err)
+	}
+	before := stale.GetState()
+
+	// 上限を超えるロードで alice が退避される
+	if _, err := r.Create("bob", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// 退避前に取得した参照からは状態を変更できない
+	if _, err := stale.ProcessInput(chatInput); !errors.Is(err, ErrBrainClosed) {
+		t.Errorf("ProcessInput() on evicted brain error = %v, want ErrBrainClosed", err)
+	}
+	if _, err := stale.AddMemory("覚えておいて"); !errors.Is(err, ErrBrainClosed) {
+		t.Errorf("AddMemory() on evicted brain error = %v, want ErrBrainClosed", err)
+	}
+	if _, err := stale.UpdateMotivation(true); !errors.Is(err, ErrBrainClosed) {
+		t.Errorf("UpdateMotivation() on evicted brain error = %v, want ErrBrainClosed", err)
+	}
+	if err := stale.ApplyStress(50); !errors.Is(err, ErrBrainClosed) {
+		t.Errorf("ApplyStress() on evicted brain error = %v, want ErrBrainClosed", err)
+	}
+
+	// 取得し直すと保存済みの状態から新しいインスタンスがロードされる
+	fresh, err := r.Get("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh == stale {
+		t.Fatal("Get() after evict returned the closed instance")
+	}
+	if got := fresh.GetState(); got.Motivation != before.Motivation || got.Sanity != before.Sanity {
+		t.Errorf("restored state = motivation %d, sanity %d, want %d, %d", got.Motivation, got.Sanity, before.Motivation, before.Sanity)
+	}
+	if _, err := fresh.ProcessInput(chatInput); err != nil {
+		t.Errorf("ProcessInput() on reloaded brain error = %v", err)
+	}
+}
+
+func TestRegistry_DeleteWhileInUse(t *testing.T) {
+	r := newTestRegistry(t, 10)
+
+	stale, err := r.Create("alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stale.AddMemory("初めての記憶"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.Delete("alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 削除後に古い参照から操作しても行が復活しない
+	if _, err := stale.AddMemory("削除後の記憶"); !errors.Is(err, ErrBrainClosed) {
+		t.Errorf("AddMemory() on deleted brain error = %v, want ErrBrainClosed", err)
+	}
+	if _, err := stale.Sleep(); !errors.Is(err, ErrBrainClosed) {
+		t.Errorf("Sleep() on deleted brain error = %v, want ErrBrainClosed", err)
+	}
+	if _, err := stale.ResetMotivation(); !errors.Is(err, ErrBrainClosed) {
+		t.Errorf("ResetMotivation() on deleted brain error = %v, want ErrBrainClosed", err)
+	}
+	if err := stale.Close(); err != nil {
+		t.Errorf("Close() on deleted brain error = %v", err)
+	}
+
+	if exists, err := r.db.BrainExists("alice"); err != nil || exists {
+		t.Errorf("BrainExists(alice) = %v, %v, want false", exists, err)
+	}
+	if state, err := r.db.ForBrain("alice").LoadBrainState(); err != nil || state != nil {
+		t.Errorf("LoadBrainState(alice) = %+v, %v, want no row", state, err)
+	}
+	if count, err := r.db.ForBrain("alice").GetLTMCount(); err != nil || count != 0 {
+		t.Errorf("GetLTMCount(alice) = %d, %v, want 0", count, err)
+	}
+	if _, err := r.Get("alice"); !errors.Is(err, ErrBrainNotFound) {
+		t.Errorf("Get(alice) after delete error = %v, want ErrBrainNotFound", err)
+	}
+}
+
+func TestRegistry_CloseOutsideLock(t *testing.T) {
+	r := newTestRegistry(t, 10)
+
+	alice, err := r.Create("alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Create("bob", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// alice の処理中 (ロックを保持) に退避を始める
+	alice.mu.Lock()
+	evicted := make(chan error, 1)
+	go func() { evicted <- r.Evict("alice") }()
+	waitUntil(t, func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		_, closing := r.closing["alice"]
+		return closing
+	})
+
+	// 退避の完了を待たずに他の脳を取得・一覧できる
+	done := make(chan error, 1)
+	go func() {
+		if _, err := r.Get("bob"); err != nil {
+			done <- err
+			return
+		}
+		_, err := r.List()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Get(bob)/List() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Get(bob)/List() blocked while alice was closing")
+	}
+
+	// 同じIDのロードは退避が終わるまで待つ
+	reloaded := make(chan *Brain, 1)
+	go func() {
+		b, _ := r.Get("alice")
+		reloaded <- b
+	}()
+	select {
+	case <-reloaded:
+		t.Fatal("Get(alice) returned before the evicted instance was closed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	alice.mu.Unlock()
+	if err := <-evicted; err != nil {
+		t.Fatalf("Evict() error = %v", err)
+	}
+	if b := <-reloaded; b == nil || b == alice {
+		t.Errorf("Get(alice) = %p, want a new instance (evicted %p)", b, alice)
+	}
+}
+
+// waitUntil は cond が満たされるまで待つ
+func waitUntil(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 5s")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
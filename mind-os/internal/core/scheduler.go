@@ -0,0 +1,265 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/umekku/mind-os/internal/config"
+	"github.com/umekku/mind-os/internal/hypothalamus"
+)
+
+// schedulerHistorySize はステータスに保持する直近の活動の件数
+const schedulerHistorySize = 50
+
+// ActivityKind は自律活動の種類
+type ActivityKind string
+
+const (
+	ActivityDecay  ActivityKind = "decay"  // 意欲・ホルモンの時間減衰
+	ActivitySleep  ActivityKind = "sleep"  // 夜間の睡眠 (記憶の固定化)
+	ActivityWander ActivityKind = "wander" // 無刺激時のマインドワンダリング
+)
+
+// Activity は自律活動の記録
+type Activity struct {
+	Time    time.Time
+	BrainID string
+	Kind    ActivityKind
+	Detail  string
+}
+
+// SchedulerConfig は自律活動スケジューラの設定
+type SchedulerConfig struct {
+	Enabled         bool
+	Tick            time.Duration // 巡回間隔
+	DecayInterval   time.Duration // 意欲・ホルモンを減衰させる間隔
+	IdleWanderAfter time.Duration // 刺激がない状態がこの時間続くとマインドワンダリングを行う
+	DayStart        int           // 日中開始 (時)
+	NightStart      int           // 夜間開始 (時)。夜間は会話が途切れたら睡眠を行う
+}
+
+// NewSchedulerConfig は config.Config からスケジューラ設定を生成
+func NewSchedulerConfig(cfg *config.Config) SchedulerConfig {
+	return SchedulerConfig{
+		Enabled:         cfg.SchedulerEnabled,
+		Tick:            time.Duration(cfg.SchedulerTickSeconds) * time.Second,
+		DecayInterval:   time.Duration(cfg.DecayIntervalMinutes) * time.Minute,
+		IdleWanderAfter: time.Duration(cfg.IdleWanderAfterMinutes) * time.Minute,
+		DayStart:        cfg.DayTimeStart,
+		NightStart:      cfg.NightTimeStart,
+	}
+}
+
+// SchedulerStatus はスケジューラの稼働状況
+type SchedulerStatus struct {
+	Config    SchedulerConfig
+	Running   bool
+	StartedAt time.Time
+	LastTick  time.Time
+	Counts    map[ActivityKind]int // 起動以降の活動回数
+	Recent    []Activity           // 直近の活動 (新しい順)
+}
+
+// Scheduler は脳の自律活動を定期的に実行する
+// 【神経科学的意味】外部からの刺激がなくても脳は活動を続ける。
+// 時間とともに意欲やホルモンはベースラインへ戻り、退屈するとデフォルトモードネットワークが働き、
+// 夜になれば眠って記憶を整理する。
+// 【処理内容】メモリ上にロードされている全ての脳を巡回し、条件を満たした活動を実行する
+type Scheduler struct {
+	registry *Registry
+	cfg      SchedulerConfig
+
+	mu        sync.Mutex
+	cancel    context.CancelFunc
+	done      chan struct{}
+	startedAt time.Time
+	lastTick  time.Time
+	counts    map[ActivityKind]int
+	recent    []Activity
+}
+
+// NewScheduler は新しい Scheduler を作成
+func NewScheduler(registry *Registry, cfg SchedulerConfig) *Scheduler {
+	return &Scheduler{
+		registry: registry,
+		cfg:      cfg,
+		counts:   make(map[ActivityKind]int),
+	}
+}
+
+// Start はスケジューラをバックグラウンドで起動する
+// 無効化されている場合や起動済みの場合は何もしない。ctx のキャンセルまたは Stop で停止する
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.cfg.Enabled || s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	s.startedAt = time.Now()
+
+	go s.run(ctx, s.done)
+
+	slog.Info("Scheduler started",
+		"tick", s.cfg.Tick.String(),
+		"decay_interval", s.cfg.DecayInterval.String(),
+		"idle_wander_after", s.cfg.IdleWanderAfter.String(),
+	)
+}
+
+// Stop はスケジューラを停止し、実行中の巡回が終わるまで待つ
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+
+	slog.Info("Scheduler stopped")
+}
+
+// Status はスケジューラの稼働状況を返す
+func (s *Scheduler) Status() SchedulerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make(map[ActivityKind]int, len(s.counts))
+	for k, v := range s.counts {
+		counts[k] = v
+	}
+
+	recent := make([]Activity, len(s.recent))
+	for i, a := range s.recent {
+		recent[len(s.recent)-1-i] = a
+	}
+
+	return SchedulerStatus{
+		Config:    s.cfg,
+		Running:   s.cancel != nil,
+		StartedAt: s.startedAt,
+		LastTick:  s.lastTick,
+		Counts:    counts,
+		Recent:    recent,
+	}
+}
+
+// run は巡回ループ
+func (s *Scheduler) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(s.cfg.Tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.tick(ctx, now)
+		}
+	}
+}
+
+// tick はロードされている全ての脳に対して自律活動を1回分実行する
+func (s *Scheduler) tick(ctx context.Context, now time.Time) {
+	for _, brain := range s.registry.Active() {
+		if ctx.Err() != nil {
+			return
+		}
+		for _, activity := range brain.runAutonomic(now, s.cfg) {
+			s.record(activity)
+		}
+	}
+
+	s.mu.Lock()
+	s.lastTick = now
+	s.mu.Unlock()
+}
+
+// record は活動を記録する
+func (s *Scheduler) record(a Activity) {
+	slog.Debug("Autonomic activity", "brain_id", a.BrainID, "kind", a.Kind, "detail", a.Detail)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts[a.Kind]++
+	s.recent = append(s.recent, a)
+	if len(s.recent) > schedulerHistorySize {
+		s.recent = s.recent[len(s.recent)-schedulerHistorySize:]
+	}
+}
+
+// runAutonomic は脳の自律活動を実行し、行った活動を返す
+// 【処理内容】
+// 1. 減衰: DecayInterval ごとに意欲をベースラインへ戻し、ホルモンの自然減衰と概日リズムを更新
+// 2. 睡眠: 夜間に会話が途切れていれば、その夜に一度だけ記憶を固定化する
+// 3. マインドワンダリング: 日中に IdleWanderAfter 以上刺激がなければ記憶を回想する
+func (b *Brain) runAutonomic(now time.Time, cfg SchedulerConfig) []Activity {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+
+	var activities []Activity
+	activity := func(kind ActivityKind, format string, args ...any) {
+		activities = append(activities, Activity{Time: now, BrainID: b.ID, Kind: kind, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	// 1. 時間経過による減衰
+	if now.Sub(b.lastDecay) >= cfg.DecayInterval {
+		b.BasalGanglia.ApplyDecay()
+		b.Hypothalamus.Decay()
+		b.Hypothalamus.UpdateCircadianRhythm(now)
+		b.decayCoreAffectLocked(now)
+		b.lastDecay = now
+		b.persistStateLocked()
+		b.publishLocked(EventDecay)
+
+		cortisol, oxytocin := b.Hypothalamus.GetStatus()
+		activity(ActivityDecay, "motivation=%d cortisol=%.1f oxytocin=%.1f", b.BasalGanglia.GetMotivation(), cortisol, oxytocin)
+	}
+
+	idle := now.Sub(b.lastStimulus)
+	if idle < cfg.IdleWanderAfter {
+		return activities
+	}
+
+	// 2. 夜間の睡眠 (会話が途切れてから、一晩に一度)
+	if hypothalamus.IsNightHour(now.Hour(), cfg.DayStart, cfg.NightStart) {
+		if b.lastSleep.Before(hypothalamus.NightBegan(now, cfg.NightStart)) {
+			result := b.sleepLocked()
+			b.lastSleep = now
+			activity(ActivitySleep, "consolidated=%d forgotten=%d faded=%d removed=%d", result.ConsolidatedCount, result.ForgottenCount, result.FadedCount, result.RemovedCount)
+		}
+		// 眠っている間はマインドワンダリングを行わない
+		return activities
+	}
+
+	// 3. 無刺激時のマインドワンダリング
+	since := b.lastStimulus
+	if b.lastDaydream.After(since) {
+		since = b.lastDaydream
+	}
+	if wandering := now.Sub(since); wandering >= cfg.IdleWanderAfter {
+		result := b.wanderMindLocked(DaydreamOptions{Duration: wandering})
+		b.lastDaydream = now
+		activity(ActivityWander, "recalled=%d mood=%s", len(result.Recollections), result.MoodTendency)
+	}
+
+	return activities
+}
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+// testSchedulerConfig は既定の夜間 (22時 → 6時) のスケジューラ設定
+var testSchedulerConfig = SchedulerConfig{
+	Tick:            time.Minute,
+	DecayInterval:   10 * time.Minute,
+	IdleWanderAfter: 30 * time.Minute,
+	DayStart:        6,
+	NightStart:      22,
+}
+
+// tickActivities は1回分の巡回を実行し、行った活動の種類を実行順に返す
+func tickActivities(r *Registry, cfg SchedulerConfig, now time.Time) []ActivityKind {
+	s := NewScheduler(r, cfg)
+	s.tick(context.Background(), now)
+
+	recent := s.Status().Recent
+	kinds := make([]ActivityKind, len(recent))
+	for i, a := range recent {
+		kinds[len(recent)-1-i] = a.Kind
+	}
+	return kinds
+}
+
+// resetAutonomic は自律活動の記録を設定する
+func resetAutonomic(b *Brain, lastStimulus, lastDecay time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastStimulus = lastStimulus
+	b.lastDecay = lastDecay
+	b.lastSleep = time.Time{}
+	b.lastDaydream = time.Time{}
+}
+
+func TestScheduler_Tick(t *testing.T) {
+	r := newTestRegistry(t, 10)
+	brain, err := r.Create("alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := func(hour int) time.Time {
+		return time.Date(2026, time.March, 10, hour, 0, 0, 0, time.Local)
+	}
+	// 日付をまたがない夜間 (1時 → 5時)
+	earlyNight := testSchedulerConfig
+	earlyNight.DayStart, earlyNight.NightStart = 5, 1
+
+	tests := []struct {
+		name  string
+		cfg   SchedulerConfig
+		now   time.Time
+		idle  time.Duration // 最後の入力からの経過時間
+		since time.Duration // 最後の減衰からの経過時間
+		want  []ActivityKind
+	}{
+		// 減衰
+		{"減衰の間隔前", testSchedulerConfig, at(12), time.Minute, 9 * time.Minute, nil},
+		{"減衰の間隔経過", testSchedulerConfig, at(12), time.Minute, 10 * time.Minute, []ActivityKind{ActivityDecay}},
+		// マインドワンダリング
+		{"無刺激が閾値未満", testSchedulerConfig, at(12), 29 * time.Minute, 0, nil},
+		{"日中の無刺激", testSchedulerConfig, at(12), 30 * time.Minute, 0, []ActivityKind{ActivityWander}},
+		{"減衰と無刺激", testSchedulerConfig, at(12), time.Hour, time.Hour, []ActivityKind{ActivityDecay, ActivityWander}},
+		// 日付をまたぐ夜間 (22時 → 6時)
+		{"夜間の開始", testSchedulerConfig, at(22), 30 * time.Minute, 0, []ActivityKind{ActivitySleep}},
+		{"日付をまたいだ夜間", testSchedulerConfig, at(3), 30 * time.Minute, 0, []ActivityKind{ActivitySleep}},
+		{"夜間でも会話中は眠らない", testSchedulerConfig, at(23), 29 * time.Minute, 0, nil},
+		{"夜明け後は眠らない", testSchedulerConfig, at(6), 30 * time.Minute, 0, []ActivityKind{ActivityWander}},
+		// 日付をまたがない夜間 (1時 → 5時)
+		{"日付内の夜間", earlyNight, at(3), 30 * time.Minute, 0, []ActivityKind{ActivitySleep}},
+		{"日付内の夜間の前", earlyNight, at(23), 30 * time.Minute, 0, []ActivityKind{ActivityWander}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetAutonomic(brain, tt.now.Add(-tt.idle), tt.now.Add(-tt.since))
+			if got := tickActivities(r, tt.cfg, tt.now); !slices.Equal(got, tt.want) {
+				t.Errorf("tick(%s) = %v, want %v", tt.now.Format("15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduler_SleepOncePerNight(t *testing.T) {
+	r := newTestRegistry(t, 10)
+	brain, err := r.Create("alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	night := time.Date(2026, time.March, 10, 23, 0, 0, 0, time.Local)
+	resetAutonomic(brain, night.Add(-time.Hour), night)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want []ActivityKind
+	}{
+		{"最初の巡回で眠る", night, []ActivityKind{ActivitySleep}},
+		{"同じ夜の巡回", night.Add(time.Minute), nil},
+		{"日付をまたいでも同じ夜", night.Add(4 * time.Hour), nil},
+		{"次の夜", night.Add(24 * time.Hour), []ActivityKind{ActivitySleep}},
+	}
+
+	for _, tt := range tests {
+		// 減衰は対象外
+		brain.mu.Lock()
+		brain.lastDecay = tt.now
+		brain.mu.Unlock()
+
+		if got := tickActivities(r, testSchedulerConfig, tt.now); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: tick(%s) = %v, want %v", tt.name, tt.now.Format("01/02 15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestScheduler_IdleWander(t *testing.T) {
+	r := newTestRegistry(t, 10)
+	brain, err := r.Create("alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input := time.Date(2026, time.March, 10, 10, 0, 0, 0, time.Local)
+	resetAutonomic(brain, input, input)
+
+	cfg := testSchedulerConfig
+	cfg.DecayInterval = 24 * time.Hour // 減衰は対象外
+
+	tests := []struct {
+		name  string
+		after time.Duration // 最後の入力からの経過時間
+		want  []ActivityKind
+	}{
+		{"入力の直後", time.Minute, nil},
+		{"閾値の直前", 29 * time.Minute, nil},
+		{"閾値に達した", 30 * time.Minute, []ActivityKind{ActivityWander}},
+		// 前回のマインドワンダリングから数え直す
+		{"前回の直後", 40 * time.Minute, nil},
+		{"前回から閾値に達した", time.Hour, []ActivityKind{ActivityWander}},
+	}
+
+	for _, tt := range tests {
+		if got := tickActivities(r, cfg, input.Add(tt.after)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: tick(+%v) = %v, want %v", tt.name, tt.after, got, tt.want)
+		}
+	}
+
+	// 入力があれば、そこから閾値に達するまでマインドワンダリングしない
+	brain.mu.Lock()
+	brain.lastStimulus = input.Add(70 * time.Minute)
+	brain.mu.Unlock()
+	if got := tickActivities(r, cfg, input.Add(99*time.Minute)); len(got) != 0 {
+		t.Errorf("tick(29m after input) = %v, want nil", got)
+	}
+	if got := tickActivities(r, cfg, input.Add(100*time.Minute)); !slices.Equal(got, []ActivityKind{ActivityWander}) {
+		t.Errorf("tick(30m after input) = %v, want [wander]", got)
+	}
+}
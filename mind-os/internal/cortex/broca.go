@@ -3,6 +3,7 @@ package cortex
 import (
 	"fmt"
 	"math/rand"
+	"slices"
 
 	"github.com/umekku/mind-os/internal/models"
 )
@@ -11,35 +12,72 @@ import (
 // 『脳科学的意味』前頭葉に位置し、可動性言語生成に関与する領域
 // 「分節化された現在の感情・意欲・理性状態に基づいて適切な応答テキストを選択・生成」
 type BrocaArea struct {
-	templates map[string][]string // 感情ごとのテンプレート
+	phrasebooks map[models.Language]*phrasebook // 言語ごとのテンプレート・定型句
+}
+
+// BrocaOption は BrocaArea の設定を変更する関数
+type BrocaOption func(*BrocaArea)
+
+// WithTemplates は言語 lang の感情キーごとの応答テンプレートを差し替える (指定していない感情は既定のテンプレートを使う)
+// 【用途】キャラクターごとの口調 (パーソナリティプロファイルのテンプレートセット)
+// 対応していない言語の指定は無視する
+func WithTemplates(lang models.Language, templates map[string][]string) BrocaOption {
+	return func(b *BrocaArea) {
+		book, ok := b.phrasebooks[lang]
+		if !ok {
+			return
+		}
+		for key, phrases := range templates {
+			if len(phrases) > 0 {
+				book.templates[key] = slices.Clone(phrases)
+			}
+		}
+	}
 }
 
 // NewBrocaArea は新しいブローカ野インスタンスを作成
 // 「分節化された応答テンプレートをロードして初期化」
-func NewBrocaArea() *BrocaArea {
-	return &BrocaArea{
-		templates: initializeTemplates(),
+func NewBrocaArea(opts ...BrocaOption) *BrocaArea {
+	b := &BrocaArea{
+		phrasebooks: initializePhrasebooks(),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// phrasebook は言語の定型句を返す (対応していない言語の場合は既定の言語)
+func (b *BrocaArea) phrasebook(lang models.Language) *phrasebook {
+	if book, ok := b.phrasebooks[lang]; ok {
+		return book
 	}
+	return b.phrasebooks[models.DefaultLanguage]
 }
 
-// GenerateResponse は現在の心理状態に基づいて応答を生成
+// GenerateResponse は現在の心理状態に基づいて、言語 lang で応答を生成
 // 【アルゴリズム】
 // 1. 意欲チェック: 極端に低い場合は応答拒否
 // 2. 感情判定: 支配的な感情を特定
 // 3. 意図分類: 挨拶/質問/陳述に応じて生成ロジックを選択
-// 4. 理性チェック: 理性が低い場合は混乱表現を追加
+// 4. 回想: 話題に関連する記憶が想起されていれば言及を追加
+// 5. 理性チェック: 理性が低い場合は混乱表現を追加
 func (b *BrocaArea) GenerateResponse(
+	lang models.Language,
 	emotions []models.EmotionValue,
 	motivation float64,
 	sanity float64,
 	concepts []string,
 	intent string,
+	recalled []models.RecalledMemory,
 ) string {
 	// 意欲が極端に低い場合は短文または無言
 	if motivation < 0.2 {
 		return "..."
 	}
 
+	book := b.phrasebook(lang)
+
 	// 主要な感情を判定
 	dominantEmotion := getDominantEmotion(emotions)
 
@@ -47,45 +85,79 @@ func (b *BrocaArea) GenerateResponse(
 	var baseResponse string
 	switch intent {
 	case "greeting":
-		baseResponse = b.generateGreeting(dominantEmotion, motivation)
+		baseResponse = book.generateGreeting(dominantEmotion, motivation)
 	case "question":
-		baseResponse = b.generateQuestionResponse(dominantEmotion, sanity, concepts)
+		baseResponse = book.generateQuestionResponse(dominantEmotion, sanity, concepts)
 	case "statement":
-		baseResponse = b.generateStatementResponse(dominantEmotion, concepts)
+		baseResponse = book.generateStatementResponse(dominantEmotion, concepts)
 	default:
-		baseResponse = b.generateDefaultResponse(dominantEmotion, motivation)
+		baseResponse = book.generateDefaultResponse(dominantEmotion, motivation)
+	}
+
+	// 想起された記憶への言及（余裕がある時のみ）
+	if motivation >= 0.3 && sanity >= 0.3 {
+		if reminiscence := book.generateReminiscence(dominantEmotion, recalled); reminiscence != "" {
+			baseResponse = baseResponse + " " + reminiscence
+		}
 	}
 
 	// 理性が低い場合、文脈が乱れる
 	if sanity < 0.3 {
-		baseResponse = addConfusion(baseResponse)
+		baseResponse = addConfusion(baseResponse, book.confusions)
 	}
 
 	return baseResponse
 }
 
+// contextualRetries は直前と同じ応答になった場合に生成し直す回数
+const contextualRetries = 3
+
+// GenerateResponseInContext は会話の文脈を踏まえて応答を生成
+// 【アルゴリズム】GenerateResponse で生成した応答が直前の自分の応答と同じ場合は生成し直し、
+// それでも同じなら繰り返しであることを前置きする（沈黙 "..." はそのまま）
+func (b *BrocaArea) GenerateResponseInContext(
+	lang models.Language,
+	emotions []models.EmotionValue,
+	motivation float64,
+	sanity float64,
+	concepts []string,
+	intent string,
+	recalled []models.RecalledMemory,
+	d Discourse,
+) string {
+	reply := b.GenerateResponse(lang, emotions, motivation, sanity, concepts, intent, recalled)
+	for i := 0; i < contextualRetries && reply == d.LastReply; i++ {
+		reply = b.GenerateResponse(lang, emotions, motivation, sanity, concepts, intent, recalled)
+	}
+
+	if reply == d.LastReply && reply != "..." {
+		reply = b.phrasebook(lang).repeatPrefix + reply
+	}
+	return reply
+}
+
 // generateGreeting は挨拶応答を生成
-func (b *BrocaArea) generateGreeting(emotion models.EmotionCode, motivation float64) string {
+func (p *phrasebook) generateGreeting(emotion models.EmotionCode, motivation float64) string {
 	if motivation < 0.3 {
-		return "...こんにちは"
+		return p.greetingTired
 	}
 
 	switch emotion {
 	case models.EmotionJoy:
-		return "こんにちは、元気だね！"
+		return p.greetingJoy
 	case models.EmotionAnger:
-		return "...何？"
+		return p.greetingAnger
 	case models.EmotionGrief:
-		return "...こんにちは..."
+		return p.greetingGrief
 	default:
-		return "こんにちは"
+		return p.greetingDefault
 	}
 }
 
 // generateQuestionResponse は質問への応答を生成
-func (b *BrocaArea) generateQuestionResponse(emotion models.EmotionCode, sanity float64, concepts []string) string {
+func (p *phrasebook) generateQuestionResponse(emotion models.EmotionCode, sanity float64, concepts []string) string {
 	if sanity < 0.3 {
-		return "よくわからない..."
+		return p.questionConfused
 	}
 
 	conceptText := ""
@@ -96,52 +168,76 @@ func (b *BrocaArea) generateQuestionResponse(emotion models.EmotionCode, sanity
 	switch emotion {
 	case models.EmotionJoy:
 		if conceptText != "" {
-			return fmt.Sprintf("%sのこと？知ってるよ！", conceptText)
+			return fmt.Sprintf(p.questionJoyAbout, conceptText)
 		}
-		return "何だろう？教えて！"
+		return p.questionJoyOpen
 	case models.EmotionAnger:
-		return "今はそんな気分じゃない"
+		return p.questionAnger
 	case models.EmotionFear:
-		return "わからない...怖い..."
+		return p.questionFear
 	default:
 		if conceptText != "" {
-			return fmt.Sprintf("%sについて？うーん...", conceptText)
+			return fmt.Sprintf(p.questionDefaultAbout, conceptText)
 		}
-		return "何だろう..."
+		return p.questionDefaultOpen
 	}
 }
 
 // generateStatementResponse は陳述への応答を生成
-func (b *BrocaArea) generateStatementResponse(emotion models.EmotionCode, concepts []string) string {
+func (p *phrasebook) generateStatementResponse(emotion models.EmotionCode, concepts []string) string {
 	conceptText := ""
 	if len(concepts) > 0 {
 		conceptText = concepts[0]
 	}
 
-	templates := b.templates[emotionToTemplateKey(emotion)]
+	templates := p.templates[emotionToTemplateKey(emotion)]
 	if len(templates) == 0 {
-		templates = b.templates["neutral"]
+		templates = p.templates["neutral"]
 	}
 
 	baseReply := templates[rand.Intn(len(templates))]
 
 	if conceptText != "" && emotion == models.EmotionJoy {
-		return fmt.Sprintf("%sって%s", conceptText, baseReply)
+		return fmt.Sprintf(p.statementJoy, conceptText, baseReply)
 	}
 
 	return baseReply
 }
 
 // generateDefaultResponse はデフォルト応答を生成
-func (b *BrocaArea) generateDefaultResponse(emotion models.EmotionCode, motivation float64) string {
+func (p *phrasebook) generateDefaultResponse(emotion models.EmotionCode, motivation float64) string {
 	if motivation < 0.3 {
 		return "..."
 	}
 
-	templates := b.templates[emotionToTemplateKey(emotion)]
+	templates := p.templates[emotionToTemplateKey(emotion)]
 	if len(templates) == 0 {
 		return "..."
 	}
 
 	return templates[rand.Intn(len(templates))]
 }
+
+// generateReminiscence は想起された記憶に言及する一文を生成
+// 【脳科学的意味】話題をきっかけに過去のエピソードが想起され、発話に織り込まれる
+// 概念が一致した記憶のみを対象とし、感情に応じて語り口を変える
+func (p *phrasebook) generateReminiscence(emotion models.EmotionCode, recalled []models.RecalledMemory) string {
+	for _, memory := range recalled {
+		if len(memory.MatchedConcepts) == 0 {
+			continue
+		}
+
+		excerpt := truncateRunes(memory.Text, 20)
+		switch emotion {
+		case models.EmotionJoy, models.EmotionLove:
+			return fmt.Sprintf(p.reminiscenceWarm, excerpt)
+		case models.EmotionGrief, models.EmotionFear:
+			return fmt.Sprintf(p.reminiscenceSad, excerpt)
+		case models.EmotionAnger, models.EmotionDisgust:
+			return fmt.Sprintf(p.reminiscenceAnnoyed, excerpt)
+		default:
+			return fmt.Sprintf(p.reminiscenceDefault, excerpt)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,17 @@
+package cortex
+
+// Discourse は会話の文脈
+// 『脳科学的意味』ワーキングメモリに保持された直前までのやり取り
+// 「指示語・省略の補完（ウェルニッケ野）と、同じ応答の繰り返しの回避（ブローカ野）に用いる」
+type Discourse struct {
+	Topics    []string // 直近の発話で話題になった概念 (新しい順)
+	LastReply string   // 直前の自分の応答
+}
+
+// topic は現在の話題 (直近の概念) を返す
+func (d Discourse) topic() (string, bool) {
+	if len(d.Topics) == 0 {
+		return "", false
+	}
+	return d.Topics[0], true
+}
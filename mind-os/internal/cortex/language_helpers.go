@@ -46,8 +46,16 @@ func emotionToTemplateKey(emotion models.EmotionCode) string {
 }
 
 // addConfusion は理性が低い時の混乱を追加
-// 【演出効果】テキスト末尾に曖昧な表現を付加し、混乱状態を表現
-func addConfusion(text string) string {
-	confusions := []string{"...", "あれ？", "どうだっけ...", "頭が回らない...", "何か変だな..."}
+// 【演出効果】テキスト末尾に曖昧な表現 (言語ごとの confusions から1つ) を付加し、混乱状態を表現
+func addConfusion(text string, confusions []string) string {
 	return text + " " + confusions[rand.Intn(len(confusions))]
 }
+
+// truncateRunes は文字数(rune)で文字列を切り詰め、省略記号を付加
+func truncateRunes(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit]) + "…"
+}
@@ -13,27 +13,49 @@ type SocialCognition struct {
 
 	EmpathyLevel float64 // 共感の強さ (0.0-1.0)
 
+	// Oxytocin 0 / 100 の時の共感の強さ (気質: 共感しやすさ)
+	minEmpathy float64
+	maxEmpathy float64
+
 	// 内部参照
 	amygdala *amygdala.Amygdala // ユーザー感情推定のため扁桃体を参照
 }
 
+// SocialOption は SocialCognition の設定を変更する関数
+type SocialOption func(*SocialCognition)
+
+// WithEmpathy は共感の強さの初期値と、Oxytocin 0 / 100 の時の強さ (0-1) を設定する
+func WithEmpathy(initial, min, max float64) SocialOption {
+	return func(sc *SocialCognition) {
+		sc.EmpathyLevel = initial
+		sc.minEmpathy = min
+		sc.maxEmpathy = max
+	}
+}
+
 // New は新しい SocialCognition インスタンスを作成
-func New(amyg *amygdala.Amygdala) *SocialCognition {
-	return &SocialCognition{
+func New(amyg *amygdala.Amygdala, opts ...SocialOption) *SocialCognition {
+	sc := &SocialCognition{
 		EmpathyLevel: 0.5, // 初期値: 中程度の共感性
+		minEmpathy:   0.2,
+		maxEmpathy:   1.0,
 		amygdala:     amyg,
 	}
+	for _, opt := range opts {
+		opt(sc)
+	}
+	return sc
 }
 
 // SimulateUserEmotion はユーザーが抱いている感情を推測
-// テキストから「ユーザーの感情状態」を推定し、最も強い感情を返す
-func (sc *SocialCognition) SimulateUserEmotion(text string) (models.EmotionValue, error) {
+// 言語 lang のテキストから「ユーザーの感情状態」を推定し、最も強い感情を返す
+func (sc *SocialCognition) SimulateUserEmotion(text string, lang models.Language) (models.EmotionValue, error) {
 	sc.mu.RLock()
 	defer sc.mu.RUnlock()
 
 	// Amygdalaを使ってテキストを解析
 	// （本来は文脈を考慮した推定が必要だが、簡易実装として扁桃体の結果を流用）
-	emotions := sc.amygdala.Assess(text)
+	emotions := sc.amygdala.AssessIn(text, lang).Emotions
 
 	if len(emotions) == 0 {
 		// 感情が検出されない場合はNeutralを返す
@@ -80,8 +102,8 @@ func (sc *SocialCognition) UpdateEmpathyLevel(oxytocin float64) {
 
 	// Oxytocin 0-100 を 0.0-1.0 の共感レベルに変換
 	// Oxytocin が高いほど共感性が高まる
-	// 最低でも0.2（基本的な共感）、最大1.0（完全な共感）
-	sc.EmpathyLevel = 0.2 + (oxytocin / 100.0 * 0.8)
+	// 既定では最低でも0.2（基本的な共感）、最大1.0（完全な共感）
+	sc.EmpathyLevel = sc.minEmpathy + (oxytocin / 100.0 * (sc.maxEmpathy - sc.minEmpathy))
 
 	if sc.EmpathyLevel > 1.0 {
 		sc.EmpathyLevel = 1.0
@@ -98,6 +120,28 @@ func (sc *SocialCognition) GetEmpathyLevel() float64 {
 	return sc.EmpathyLevel
 }
 
+// SetEmpathyLevel は共感レベルを直接設定（永続化からの復元用）
+func (sc *SocialCognition) SetEmpathyLevel(level float64) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.EmpathyLevel = level
+	if sc.EmpathyLevel > 1.0 {
+		sc.EmpathyLevel = 1.0
+	}
+	if sc.EmpathyLevel < 0.0 {
+		sc.EmpathyLevel = 0.0
+	}
+}
+
+// Contagion はユーザー感情のうち AI 自身の感情に伝染する分を返す (BlendEmotions が加算する値)
+func Contagion(userEmotion models.EmotionValue, empathyStrength float64) models.EmotionValue {
+	return models.EmotionValue{
+		Code:  userEmotion.Code,
+		Value: max(int(float64(userEmotion.Value)*empathyStrength*0.5), 0),
+	}
+}
+
 // BlendEmotions はユーザー感情とAI自身の感情をブレンド（情動伝染）
 // userEmotion: 推定されたユーザーの感情
 // myEmotions: AI自身が生成した感情
@@ -107,7 +151,7 @@ func BlendEmotions(userEmotion models.EmotionValue, myEmotions []models.EmotionV
 	// empathyStrength が高いほど、ユーザーの感情の影響が大きい
 
 	// ユーザー感情の影響度を計算
-	contagionValue := int(float64(userEmotion.Value) * empathyStrength * 0.5)
+	contagionValue := Contagion(userEmotion, empathyStrength).Value
 
 	if contagionValue <= 0 {
 		return myEmotions
@@ -1,5 +1,94 @@
 package cortex
 
+import "github.com/umekku/mind-os/internal/models"
+
+// TemplateKeys は応答テンプレートの感情キー
+var TemplateKeys = []string{"joy", "anger", "fear", "love", "disgust", "grief", "neutral"}
+
+// phrasebook は1つの言語の応答テンプレートと定型句
+// 書式の %s には概念 (話題) または想起された記憶の抜粋が入る
+type phrasebook struct {
+	templates map[string][]string // 感情キーごとの応答テンプレート
+
+	// 挨拶 (意欲が低い時 / 感情ごと / それ以外)
+	greetingTired   string
+	greetingJoy     string
+	greetingAnger   string
+	greetingGrief   string
+	greetingDefault string
+
+	// 質問への応答 (About は概念がある時の書式、Open は概念がない時)
+	questionConfused     string
+	questionJoyAbout     string
+	questionJoyOpen      string
+	questionAnger        string
+	questionFear         string
+	questionDefaultAbout string
+	questionDefaultOpen  string
+
+	// 喜びの陳述で概念に言及する書式 (概念, テンプレート)
+	statementJoy string
+
+	// 記憶への言及 (喜び・愛 / 悲しみ・恐れ / 怒り・嫌悪 / それ以外)
+	reminiscenceWarm    string
+	reminiscenceSad     string
+	reminiscenceAnnoyed string
+	reminiscenceDefault string
+
+	confusions   []string // 理性が低い時に付け加える混乱の表現
+	repeatPrefix string   // 直前と同じ応答を繰り返す時の前置き
+}
+
+// initializePhrasebooks は対応する言語ごとの定型句を初期化
+func initializePhrasebooks() map[models.Language]*phrasebook {
+	return map[models.Language]*phrasebook{
+		models.LanguageJapanese: {
+			templates:            initializeTemplates(),
+			greetingTired:        "...こんにちは",
+			greetingJoy:          "こんにちは、元気だね！",
+			greetingAnger:        "...何？",
+			greetingGrief:        "...こんにちは...",
+			greetingDefault:      "こんにちは",
+			questionConfused:     "よくわからない...",
+			questionJoyAbout:     "%sのこと？知ってるよ！",
+			questionJoyOpen:      "何だろう？教えて！",
+			questionAnger:        "今はそんな気分じゃない",
+			questionFear:         "わからない...怖い...",
+			questionDefaultAbout: "%sについて？うーん...",
+			questionDefaultOpen:  "何だろう...",
+			statementJoy:         "%sって%s",
+			reminiscenceWarm:     "そういえば「%s」のこと、覚えてるよ！",
+			reminiscenceSad:      "...「%s」のことを思い出しちゃった",
+			reminiscenceAnnoyed:  "前にも「%s」って言ってたよね",
+			reminiscenceDefault:  "そういえば、前に「%s」って話してたね",
+			confusions:           []string{"...", "あれ？", "どうだっけ...", "頭が回らない...", "何か変だな..."},
+			repeatPrefix:         "さっきも言ったけど、",
+		},
+		models.LanguageEnglish: {
+			templates:            initializeEnglishTemplates(),
+			greetingTired:        "...hi",
+			greetingJoy:          "Hi! You seem cheerful!",
+			greetingAnger:        "...What?",
+			greetingGrief:        "...hi...",
+			greetingDefault:      "Hello",
+			questionConfused:     "I don't really know...",
+			questionJoyAbout:     "%s? I know about that!",
+			questionJoyOpen:      "What is it? Tell me!",
+			questionAnger:        "I'm not in the mood right now",
+			questionFear:         "I don't know... it's scary...",
+			questionDefaultAbout: "About %s? Hmm...",
+			questionDefaultOpen:  "I wonder...",
+			statementJoy:         "%s? %s",
+			reminiscenceWarm:     "Oh, I remember \"%s\"!",
+			reminiscenceSad:      "...that reminds me of \"%s\"",
+			reminiscenceAnnoyed:  "You said \"%s\" before, too",
+			reminiscenceDefault:  "By the way, we talked about \"%s\" before",
+			confusions:           []string{"...", "huh?", "what was it again...", "my head is foggy...", "something feels off..."},
+			repeatPrefix:         "Like I said, ",
+		},
+	}
+}
+
 // initializeTemplates は応答テンプレートを初期化
 // 【データ構造】感情キー(joy, anger等)に対する応答文字列のスライス
 func initializeTemplates() map[string][]string {
@@ -54,3 +143,57 @@ func initializeTemplates() map[string][]string {
 		},
 	}
 }
+
+// initializeEnglishTemplates は英語の応答テンプレートを初期化
+func initializeEnglishTemplates() map[string][]string {
+	return map[string][]string{
+		"joy": {
+			"Yay!",
+			"That's awesome!",
+			"This is fun~",
+			"Thank you!",
+			"Woohoo!",
+			"I feel great!",
+		},
+		"anger": {
+			"That's annoying...",
+			"Whatever",
+			"Be quiet",
+			"Leave me alone",
+			"Stop messing around",
+		},
+		"fear": {
+			"That's scary...",
+			"I'm worried...",
+			"Will it be okay...",
+			"What should I do...",
+		},
+		"love": {
+			"I love you",
+			"Thank you",
+			"I want to stay with you",
+			"I'll treasure it",
+			"That makes me happy",
+		},
+		"disgust": {
+			"No way...",
+			"Gross",
+			"Stop it",
+			"I don't want to see that",
+		},
+		"grief": {
+			"I'm sad...",
+			"I'm lonely...",
+			"This is hard...",
+			"I want to cry...",
+			"My heart hurts...",
+		},
+		"neutral": {
+			"I see",
+			"Hmm",
+			"Got it",
+			"Oh",
+			"And then?",
+		},
+	}
+}
@@ -3,45 +3,91 @@ package cortex
 import (
 	"strings"
 
-	"github.com/ikawaha/kagome-dict/ipa"
-	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/umekku/mind-os/internal/language"
+	"github.com/umekku/mind-os/internal/models"
 )
 
 // WernickeArea はウェルニッケ野 - 言語理解を担当
 // 『脳科学的意味』側頭葉に位置し、受容性言語理解に関与する領域
 // 「分節化された形態素解析により入力テキストを分解し、意味のある単語（概念）と発話意図を抽出」
 type WernickeArea struct {
-	tokenizer *tokenizer.Tokenizer
+	analyzers language.Analyzers
 }
 
 // NewWernickeArea は新しいウェルニッケ野インスタンスを作成
-// 「分節化されたKagomeトークナイザー（IPA辞書）を初期化」
+// 「分節化された言語ごとの形態素解析器（日本語は Kagome IPA辞書）を初期化」
 func NewWernickeArea() (*WernickeArea, error) {
-	t, err := tokenizer.New(ipa.Dict(), tokenizer.OmitBosEos())
+	analyzers, err := language.NewAnalyzers()
 	if err != nil {
 		return nil, err
 	}
 
 	return &WernickeArea{
-		tokenizer: t,
+		analyzers: analyzers,
 	}, nil
 }
 
+// utterance は1つの発話から読み取った手がかり
+type utterance struct {
+	concepts    []string
+	hasQuestion bool
+	hasGreeting bool
+}
+
 // Comprehend はテキストを理解し、概念と意図を抽出
 // 【アルゴリズム】
-// 1. 形態素解析を実行
+// 1. テキストの言語を判定し、その言語の形態素解析を実行
 // 2. 名詞を抽出し「概念(concepts)」とする
 // 3. 疑問符や特定キーワードから「意図(intent)」を分類（挨拶、質問、陳述）
 func (w *WernickeArea) Comprehend(text string) (concepts []string, intent string) {
+	return w.ComprehendInContext(text, language.Detect(text), Discourse{})
+}
+
+// ComprehendInContext は会話の文脈を踏まえて、指定した言語のテキストとして理解する
+// 【アルゴリズム】Comprehend に加えて
+// 1. 代名詞（「それ」「あれ」、it / that など）は直前の話題の概念に置き換える
+// 2. 概念を含まない質問（「どうして？」など）は直前の話題についての質問とみなす
+func (w *WernickeArea) ComprehendInContext(text string, lang models.Language, d Discourse) (concepts []string, intent string) {
+	topic, hasTopic := d.topic()
+
 	// 形態素解析
-	tokens := w.tokenizer.Tokenize(text)
+	analyzer := w.analyzers.For(lang)
+	tokens := analyzer.Tokenize(text)
+
+	var u utterance
+	switch analyzer.Language() {
+	case models.LanguageEnglish:
+		u = comprehendEnglish(tokens, topic, hasTopic)
+	default:
+		u = comprehendJapanese(text, tokens, topic, hasTopic)
+	}
+	concepts = u.concepts
+
+	// 省略された話題の補完
+	if len(concepts) == 0 && u.hasQuestion && hasTopic {
+		concepts = append(concepts, topic)
+	}
+
+	// 意図の判定
+	if u.hasQuestion {
+		intent = "question"
+	} else if u.hasGreeting {
+		intent = "greeting"
+	} else if len(concepts) > 0 {
+		intent = "statement"
+	} else {
+		intent = "unknown"
+	}
 
-	concepts = make([]string, 0)
-	hasQuestion := false
-	hasGreeting := false
+	return concepts, intent
+}
+
+// comprehendJapanese は日本語の形態素から概念・疑問・挨拶を読み取る
+func comprehendJapanese(text string, tokens []language.Token, topic string, hasTopic bool) utterance {
+	u := utterance{concepts: make([]string, 0)}
 
 	for _, token := range tokens {
-		features := token.Features()
+		features := token.Features
 		if len(features) < 2 {
 			continue
 		}
@@ -51,32 +97,76 @@ func (w *WernickeArea) Comprehend(text string) (concepts []string, intent string
 
 		// 名詞を概念として抽出
 		if pos == "名詞" && len(surface) > 1 {
-			concepts = append(concepts, surface)
+			// 指示語は文脈上の話題を指す
+			if features[1] == "代名詞" && hasTopic {
+				surface = topic
+			}
+			u.concepts = append(u.concepts, surface)
 		}
 
 		// 疑問文の検知
 		if surface == "？" || surface == "?" || strings.Contains(text, "何") ||
 			strings.Contains(text, "どう") || strings.Contains(text, "いつ") {
-			hasQuestion = true
+			u.hasQuestion = true
 		}
 
 		// 挨拶の検知
 		if strings.Contains(surface, "こんにちは") || strings.Contains(surface, "おはよう") ||
 			strings.Contains(surface, "こんばんは") || strings.Contains(surface, "ありがとう") {
-			hasGreeting = true
+			u.hasGreeting = true
 		}
 	}
 
-	// 意図の判定
-	if hasQuestion {
-		intent = "question"
-	} else if hasGreeting {
-		intent = "greeting"
-	} else if len(concepts) > 0 {
-		intent = "statement"
-	} else {
-		intent = "unknown"
+	return u
+}
+
+// englishReferents は文脈上の話題を指す英語の代名詞
+var englishReferents = map[string]bool{"it": true, "this": true, "that": true, "they": true, "them": true}
+
+// englishQuestionWords は文頭にあれば質問とみなす英語の疑問詞
+var englishQuestionWords = map[string]bool{
+	"what": true, "who": true, "why": true, "how": true, "where": true, "when": true, "which": true,
+}
+
+// englishGreetings は挨拶とみなす英語の語 (見出し語。good morning は morning)
+var englishGreetings = map[string]bool{
+	"hello": true, "hi": true, "hey": true, "thanks": true, "thx": true, "thank": true,
+	"morning": true, "evening": true,
+}
+
+// comprehendEnglish は英語の語から概念・疑問・挨拶を読み取る
+// 規則ベースの解析では名詞を判定できないため、機能語・頻出の動詞・形容詞以外の3文字以上の語を概念とする
+func comprehendEnglish(tokens []language.Token, topic string, hasTopic bool) utterance {
+	u := utterance{concepts: make([]string, 0)}
+
+	for i, token := range tokens {
+		if len(token.Features) == 0 {
+			continue
+		}
+		pos := token.Features[0]
+
+		switch {
+		case pos == language.POSOther && len(token.BaseForm) > 2:
+			u.concepts = append(u.concepts, token.BaseForm)
+		case pos == language.POSPronoun && englishReferents[token.BaseForm] && hasTopic:
+			// 指示語は文脈上の話題を指す
+			u.concepts = append(u.concepts, topic)
+		}
+
+		// 疑問文の検知 (疑問符、文頭の疑問詞、Do you / Is it のような倒置)
+		if token.Surface == "?" || token.Surface == "？" {
+			u.hasQuestion = true
+		}
+		if i == 0 && (englishQuestionWords[token.BaseForm] || pos == language.POSAuxiliary &&
+			len(tokens) > 1 && len(tokens[1].Features) > 0 && tokens[1].Features[0] == language.POSPronoun) {
+			u.hasQuestion = true
+		}
+
+		// 挨拶の検知
+		if englishGreetings[token.BaseForm] {
+			u.hasGreeting = true
+		}
 	}
 
-	return concepts, intent
+	return u
 }
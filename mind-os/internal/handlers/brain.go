@@ -3,18 +3,26 @@ package handlers
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/gin-gonic/gin"
-	"github.com/umekku/mind-os/internal/config"
+	"github.com/gorilla/websocket"
 	"github.com/umekku/mind-os/internal/core"
 	"github.com/umekku/mind-os/internal/models"
+	"github.com/umekku/mind-os/internal/ratelimit"
 )
 
 // SensoryRequest は感覚入力リクエストの構造体
 type SensoryRequest struct {
 	Type        string `json:"type" validate:"omitempty,oneof=chat physical"` // "chat" or "physical"
 	Text        string `json:"text" binding:"required" validate:"required,max=500"`
-	SignalValue int    `json:"signalValue" validate:"min=-100,max=100"` // -100 to 100
+	SignalValue int    `json:"signalValue" validate:"min=-100,max=100"`   // -100 to 100
+	Language    string `json:"language" validate:"omitempty,oneof=ja en"` // "ja" or "en" (省略時はテキストから判定)
+}
+
+// ExplainQuery は処理の根拠を返すかを指定するクエリパラメータ
+type ExplainQuery struct {
+	Explain bool `form:"explain" json:"explain"` // true なら感情の処理の根拠 (explanation) を返す
 }
 
 // FeedbackRequest はフィードバックリクエストの構造体
@@ -42,14 +50,30 @@ type MindStateResponse struct {
 }
 
 // BrainHandler は脳統合APIハンドラー
+// リクエストごとに脳IDを解決し、Registry から対応する Brain を取得して処理する
 type BrainHandler struct {
-	brain *core.Brain
+	registry *core.Registry
+
+	// 会話 (WebSocket) のメッセージに適用するレート制限 (nil の場合は制限しない)
+	conversationLimiter ratelimit.Limiter
+
+	// 会話 (WebSocket) のハンドシェイク
+	upgrader *websocket.Upgrader
+
+	// SSE ストリームの終了通知 (CloseStreams で閉じる)
+	streamsClosed chan struct{}
+	closeStreams  sync.Once
 }
 
 // NewBrainHandler は新しい BrainHandler を作成
-func NewBrainHandler(cfg *config.Config) *BrainHandler {
+// conversationLimiter は会話のメッセージごとに消費する予算 (HTTP の入力と同じ予算を渡す)。nil の場合は制限しない
+// checkOrigin は会話のハンドシェイクでオリジンを検証する (CORS と同じ許可リストを渡す)。nil の場合は同一オリジンのみ許可する
+func NewBrainHandler(registry *core.Registry, conversationLimiter ratelimit.Limiter, checkOrigin func(r *http.Request) bool) *BrainHandler {
 	return &BrainHandler{
-		brain: core.New(cfg),
+		registry:            registry,
+		conversationLimiter: conversationLimiter,
+		upgrader:            newConversationUpgrader(checkOrigin),
+		streamsClosed:       make(chan struct{}),
 	}
 }
 
@@ -62,18 +86,30 @@ func NewBrainHandler(cfg *config.Config) *BrainHandler {
 // @Tags         brain
 // @Accept       json
 // @Produce      json
-// @Param        input  body      handlers.FeedbackRequest  true  "Feedback"
+// @Param        X-Brain-ID  header    string                    false  "Brain ID (default: default)"
+// @Param        input       body      handlers.FeedbackRequest  true   "Feedback"
 // @Success      200    {object}  models.SuccessResponse
 // @Failure      400    {object}  models.ProblemDetails
+// @Failure      404    {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
 // @Router       /api/v1/feedback [post]
 func (h *BrainHandler) Feedback(c *gin.Context) {
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
 	var req FeedbackRequest
 	if err := BindStrict(c, &req); err != nil {
 		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", err.Error())
 		return
 	}
 
-	motivation := h.brain.UpdateMotivation(req.Positive)
+	motivation, err := brain.UpdateMotivation(req.Positive)
+	if err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
 
 	SuccessResponse(c, gin.H{
 		"message":    "Feedback processed",
@@ -81,43 +117,3 @@ func (h *BrainHandler) Feedback(c *gin.Context) {
 		"motivation": motivation,
 	})
 }
-
-// GetRecentMemories は直近の記憶を取得
-// GET /api/v1/memories
-// [神経科学] 短期記憶(STM)として海馬に保持されているエピソード記憶のバッファへのアクセスを提供します。
-// これらはまだ長期記憶(LTM)への固定化(Consolidation)が完了していない、不安定で揮発性の高い記憶群です。
-// @Summary      Get Recent Memories (STM)
-// @Description  海馬に存在する短期記憶(STM)を取得します。長期固定化前のエピソード記憶です。
-// @Tags         brain
-// @Produce      json
-// @Success      200    {object}  models.SuccessResponse
-// @Router       /api/v1/memories [get]
-func (h *BrainHandler) GetRecentMemories(c *gin.Context) {
-	memories := h.brain.GetRecentMemories()
-
-	responses := make([]MemoryResponse, len(memories))
-	for i, memory := range memories {
-		emotionResponses := make([]models.EmotionValue, len(memory.Emotions))
-		for j, emotion := range memory.Emotions {
-			emotionResponses[j] = models.EmotionValue{
-				Code:  models.EmotionCode(emotion.Code),
-				Value: emotion.Value,
-			}
-		}
-
-		responses[i] = MemoryResponse{
-			UUID:      memory.UUID,
-			Text:      memory.Text,
-			Emotions:  emotionResponses,
-			Weight:    memory.Weight,
-			Type:      string(memory.Type),
-			CreatedAt: memory.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			Tags:      memory.Tags,
-		}
-	}
-
-	SuccessResponse(c, gin.H{
-		"memories": responses,
-		"count":    len(responses),
-	})
-}
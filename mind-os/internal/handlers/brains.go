@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/core"
+	"github.com/umekku/mind-os/internal/store"
+)
+
+// BrainIDHeader は操作対象の脳IDを指定するリクエストヘッダー
+// パスパラメータ (:brainId) が指定されている場合はそちらを優先する
+const BrainIDHeader = "X-Brain-ID"
+
+// CreateBrainRequest は脳作成リクエストの構造体
+type CreateBrainRequest struct {
+	ID      string `json:"id" validate:"required,max=64"`
+	Profile string `json:"profile,omitempty" validate:"max=64"` // パーソナリティプロファイル名 (省略時は既定のプロファイル)
+}
+
+// BrainInfoResponse は脳情報レスポンスの構造体
+type BrainInfoResponse struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	Profile   string `json:"profile,omitempty"` // パーソナリティプロファイル名
+	Active    bool   `json:"active"`            // メモリ上にロードされているか
+}
+
+// resolveBrain はリクエストから脳IDを解決し、対応する Brain を返す
+// 解決に失敗した場合はエラーレスポンスを書き込み、false を返す
+func (h *BrainHandler) resolveBrain(c *gin.Context) (*core.Brain, bool) {
+	return resolveBrain(c, h.registry)
+}
+
+// resolveBrain は パスパラメータ > X-Brain-ID ヘッダー > 既定の脳 の順で脳IDを解決する
+func resolveBrain(c *gin.Context, registry *core.Registry) (*core.Brain, bool) {
+	id := c.Param("brainId")
+	if id == "" {
+		id = c.GetHeader(BrainIDHeader)
+	}
+	if id == "" {
+		id = store.DefaultBrainID
+	}
+
+	brain, err := registry.Get(id)
+	if err != nil {
+		registryErrorResponse(c, err)
+		return nil, false
+	}
+	return brain, true
+}
+
+// registryErrorResponse は Registry のエラーを適切なHTTPステータスに変換して返す
+func registryErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, core.ErrInvalidBrainID):
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Brain ID", err.Error())
+	case errors.Is(err, core.ErrBrainNotFound):
+		ErrorResponse(c, http.StatusNotFound, "Brain Not Found", err.Error())
+	case errors.Is(err, core.ErrBrainExists):
+		ErrorResponse(c, http.StatusConflict, "Brain Already Exists", err.Error())
+	case errors.Is(err, core.ErrProfileNotFound):
+		ErrorResponse(c, http.StatusBadRequest, "Profile Not Found", err.Error())
+	case errors.Is(err, core.ErrBrainClosed):
+		// 処理中に脳が退避・削除された (再試行すると Registry から取得し直す)
+		ErrorResponse(c, http.StatusServiceUnavailable, "Brain Unavailable", "brain was unloaded; retry the request")
+	default:
+		ErrorResponse(c, http.StatusInternalServerError, "Brain Registry Error", err.Error())
+	}
+}
+
+// ListBrains は登録済みの脳を一覧表示
+// GET /api/v1/brains
+// [神経科学] 個体ごとに独立した神経系（ホルモン・意欲・理性・記憶）を持つ脳の一覧を返します。
+// @Summary      List Brains
+// @Description  登録済みの脳（キャラクター/ユーザーごとの独立した心）を一覧表示します。
+// @Tags         brains
+// @Produce      json
+// @Success      200  {object}  models.SuccessResponse
+// @Failure      500  {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/brains [get]
+func (h *BrainHandler) ListBrains(c *gin.Context) {
+	infos, err := h.registry.List()
+	if err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
+
+	brains := make([]BrainInfoResponse, len(infos))
+	for i, info := range infos {
+		brains[i] = toBrainInfoResponse(info)
+	}
+
+	SuccessResponse(c, gin.H{
+		"brains": brains,
+		"count":  len(brains),
+	})
+}
+
+// CreateBrain は新しい脳を作成
+// POST /api/v1/brains
+// @Summary      Create Brain
+// @Description  独立したホルモン・意欲・理性・記憶を持つ新しい脳を作成します。profile を指定するとそのパーソナリティ (気質) で作成します。
+// @Tags         brains
+// @Accept       json
+// @Produce      json
+// @Param        input  body      handlers.CreateBrainRequest  true  "Brain ID and profile"
+// @Success      201    {object}  handlers.BrainInfoResponse
+// @Failure      400    {object}  models.ProblemDetails
+// @Failure      409    {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/brains [post]
+func (h *BrainHandler) CreateBrain(c *gin.Context) {
+	var req CreateBrainRequest
+	if err := BindStrict(c, &req); err != nil {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", err.Error())
+		return
+	}
+
+	brain, err := h.registry.Create(req.ID, req.Profile)
+	if err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, BrainInfoResponse{
+		ID:        brain.ID,
+		CreatedAt: time.Now().Format(time.RFC3339),
+		Profile:   brain.Profile,
+		Active:    true,
+	})
+}
+
+// EvictBrain は脳をメモリから退避
+// POST /api/v1/brains/{brainId}/evictions
+// [神経科学] 退避前に睡眠処理を行い、短期記憶(STM)を長期記憶(LTM)へ固定化します。
+// @Summary      Evict Brain
+// @Description  脳をメモリから退避します。短期記憶は睡眠処理により固定化され、長期記憶は保持されます。
+// @Tags         brains
+// @Produce      json
+// @Param        brainId  path      string  true  "Brain ID"
+// @Success      200      {object}  models.SuccessResponse
+// @Failure      404      {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/brains/{brainId}/evictions [post]
+func (h *BrainHandler) EvictBrain(c *gin.Context) {
+	id := c.Param("brainId")
+	if err := h.registry.Evict(id); err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, gin.H{
+		"message": "Brain evicted",
+		"brainId": id,
+	})
+}
+
+// DeleteBrain は脳とその全ての記憶を削除
+// DELETE /api/v1/brains/{brainId}
+// @Summary      Delete Brain
+// @Description  脳とその全ての記憶を削除します。この操作は取り消せません。
+// @Tags         brains
+// @Param        brainId  path  string  true  "Brain ID"
+// @Success      204
+// @Failure      404  {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/brains/{brainId} [delete]
+func (h *BrainHandler) DeleteBrain(c *gin.Context) {
+	if err := h.registry.Delete(c.Param("brainId")); err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// toBrainInfoResponse は core.BrainInfo をレスポンス形式に変換
+func toBrainInfoResponse(info core.BrainInfo) BrainInfoResponse {
+	resp := BrainInfoResponse{
+		ID:      info.ID,
+		Profile: info.Profile,
+		Active:  info.Active,
+	}
+	if !info.CreatedAt.IsZero() {
+		resp.CreatedAt = info.CreatedAt.Format(time.RFC3339)
+	}
+	return resp
+}
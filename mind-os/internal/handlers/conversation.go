@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/umekku/mind-os/internal/core"
+	"github.com/umekku/mind-os/internal/models"
+	"github.com/umekku/mind-os/internal/ratelimit"
+)
+
+const (
+	// conversationMaxMessageBytes はクライアントから受け付けるメッセージの最大サイズ
+	conversationMaxMessageBytes = 4096
+
+	// conversationPongWait は応答 (pong またはメッセージ) がない場合に切断するまでの時間
+	conversationPongWait = 60 * time.Second
+
+	// conversationPingInterval は ping の送信間隔 (conversationPongWait より短くする)
+	conversationPingInterval = conversationPongWait * 9 / 10
+
+	// conversationWriteTimeout は1回の書き込みの期限
+	conversationWriteTimeout = 10 * time.Second
+)
+
+// 会話メッセージの種類 (サーバー → クライアント)
+const (
+	ConversationSession = "session" // 接続直後: セッションIDと現在の状態
+	ConversationReply   = "reply"   // 発話・刺激への応答
+	ConversationThought = "thought" // 自発的な発話 (マインドワンダリングで浮かんだ考え)
+	ConversationMood    = "mood"    // 気分の変化 (意欲・理性のレベルが変わった)
+	ConversationError   = "error"   // 不正なメッセージ
+)
+
+// newConversationUpgrader は HTTP 接続を WebSocket に切り替える Upgrader を作成
+// checkOrigin が nil の場合は同一オリジンからの接続のみ許可する
+func newConversationUpgrader(checkOrigin func(r *http.Request) bool) *websocket.Upgrader {
+	return &websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     checkOrigin,
+	}
+}
+
+// ConversationMessage はクライアントから送信されるメッセージ
+type ConversationMessage struct {
+	Type        string `json:"type" validate:"required,oneof=chat physical"`
+	Text        string `json:"text" validate:"required_if=Type chat,max=500"`
+	SignalValue int    `json:"signalValue" validate:"min=-100,max=100"`   // physical の場合の刺激の強さ (-100 to 100)
+	Language    string `json:"language" validate:"omitempty,oneof=ja en"` // chat の場合のテキストの言語 (省略時はテキストから判定)
+}
+
+// ConversationEvent はサーバーから送信されるメッセージ
+type ConversationEvent struct {
+	Type      string                    `json:"type"` // session, reply, thought, mood, error
+	SessionID string                    `json:"sessionId"`
+	BrainID   string                    `json:"brainId"`
+	Time      string                    `json:"time"`
+	Turn      int                       `json:"turn,omitempty"`      // reply: 会話開始からの発話数
+	MindState *models.MindStateResponse `json:"mindState,omitempty"` // reply
+	ReplyText string                    `json:"replyText,omitempty"` // reply
+	Thoughts  []string                  `json:"thoughts,omitempty"`  // thought
+	State     *BrainStateResponse       `json:"state,omitempty"`     // session, thought, mood
+	Error     *models.ProblemDetails    `json:"error,omitempty"`     // error
+}
+
+// Converse は WebSocket による会話セッションを開始
+// GET /api/v1/conversations/ws
+// [神経科学] 1回ごとの独立した入力ではなく、会話の流れ (ワーキングメモリ) を保持したまま対話します。
+// 直前の話題は指示語や省略の理解 (ウェルニッケ野) に、直前の応答は同じ言い回しの回避 (ブローカ野) に、
+// 同じ話の繰り返しは慣れ (視床) に反映されます。
+// 会話中に脳がマインドワンダリングを始めたり気分が変わると、サーバーから自発的にメッセージが届きます。
+// @Summary      Open Conversation Session
+// @Description  WebSocket で会話セッションを開きます。`{"type":"chat","text":"..."}` または `{"type":"physical","signalValue":50}` を送ると reply が返り、thought / mood は自発的に送られます。
+// @Tags         brain
+// @Param        X-Brain-ID  header  string  false  "Brain ID (default: default)"
+// @Success      101  {object}  handlers.ConversationEvent
+// @Failure      400  {string}  string  "Not a WebSocket handshake"
+// @Failure      404  {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/conversations/ws [get]
+func (h *BrainHandler) Converse(c *gin.Context) {
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
+	// 失敗した場合は Upgrade がエラーレスポンスを書き込む
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		slog.Warn("Failed to upgrade conversation", "brain_id", brain.ID, "error", err)
+		return
+	}
+	defer conn.Close()
+
+	session := &conversationSession{
+		conn:    conn,
+		brain:   brain,
+		conv:    core.NewConversation(),
+		limiter: h.conversationLimiter,
+		client:  c.GetString(ratelimit.ClientContextKey),
+	}
+
+	slog.Info("Conversation opened", "brain_id", brain.ID, "session_id", session.conv.ID)
+	session.run(h.streamsClosed)
+	slog.Info("Conversation closed", "brain_id", brain.ID, "session_id", session.conv.ID, "turns", session.conv.TurnCount())
+}
+
+// conversationSession は1つの WebSocket 接続上の会話
+type conversationSession struct {
+	conn  *websocket.Conn
+	brain *core.Brain
+	conv  *core.Conversation
+
+	// メッセージごとのレート制限 (HTTP の入力と同じ予算を消費する)
+	limiter ratelimit.Limiter
+	client  string
+
+	// 最後に伝えた気分 (変化した時だけ mood を送る)
+	motivationLevel string
+	sanityLevel     string
+}
+
+// run は会話のメインループ
+// 【処理内容】
+// 受信は専用のゴルーチンで行い、書き込み (応答・自発的な発話・ping) はこのループからのみ行う
+func (s *conversationSession) run(closed <-chan struct{}) {
+	// 購読を先に開始し、接続直後の状態より後の変化を取りこぼさないようにする
+	sub := s.brain.Events.Subscribe()
+	defer func() { sub.Close() }()
+
+	incoming := make(chan []byte)
+	done := make(chan struct{})
+	defer close(done)
+	go s.read(incoming, done)
+
+	state := s.brain.GetState()
+	s.rememberMood(state)
+	if !s.send(s.event(ConversationSession, func(e *ConversationEvent) {
+		resp := toBrainStateResponse(state)
+		e.State = &resp
+	})) {
+		return
+	}
+
+	ping := time.NewTicker(conversationPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-closed:
+			s.close(websocket.CloseGoingAway, "server shutting down")
+			return
+		case <-ping.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(conversationWriteTimeout)); err != nil {
+				return
+			}
+		case data, open := <-incoming:
+			if !open {
+				return
+			}
+			if !s.handleMessage(data) {
+				return
+			}
+		case event, open := <-sub.Events():
+			if !open {
+				if !sub.Dropped() {
+					// 脳がアンロードされた
+					s.close(websocket.CloseGoingAway, "brain unloaded")
+					return
+				}
+				// 自発的な発話の取りこぼしは会話を続けられるため、購読し直す
+				slog.Warn("Conversation fell behind brain events, resubscribing", "brain_id", s.brain.ID, "session_id", s.conv.ID)
+				sub = s.brain.Events.Subscribe()
+				continue
+			}
+			if !s.handleBrainEvent(event) {
+				return
+			}
+		}
+	}
+}
+
+// read はクライアントからのメッセージを受信して incoming に渡す
+// 接続が切れると incoming を閉じる
+func (s *conversationSession) read(incoming chan<- []byte, done <-chan struct{}) {
+	defer close(incoming)
+
+	s.conn.SetReadLimit(conversationMaxMessageBytes)
+	_ = s.conn.SetReadDeadline(time.Now().Add(conversationPongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(conversationPongWait))
+	})
+
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				slog.Debug("Conversation read error", "session_id", s.conv.ID, "error", err)
+			}
+			return
+		}
+		_ = s.conn.SetReadDeadline(time.Now().Add(conversationPongWait))
+
+		select {
+		case incoming <- data:
+		case <-done:
+			return
+		}
+	}
+}
+
+// handleMessage はクライアントの発話・刺激を処理して応答を送る
+// 不正なメッセージにはエラーを返して会話を続ける
+func (s *conversationSession) handleMessage(data []byte) bool {
+	var msg ConversationMessage
+	if err := DecodeStrict(bytes.NewReader(data), &msg); err != nil {
+		slog.Warn("Invalid conversation message", "session_id", s.conv.ID, "error", err)
+		return s.send(s.event(ConversationError, func(e *ConversationEvent) {
+			e.Error = &models.ProblemDetails{
+				Type:   "about:blank",
+				Title:  "Invalid Message",
+				Status: http.StatusBadRequest,
+				Detail: err.Error(),
+			}
+		}))
+	}
+
+	if s.limiter != nil {
+		if result := s.limiter.Allow(s.client); !result.Allowed {
+			retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+			return s.send(s.event(ConversationError, func(e *ConversationEvent) {
+				e.Error = &models.ProblemDetails{
+					Type:   "about:blank",
+					Title:  "Too Many Requests",
+					Status: http.StatusTooManyRequests,
+					Detail: fmt.Sprintf("Rate limit exceeded (%d in a burst). Retry after %d seconds", result.Limit, retryAfter),
+				}
+			}))
+		}
+	}
+
+	mindState, err := s.brain.Converse(s.conv, models.SensoryInput{
+		Type:        models.SignalType(msg.Type),
+		InputText:   msg.Text,
+		SignalValue: msg.SignalValue,
+		Language:    models.Language(msg.Language),
+	})
+	if err != nil {
+		// 会話中に脳が退避・削除された
+		return s.send(s.event(ConversationError, func(e *ConversationEvent) {
+			e.Error = &models.ProblemDetails{
+				Type:   "about:blank",
+				Title:  "Brain Unavailable",
+				Status: http.StatusServiceUnavailable,
+				Detail: "brain was unloaded; reconnect to continue the conversation",
+			}
+		}))
+	}
+	// 応答に含まれる気分の変化は mood として重ねて送らない
+	s.rememberMood(s.brain.GetState())
+
+	return s.send(s.event(ConversationReply, func(e *ConversationEvent) {
+		e.Turn = s.conv.TurnCount()
+		e.MindState = toMindStateResponse(mindState)
+		e.ReplyText = mindState.ReplyText
+	}))
+}
+
+// handleBrainEvent は脳の状態変化のうち、会話の相手に伝えるものを送る
+// マインドワンダリングで浮かんだ考えは thought、意欲・理性のレベルの変化は mood として送る
+func (s *conversationSession) handleBrainEvent(event core.BrainEvent) bool {
+	resp := toBrainStateResponse(event.State)
+
+	if event.Type == core.EventDaydream && len(event.Thoughts) > 0 {
+		s.rememberMood(event.State)
+		return s.send(s.event(ConversationThought, func(e *ConversationEvent) {
+			e.Thoughts = event.Thoughts
+			e.State = &resp
+		}))
+	}
+
+	if event.State.MotivationLevel == s.motivationLevel && event.State.SanityLevel == s.sanityLevel {
+		return true
+	}
+	s.rememberMood(event.State)
+	return s.send(s.event(ConversationMood, func(e *ConversationEvent) {
+		e.State = &resp
+	}))
+}
+
+// rememberMood は相手に伝えた気分を記録する
+func (s *conversationSession) rememberMood(state core.BrainState) {
+	s.motivationLevel = state.MotivationLevel
+	s.sanityLevel = state.SanityLevel
+}
+
+// event は共通フィールドを埋めたメッセージを作成する
+func (s *conversationSession) event(eventType string, fill func(*ConversationEvent)) ConversationEvent {
+	e := ConversationEvent{
+		Type:      eventType,
+		SessionID: s.conv.ID,
+		BrainID:   s.brain.ID,
+		Time:      time.Now().Format(time.RFC3339Nano),
+	}
+	fill(&e)
+	return e
+}
+
+// send はメッセージを書き込み、接続を続けられるかを返す
+func (s *conversationSession) send(e ConversationEvent) bool {
+	_ = s.conn.SetWriteDeadline(time.Now().Add(conversationWriteTimeout))
+	if err := s.conn.WriteJSON(e); err != nil {
+		if !errors.Is(err, websocket.ErrCloseSent) {
+			slog.Debug("Conversation write error", "session_id", s.conv.ID, "error", err)
+		}
+		return false
+	}
+	return true
+}
+
+// close はクローズフレームを送って会話を終える
+func (s *conversationSession) close(code int, reason string) {
+	msg := websocket.FormatCloseMessage(code, reason)
+	_ = s.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(conversationWriteTimeout))
+}
@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/umekku/mind-os/internal/core"
+)
+
+// newConversationServer は会話のエンドポイントを持つテストサーバーを起動する
+func newConversationServer(t *testing.T, h *BrainHandler) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(newBrainRouter(h))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// dialConversation は会話のセッションを開く (headers は名前と値の組)
+func dialConversation(t *testing.T, srv *httptest.Server, path string, headers ...string) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+	header := http.Header{}
+	for i := 0; i+1 < len(headers); i += 2 {
+		header.Set(headers[i], headers[i+1])
+	}
+	conn, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+path, header)
+	if conn != nil {
+		t.Cleanup(func() { conn.Close() })
+	}
+	return conn, resp, err
+}
+
+// openConversation は会話のセッションを開き、最初の session メッセージを読み取る
+func openConversation(t *testing.T, srv *httptest.Server, path string) (*websocket.Conn, ConversationEvent) {
+	t.Helper()
+	conn, _, err := dialConversation(t, srv, path)
+	if err != nil {
+		t.Fatalf("Dial(%s) error = %v", path, err)
+	}
+	session := readEvent(t, conn)
+	if session.Type != ConversationSession || session.SessionID == "" || session.State == nil {
+		t.Fatalf("first event = %+v, want session with state", session)
+	}
+	return conn, session
+}
+
+// readEvent は次のメッセージを読み取る (気分の変化は読み飛ばす)
+func readEvent(t *testing.T, conn *websocket.Conn) ConversationEvent {
+	t.Helper()
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		var e ConversationEvent
+		if err := conn.ReadJSON(&e); err != nil {
+			t.Fatalf("ReadJSON() error = %v", err)
+		}
+		if e.Type != ConversationMood {
+			return e
+		}
+	}
+}
+
+// readClose はクローズフレームを受け取るまで読み取り、その内容を返す
+func readClose(t *testing.T, conn *websocket.Conn) *websocket.CloseError {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			var closeErr *websocket.CloseError
+			if !errors.As(err, &closeErr) {
+				t.Fatalf("ReadMessage() error = %v, want close frame", err)
+			}
+			return closeErr
+		}
+	}
+}
+
+// say はメッセージを送って次のメッセージを読み取る
+func say(t *testing.T, conn *websocket.Conn, msg string) ConversationEvent {
+	t.Helper()
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+		t.Fatalf("WriteMessage(%s) error = %v", msg, err)
+	}
+	return readEvent(t, conn)
+}
+
+func TestConversation_Turns(t *testing.T) {
+	registry := newTestRegistry(t)
+	newTestBrain(t, registry)
+	srv := newConversationServer(t, NewBrainHandler(registry, nil, nil))
+
+	messages := []string{
+		`{"type":"chat","text":"こんにちは"}`,
+		`{"type":"physical","signalValue":30}`,
+		`{"type":"chat","text":"猫が好きです","language":"ja"}`,
+	}
+
+	// 発話数はセッションごとに数える (新しいセッションは1から始まる)
+	var sessions []string
+	for range 2 {
+		conn, session := openConversation(t, srv, "/api/v1/brains/alice/conversations/ws")
+		if session.BrainID != "alice" {
+			t.Errorf("session brainId = %q, want alice", session.BrainID)
+		}
+		sessions = append(sessions, session.SessionID)
+
+		for i, msg := range messages {
+			reply := say(t, conn, msg)
+			if reply.Type != ConversationReply || reply.MindState == nil {
+				t.Fatalf("reply to %s = %+v, want reply with mindState", msg, reply)
+			}
+			if reply.Turn != i+1 || reply.SessionID != session.SessionID {
+				t.Errorf("reply to %s: turn %d in session %s, want %d in %s", msg, reply.Turn, reply.SessionID, i+1, session.SessionID)
+			}
+		}
+	}
+	if sessions[0] == sessions[1] {
+		t.Errorf("session IDs = %v, want distinct", sessions)
+	}
+
+	// 既定の脳は X-Brain-ID がなくても会話できる
+	conn, session := openConversation(t, srv, "/api/v1/conversations/ws")
+	if session.BrainID != "default" {
+		t.Errorf("session brainId = %q, want default", session.BrainID)
+	}
+	if reply := say(t, conn, messages[0]); reply.Type != ConversationReply || reply.Turn != 1 {
+		t.Errorf("reply = %+v, want turn 1", reply)
+	}
+}
+
+func TestConversation_InvalidMessage(t *testing.T) {
+	registry := newTestRegistry(t)
+	newTestBrain(t, registry)
+	srv := newConversationServer(t, NewBrainHandler(registry, nil, nil))
+	conn, _ := openConversation(t, srv, "/api/v1/brains/alice/conversations/ws")
+
+	tests := []struct {
+		name string
+		msg  string
+	}{
+		{"JSON でない", `hello`},
+		{"種類なし", `{"text":"こんにちは"}`},
+		{"未知の種類", `{"type":"video","text":"こんにちは"}`},
+		{"テキストのない発話", `{"type":"chat"}`},
+		{"長すぎるテキスト", `{"type":"chat","text":"` + strings.Repeat("あ", 501) + `"}`},
+		{"刺激の上限", `{"type":"physical","signalValue":101}`},
+		{"未知の言語", `{"type":"chat","text":"hello","language":"fr"}`},
+		{"未知のフィールド", `{"type":"chat","text":"こんにちは","mood":"happy"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := say(t, conn, tt.msg)
+			if e.Type != ConversationError || e.Error == nil {
+				t.Fatalf("event = %+v, want error", e)
+			}
+			if e.Error.Status != http.StatusBadRequest || e.Error.Title != "Invalid Message" {
+				t.Errorf("error = %d %q, want 400 Invalid Message", e.Error.Status, e.Error.Title)
+			}
+		})
+	}
+
+	// 不正なメッセージは発話に数えず、会話を続けられる
+	if reply := say(t, conn, `{"type":"chat","text":"こんにちは"}`); reply.Type != ConversationReply || reply.Turn != 1 {
+		t.Errorf("reply after invalid messages = %+v, want turn 1", reply)
+	}
+}
+
+func TestConversation_BrainClosed(t *testing.T) {
+	registry := newTestRegistry(t)
+	brain := newTestBrain(t, registry)
+	if err := registry.Evict("alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 退避前に取得した脳で会話を続けると、入力は ErrBrainClosed で拒否される
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := newConversationUpgrader(nil).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		s := &conversationSession{conn: conn, brain: brain, conv: core.NewConversation()}
+		s.handleMessage([]byte(`{"type":"chat","text":"こんにちは"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	conn, _, err := dialConversation(t, srv, "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := readEvent(t, conn)
+	if e.Type != ConversationError || e.Error == nil || e.Error.Status != http.StatusServiceUnavailable || e.Error.Title != "Brain Unavailable" {
+		t.Errorf("event = %+v (error %+v), want 503 Brain Unavailable", e, e.Error)
+	}
+}
+
+func TestConversation_Close(t *testing.T) {
+	tests := []struct {
+		name       string
+		close      func(registry *core.Registry, h *BrainHandler) error
+		wantReason string
+	}{
+		{"脳の退避", func(r *core.Registry, _ *BrainHandler) error { return r.Evict("alice") }, "brain unloaded"},
+		{"脳の削除", func(r *core.Registry, _ *BrainHandler) error { return r.Delete("alice") }, "brain unloaded"},
+		{"サーバーの停止", func(_ *core.Registry, h *BrainHandler) error { h.CloseStreams(); return nil }, "server shutting down"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := newTestRegistry(t)
+			newTestBrain(t, registry)
+			h := NewBrainHandler(registry, nil, nil)
+			srv := newConversationServer(t, h)
+			conn, _ := openConversation(t, srv, "/api/v1/brains/alice/conversations/ws")
+			if reply := say(t, conn, `{"type":"chat","text":"こんにちは"}`); reply.Type != ConversationReply {
+				t.Fatalf("reply = %+v", reply)
+			}
+
+			if err := tt.close(registry, h); err != nil {
+				t.Fatal(err)
+			}
+			closeErr := readClose(t, conn)
+			if closeErr.Code != websocket.CloseGoingAway || closeErr.Text != tt.wantReason {
+				t.Errorf("close = %d %q, want %d %q", closeErr.Code, closeErr.Text, websocket.CloseGoingAway, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestConversation_Handshake(t *testing.T) {
+	registry := newTestRegistry(t)
+	newTestBrain(t, registry)
+	allowed := func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		return origin == "" || origin == "https://app.example.com"
+	}
+	srv := newConversationServer(t, NewBrainHandler(registry, nil, allowed))
+
+	tests := []struct {
+		name       string
+		path       string
+		headers    []string
+		wantStatus int // 0 の場合はハンドシェイクに成功する
+	}{
+		{"Origin なし", "/api/v1/brains/alice/conversations/ws", nil, 0},
+		{"許可したオリジン", "/api/v1/brains/alice/conversations/ws", []string{"Origin", "https://app.example.com"}, 0},
+		{"許可していないオリジン", "/api/v1/brains/alice/conversations/ws", []string{"Origin", "https://evil.example.net"}, http.StatusForbidden},
+		{"存在しない脳", "/api/v1/brains/bob/conversations/ws", nil, http.StatusNotFound},
+		{"不正な脳ID", "/api/v1/conversations/ws", []string{BrainIDHeader, "../alice"}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, resp, err := dialConversation(t, srv, tt.path, tt.headers...)
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("Dial() error = %v", err)
+				}
+				if e := readEvent(t, conn); e.Type != ConversationSession {
+					t.Errorf("first event = %+v, want session", e)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Dial() succeeded, want handshake error")
+			}
+			if resp == nil || resp.StatusCode != tt.wantStatus {
+				t.Errorf("handshake response = %v, want status %d", resp, tt.wantStatus)
+			}
+		})
+	}
+
+	// オリジンの検証を指定しない場合は同一オリジンのみ許可する
+	sameOrigin := newConversationServer(t, NewBrainHandler(registry, nil, nil))
+	if _, resp, err := dialConversation(t, sameOrigin, "/api/v1/brains/alice/conversations/ws", "Origin", "https://app.example.com"); err == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("cross-origin handshake without checker = %v, want 403", err)
+	}
+	if _, _, err := dialConversation(t, sameOrigin, "/api/v1/brains/alice/conversations/ws", "Origin", sameOrigin.URL); err != nil {
+		t.Errorf("same-origin handshake error = %v", err)
+	}
+}
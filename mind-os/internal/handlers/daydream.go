@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/core"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// DaydreamRequest は白昼夢リクエストの構造体
+type DaydreamRequest struct {
+	DurationMinutes int    `json:"durationMinutes" validate:"required,min=1,max=1440"`            // 非アクティブ時間 (1時間ごとに1件、最大5件を回想)
+	Seed            *int64 `json:"seed"`                                                          // 記憶選択の乱数シード (再現用)
+	MoodBias        string `json:"moodBias" validate:"omitempty,oneof=positive negative neutral"` // 気分傾向の上書き
+}
+
+// HormoneDeltaResponse はホルモン変化量のレスポンス
+type HormoneDeltaResponse struct {
+	Cortisol float64 `json:"cortisol"`
+	Oxytocin float64 `json:"oxytocin"`
+}
+
+// ReconsolidationResponse は想起による再固定化の内容
+type ReconsolidationResponse struct {
+	EmotionsBefore []models.EmotionValue `json:"emotionsBefore"`
+	EmotionsAfter  []models.EmotionValue `json:"emotionsAfter"`
+	WeightBefore   float64               `json:"weightBefore"`
+	WeightAfter    float64               `json:"weightAfter"`
+	RecallCount    int                   `json:"recallCount"`
+}
+
+// RecollectionResponse は想起された記憶1件のレスポンス
+type RecollectionResponse struct {
+	Memory          MemoryResponse          `json:"memory"`
+	Summary         string                  `json:"summary"`
+	Reconsolidation ReconsolidationResponse `json:"reconsolidation"`
+	HormoneDelta    HormoneDeltaResponse    `json:"hormoneDelta"` // この記憶の反芻によるホルモン変化
+}
+
+// DaydreamResponse は白昼夢レスポンスの構造体
+type DaydreamResponse struct {
+	DaydreamLog   string                   `json:"daydreamLog"`
+	MoodTendency  string                   `json:"moodTendency"` // 記憶選択に使用した気分傾向
+	Seed          int64                    `json:"seed"`         // 同じ状態・シードで同じ記憶が選ばれる
+	Recollections []RecollectionResponse   `json:"recollections"`
+	HormoneDelta  HormoneDeltaResponse     `json:"hormoneDelta"` // 反芻全体によるホルモン変化
+	State         models.MindStateResponse `json:"state"`
+}
+
+// Daydream は白昼夢処理を実行
+// POST /api/v1/daydreams
+// [神経科学] デフォルトモードネットワーク(DMN)の活性化により、過去の記憶や未来のシミュレーションをランダムに想起します。
+// 想起された記憶は現在の感情で再固定化され、その感情価の反芻がホルモンバランスに影響します。
+// @Summary      Daydream
+// @Description  DMNを活性化し、白昼夢（Daydreaming）処理を行います。想起した記憶、再固定化の内容、反芻によるホルモン変化を返します。
+// @Tags         brain
+// @Accept       json
+// @Produce      json
+// @Param        X-Brain-ID  header    string                    false  "Brain ID (default: default)"
+// @Param        input       body      handlers.DaydreamRequest  true   "Daydream Parameters"
+// @Success      200    {object}  handlers.DaydreamResponse
+// @Failure      400    {object}  models.ProblemDetails
+// @Failure      404    {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/daydreams [post]
+func (h *BrainHandler) Daydream(c *gin.Context) {
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
+	var req DaydreamRequest
+	if err := BindStrict(c, &req); err != nil {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", err.Error())
+		return
+	}
+
+	result, err := brain.Daydream(core.DaydreamOptions{
+		Duration: time.Duration(req.DurationMinutes) * time.Minute,
+		Seed:     req.Seed,
+		MoodBias: req.MoodBias,
+	})
+	if err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
+
+	recollections := make([]RecollectionResponse, len(result.Recollections))
+	for i, r := range result.Recollections {
+		recollections[i] = RecollectionResponse{
+			Memory:  toMemoryResponse(r.Memory),
+			Summary: r.Summary,
+			Reconsolidation: ReconsolidationResponse{
+				EmotionsBefore: r.EmotionsBefore,
+				EmotionsAfter:  r.Memory.Emotions,
+				WeightBefore:   r.WeightBefore,
+				WeightAfter:    r.Memory.Weight,
+				RecallCount:    r.Memory.RecallCount,
+			},
+			HormoneDelta: toHormoneDeltaResponse(r.HormoneDelta),
+		}
+	}
+
+	SuccessResponse(c, DaydreamResponse{
+		DaydreamLog:   result.Log,
+		MoodTendency:  result.MoodTendency,
+		Seed:          result.Seed,
+		Recollections: recollections,
+		HormoneDelta:  toHormoneDeltaResponse(result.HormoneDelta),
+		State:         result.State,
+	})
+}
+
+// toHormoneDeltaResponse はホルモン変化量をレスポンス形式に変換
+func toHormoneDeltaResponse(d core.HormoneDelta) HormoneDeltaResponse {
+	return HormoneDeltaResponse{
+		Cortisol: d.Cortisol,
+		Oxytocin: d.Oxytocin,
+	}
+}
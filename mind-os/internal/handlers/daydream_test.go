@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/umekku/mind-os/internal/core"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// seedDaydreamMemories は悲しい記憶2件と嬉しい記憶2件を保存する (UUID は脳IDで始まる)
+// 重みが 0 のため、気分傾向による重み付けがなければ選ばれやすさに差はない
+func seedDaydreamMemories(t *testing.T, brain *core.Brain) {
+	t.Helper()
+	grief := []models.EmotionValue{{Code: models.EmotionGrief, Value: 80}}
+	joy := []models.EmotionValue{{Code: models.EmotionJoy, Value: 80}}
+	seedMemories(t, brain,
+		models.RuneMemory{UUID: brain.ID + "-lost", Text: "財布をなくした", Emotions: grief, Tags: []string{"G"}},
+		models.RuneMemory{UUID: brain.ID + "-party", Text: "誕生日を祝ってもらった", Emotions: joy, Tags: []string{"J"}},
+		models.RuneMemory{UUID: brain.ID + "-rain", Text: "雨で旅行が中止になった", Emotions: grief, Tags: []string{"G"}},
+		models.RuneMemory{UUID: brain.ID + "-cat", Text: "猫と遊んだ", Emotions: joy, Tags: []string{"J"}},
+	)
+}
+
+// recollectionTexts は想起された記憶の本文を順に返す
+func recollectionTexts(resp DaydreamResponse) []string {
+	texts := make([]string, len(resp.Recollections))
+	for i, r := range resp.Recollections {
+		texts[i] = r.Memory.Text
+	}
+	return texts
+}
+
+func TestDaydream_MoodBias(t *testing.T) {
+	registry := newTestRegistry(t)
+	r := newBrainRouter(NewBrainHandler(registry, nil, nil))
+
+	tests := []struct {
+		brainID      string
+		moodBias     string
+		wantEmotion  models.EmotionCode // 想起される記憶の感情
+		wantCortisol bool               // 反芻でストレスが増えるか
+		wantOxytocin bool               // 反芻で愛着が増えるか
+	}{
+		{"negative", "negative", models.EmotionGrief, true, false},
+		{"positive", "positive", models.EmotionJoy, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.moodBias, func(t *testing.T) {
+			brain, err := registry.Create(tt.brainID, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			seedDaydreamMemories(t, brain)
+
+			// 2時間分 = 2件の回想は、気分に一致する記憶から選ばれる
+			for _, seed := range []int{1, 2, 3} {
+				body := fmt.Sprintf(`{"durationMinutes":120,"moodBias":%q,"seed":%d}`, tt.moodBias, seed)
+				w := serve(r, http.MethodPost, "/api/v1/brains/"+tt.brainID+"/daydreams", body)
+				resp := decodeJSON[DaydreamResponse](t, w, http.StatusOK)
+
+				if resp.MoodTendency != tt.moodBias {
+					t.Errorf("moodTendency = %q, want %q", resp.MoodTendency, tt.moodBias)
+				}
+				if len(resp.Recollections) != 2 {
+					t.Fatalf("seed %d: recollections = %v, want 2", seed, recollectionTexts(resp))
+				}
+				for _, rec := range resp.Recollections {
+					if len(rec.Reconsolidation.EmotionsBefore) == 0 || rec.Reconsolidation.EmotionsBefore[0].Code != tt.wantEmotion {
+						t.Errorf("seed %d: recalled %q (%v), want emotion %s", seed, rec.Memory.Text, rec.Reconsolidation.EmotionsBefore, tt.wantEmotion)
+					}
+				}
+				if got := resp.HormoneDelta.Cortisol > 0; got != tt.wantCortisol {
+					t.Errorf("seed %d: cortisol delta = %v, want increase %v", seed, resp.HormoneDelta.Cortisol, tt.wantCortisol)
+				}
+				if got := resp.HormoneDelta.Oxytocin > 0; got != tt.wantOxytocin {
+					t.Errorf("seed %d: oxytocin delta = %v, want increase %v", seed, resp.HormoneDelta.Oxytocin, tt.wantOxytocin)
+				}
+			}
+		})
+	}
+}
+
+func TestDaydream_Seed(t *testing.T) {
+	registry := newTestRegistry(t)
+	r := newBrainRouter(NewBrainHandler(registry, nil, nil))
+
+	// 同じ記憶を持つ脳を用意する (重みを揃えて抽選の結果をシードだけで決める)
+	for _, id := range []string{"alice", "bob", "carol"} {
+		brain, err := registry.Create(id, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		seedDaydreamMemories(t, brain)
+	}
+	daydream := func(brainID, body string) DaydreamResponse {
+		t.Helper()
+		w := serve(r, http.MethodPost, "/api/v1/daydreams", body, BrainIDHeader, brainID)
+		return decodeJSON[DaydreamResponse](t, w, http.StatusOK)
+	}
+
+	// 同じ状態・同じシードなら同じ記憶を同じ順に想起する
+	alice := daydream("alice", `{"durationMinutes":180,"moodBias":"neutral","seed":42}`)
+	bob := daydream("bob", `{"durationMinutes":180,"moodBias":"neutral","seed":42}`)
+	if alice.Seed != 42 || bob.Seed != 42 {
+		t.Errorf("seed = %d, %d, want 42", alice.Seed, bob.Seed)
+	}
+	if len(alice.Recollections) != 3 || !slices.Equal(recollectionTexts(alice), recollectionTexts(bob)) {
+		t.Errorf("recollections = %v and %v, want the same 3 memories", recollectionTexts(alice), recollectionTexts(bob))
+	}
+	if alice.DaydreamLog != bob.DaydreamLog {
+		t.Errorf("daydreamLog = %q and %q, want equal", alice.DaydreamLog, bob.DaydreamLog)
+	}
+
+	// シードを省略した場合は使用したシードを返し、それを指定すると再現できる
+	carol := daydream("carol", `{"durationMinutes":180,"moodBias":"neutral"}`)
+	if carol.Seed == 0 || carol.Seed == 42 {
+		t.Fatalf("generated seed = %d", carol.Seed)
+	}
+	replay := newTestRegistry(t)
+	replayBrain, err := replay.Create("carol", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seedDaydreamMemories(t, replayBrain)
+	w := serve(newBrainRouter(NewBrainHandler(replay, nil, nil)), http.MethodPost, "/api/v1/brains/carol/daydreams",
+		fmt.Sprintf(`{"durationMinutes":180,"moodBias":"neutral","seed":%d}`, carol.Seed))
+	if got := decodeJSON[DaydreamResponse](t, w, http.StatusOK); !slices.Equal(recollectionTexts(got), recollectionTexts(carol)) {
+		t.Errorf("replayed recollections = %v, want %v", recollectionTexts(got), recollectionTexts(carol))
+	}
+
+	// 想起された記憶は再固定化され、想起回数が増える
+	for _, rec := range alice.Recollections {
+		stored := decodeJSON[MemoryResponse](t, serve(r, http.MethodGet, "/api/v1/brains/alice/memories/"+rec.Memory.UUID, ""), http.StatusOK)
+		if stored.RecallCount != 1 || rec.Reconsolidation.RecallCount != 1 || rec.Reconsolidation.WeightAfter != stored.Weight {
+			t.Errorf("memory %s: stored recallCount %d, weight %v; response %+v", rec.Memory.UUID, stored.RecallCount, stored.Weight, rec.Reconsolidation)
+		}
+	}
+}
+
+func TestDaydream_InvalidRequest(t *testing.T) {
+	registry := newTestRegistry(t)
+	newTestBrain(t, registry)
+	r := newBrainRouter(NewBrainHandler(registry, nil, nil))
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"ボディなし", ""},
+		{"時間なし", `{}`},
+		{"時間の下限", `{"durationMinutes":0}`},
+		{"時間の上限", `{"durationMinutes":1441}`},
+		{"未知の気分傾向", `{"durationMinutes":60,"moodBias":"happy"}`},
+		{"数値でないシード", `{"durationMinutes":60,"seed":"abc"}`},
+		{"未知のフィールド", `{"durationMinutes":60,"mood":"positive"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodPost, "/api/v1/daydreams", tt.body, brainHeader...)
+			if problem := decodeProblem(t, w, http.StatusBadRequest); problem.Title != "Invalid Request Body" {
+				t.Errorf("Title = %q, want Invalid Request Body", problem.Title)
+			}
+		})
+	}
+}
@@ -5,18 +5,25 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/umekku/mind-os/internal/amygdala"
+	"github.com/umekku/mind-os/internal/language"
 	"github.com/umekku/mind-os/internal/models"
 )
 
 // EmotionRequest は感情分析リクエストの構造体
 type EmotionRequest struct {
-	Text string `json:"text" binding:"required" validate:"required,max=1000"`
+	Text     string `json:"text" binding:"required" validate:"required,max=1000"`
+	Language string `json:"language" validate:"omitempty,oneof=ja en"` // "ja" or "en" (省略時はテキストから判定)
 }
 
 // EmotionResponse は感情分析レスポンスの構造体
 type EmotionResponse struct {
 	Text     string                `json:"text"`
 	Emotions []models.EmotionValue `json:"emotions"`
+	Affect   models.Affect         `json:"affect"`            // 入力全体の VAD (Valence / Arousal / Dominance)
+	Signals  []models.AffectSignal `json:"signals,omitempty"` // 語彙以外の感情の手がかり (絵文字・顔文字・記号の繰り返しなど)
+	Language models.Language       `json:"language"`          // 解析に使った言語
+
+	Explanation *models.AssessmentTrace `json:"explanation,omitempty"` // 評価の根拠 (?explain=true の場合のみ)
 }
 
 // EmotionHandler は感情分析ハンドラー
@@ -25,8 +32,9 @@ type EmotionHandler struct {
 }
 
 // NewEmotionHandler は新しい EmotionHandler を作成
-func NewEmotionHandler() *EmotionHandler {
-	am, err := amygdala.New()
+// lexicon は脳と共有する感情辞書 (nil の場合は組み込みの辞書)
+func NewEmotionHandler(lexicon *amygdala.Lexicon) *EmotionHandler {
+	am, err := amygdala.New(amygdala.WithLexicon(lexicon))
 	if err != nil {
 		// 初期化失敗時はpanicせず、エラーを考慮すべきだが
 		// ここでは簡易的にpanic、実際はログ出力してnilで返す等の処理が必要
@@ -41,28 +49,51 @@ func NewEmotionHandler() *EmotionHandler {
 // POST /api/v1/emotions/assess
 // [神経科学] 扁桃体(Amygdala)の機能に基づき、入力されたテキストパターンから
 // 6つの基本感情（喜び・怒り・恐れ・悲しみ・嫌悪・驚き）および社会的感情（信頼・希望）を抽出します。
+// あわせて、感情価・覚醒度・支配感 (VAD) の連続値で入力全体の感情を返します。
 // [制御機構] 高次の前頭前野(PFC)によるトップダウン制御（抑制）はここでは適用されず、
 // 純粋なボトムアップの情動反応（一次反応）を返します。
 // @Summary      Assess Emotions (Amygdala)
-// @Description  テキスト入力に対して扁桃体モジュールが生成する即時的な感情反応を分析します。PFCによる抑制前の「生の感情」です。
+// @Description  テキスト入力に対して扁桃体モジュールが生成する即時的な感情反応を分析します。PFCによる抑制前の「生の感情」です。感情コードごとの強度 (emotions) と VAD (affect, 各軸 -1.0〜1.0) を返します。テキストは日本語 (ja) と英語 (en) に対応し、language を省略した場合は文字種から判定します。explain=true を指定すると、トークン・辞書の一致 (表層形・基本形)・修飾・感情ごとの寄与を explanation に返します。
 // @Tags         brain
 // @Accept       json
 // @Produce      json
-// @Param        input  body      handlers.EmotionRequest  true  "Text Input"
+// @Param        input    body      handlers.EmotionRequest  true   "Text Input"
+// @Param        explain  query     bool                     false  "Return the assessment trace (tokens, lexicon matches, modifiers, contributions)"
 // @Success      200    {object}  handlers.EmotionResponse
 // @Failure      400    {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
 // @Router       /api/v1/emotions/assess [post]
 func (h *EmotionHandler) Assess(c *gin.Context) {
+	var query ExplainQuery
+	if err := BindQueryStrict(c, &query); err != nil {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Query Parameters", err.Error())
+		return
+	}
+
 	var req EmotionRequest
 	if err := BindStrict(c, &req); err != nil {
 		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", err.Error())
 		return
 	}
 
-	emotions := h.amygdala.Assess(req.Text)
+	lang := models.Language(req.Language)
+	if lang == "" {
+		lang = language.Detect(req.Text)
+	}
+	var assessment amygdala.Assessment
+	if query.Explain {
+		assessment = h.amygdala.ExplainIn(req.Text, lang)
+	} else {
+		assessment = h.amygdala.AssessIn(req.Text, lang)
+	}
 
 	c.JSON(http.StatusOK, EmotionResponse{
 		Text:     req.Text,
-		Emotions: emotions,
+		Emotions: assessment.Emotions,
+		Affect:   assessment.Affect,
+		Signals:  assessment.Signals,
+		Language: assessment.Language,
+
+		Explanation: assessment.Trace,
 	})
 }
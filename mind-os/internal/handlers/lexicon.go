@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/amygdala"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// LexiconHandler は扁桃体の感情辞書の管理ハンドラー
+type LexiconHandler struct {
+	lexicon *amygdala.Lexicon
+}
+
+// NewLexiconHandler は新しい LexiconHandler を作成
+func NewLexiconHandler(lexicon *amygdala.Lexicon) *LexiconHandler {
+	return &LexiconHandler{lexicon: lexicon}
+}
+
+// LexiconLayerRequest は操作対象の辞書レイヤーを指定するクエリパラメータ
+type LexiconLayerRequest struct {
+	Layer     string `form:"layer" json:"layer" validate:"required,oneof=base domain character"`
+	Character string `form:"character" json:"character" validate:"required_if=Layer character,max=64"`
+}
+
+// LexiconEntryRequest は辞書に語を追加するリクエストの構造体
+type LexiconEntryRequest struct {
+	Layer     string         `json:"layer" validate:"required,oneof=base domain character"`
+	Character string         `json:"character,omitempty" validate:"required_if=Layer character,max=64"` // character レイヤーの場合のプロファイル名
+	Word      string         `json:"word" validate:"required,max=50"`
+	BaseForm  string         `json:"baseForm,omitempty" validate:"max=50"`
+	Emotion   string         `json:"emotion" validate:"required,oneof=J S A F L D H G N"`
+	Intensity int            `json:"intensity" validate:"min=1,max=100"`
+	POS       string         `json:"pos,omitempty" validate:"max=50"`                     // 品詞の制約 ("名詞", "形容詞,自立" など)
+	Affect    *models.Affect `json:"affect,omitempty"`                                    // VAD 座標 (各軸 -1.0〜1.0。省略時は感情コードから算出)
+	Language  string         `json:"language,omitempty" validate:"omitempty,oneof=ja en"` // 語の言語 (省略時は語の文字種から判定)
+}
+
+// LexiconEntryResponse は辞書の語のレスポンスの構造体
+type LexiconEntryResponse struct {
+	Word      string         `json:"word"`
+	BaseForm  string         `json:"baseForm,omitempty"`
+	Emotion   string         `json:"emotion"`
+	Intensity int            `json:"intensity"`
+	POS       string         `json:"pos,omitempty"`
+	Affect    *models.Affect `json:"affect,omitempty"`
+	Language  string         `json:"language"`
+}
+
+// LexiconLayerResponse は辞書レイヤーの状態のレスポンスの構造体
+type LexiconLayerResponse struct {
+	Layer     string `json:"layer"`
+	Character string `json:"character,omitempty"`
+	Source    string `json:"source,omitempty"` // 読み込んだファイル (組み込み・実行時のみの場合は空)
+	Words     int    `json:"words"`
+	Overrides int    `json:"overrides"` // 実行時に追加・削除された語数
+}
+
+// LexiconStatsResponse は辞書の状態のレスポンスの構造体
+type LexiconStatsResponse struct {
+	LoadedAt string                 `json:"loadedAt"`
+	Layers   []LexiconLayerResponse `json:"layers"`
+}
+
+// GetLexicon は感情辞書の状態を取得
+// GET /api/v1/lexicon
+// [神経科学] 扁桃体が反射的な情動反応を起こす刺激 (語) の一覧です。基本語彙・分野別の語彙・キャラクター固有の語彙を重ねて使います。
+// @Summary      Get Emotion Lexicon Status
+// @Description  感情辞書のレイヤー (base, domain, character) ごとの語数と読み込み元を取得します。
+// @Tags         lexicon
+// @Produce      json
+// @Success      200  {object}  handlers.LexiconStatsResponse
+// @Security     ApiKeyAuth
+// @Router       /api/v1/lexicon [get]
+func (h *LexiconHandler) GetLexicon(c *gin.Context) {
+	c.JSON(http.StatusOK, toLexiconStatsResponse(h.lexicon.Stats()))
+}
+
+// ListEntries は辞書レイヤーの語を一覧表示
+// GET /api/v1/lexicon/entries
+// @Summary      List Lexicon Entries
+// @Description  指定したレイヤーの語を表層形の順で取得します。実行時の追加・削除を反映した内容です。
+// @Tags         lexicon
+// @Produce      json
+// @Param        layer      query     string  true   "Layer (base, domain, character)"
+// @Param        character  query     string  false  "Character (profile name, required for the character layer)"
+// @Success      200        {object}  models.SuccessResponse
+// @Failure      400        {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/lexicon/entries [get]
+func (h *LexiconHandler) ListEntries(c *gin.Context) {
+	var req LexiconLayerRequest
+	if err := BindQueryStrict(c, &req); err != nil {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Query Parameters", err.Error())
+		return
+	}
+
+	entries, err := h.lexicon.Entries(amygdala.Layer(req.Layer), req.Character)
+	if err != nil {
+		lexiconErrorResponse(c, err)
+		return
+	}
+
+	resp := make([]LexiconEntryResponse, len(entries))
+	for i, e := range entries {
+		resp[i] = toLexiconEntryResponse(e)
+	}
+	SuccessResponse(c, gin.H{
+		"layer":     req.Layer,
+		"character": req.Character,
+		"entries":   resp,
+		"count":     len(resp),
+	})
+}
+
+// AddEntry は辞書に語を追加
+// POST /api/v1/lexicon/entries
+// [神経科学] 新しい刺激と情動の結び付きを学習させます (恐怖条件づけのように、特定の語に反応するようになります)。
+// @Summary      Add Lexicon Entry
+// @Description  辞書レイヤーに語を追加します。同じ語・感情・品詞の語がある場合は置き換えます。全ての脳に即座に反映され、再読み込み後も維持されます (ファイルには保存されません)。
+// @Tags         lexicon
+// @Accept       json
+// @Produce      json
+// @Param        input  body      handlers.LexiconEntryRequest  true  "Entry"
+// @Success      201    {object}  handlers.LexiconEntryResponse
+// @Failure      400    {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/lexicon/entries [post]
+func (h *LexiconHandler) AddEntry(c *gin.Context) {
+	var req LexiconEntryRequest
+	if err := BindStrict(c, &req); err != nil {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", err.Error())
+		return
+	}
+
+	entry := amygdala.LexiconEntry{
+		Word:      req.Word,
+		BaseForm:  req.BaseForm,
+		Emotion:   models.EmotionCode(req.Emotion),
+		Intensity: req.Intensity,
+		POS:       req.POS,
+		Affect:    req.Affect,
+		Language:  models.Language(req.Language),
+	}
+	if err := h.lexicon.Add(amygdala.Layer(req.Layer), req.Character, entry); err != nil {
+		lexiconErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toLexiconEntryResponse(entry))
+}
+
+// DeleteEntry は辞書レイヤーから語を削除
+// DELETE /api/v1/lexicon/entries/{word}
+// [神経科学] 刺激と情動の結び付きを消去します (消去学習)。下位のレイヤーにある同じ語にも反応しなくなります。
+// @Summary      Delete Lexicon Entry
+// @Description  辞書レイヤーから語 (全ての感情) を削除します。全ての脳に即座に反映され、再読み込み後も維持されます (ファイルには保存されません)。
+// @Tags         lexicon
+// @Param        word       path  string  true   "Word (surface form)"
+// @Param        layer      query string  true   "Layer (base, domain, character)"
+// @Param        character  query string  false  "Character (profile name, required for the character layer)"
+// @Success      204
+// @Failure      400  {object}  models.ProblemDetails
+// @Failure      404  {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/lexicon/entries/{word} [delete]
+func (h *LexiconHandler) DeleteEntry(c *gin.Context) {
+	var req LexiconLayerRequest
+	if err := BindQueryStrict(c, &req); err != nil {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Query Parameters", err.Error())
+		return
+	}
+
+	if err := h.lexicon.Remove(amygdala.Layer(req.Layer), req.Character, c.Param("word")); err != nil {
+		lexiconErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Reload は辞書ファイルを再読み込み
+// POST /api/v1/lexicon/reloads
+// @Summary      Reload Lexicon
+// @Description  辞書ファイルを再読み込みし、全ての脳の辞書を差し替えます。処理中の評価は差し替え前の辞書を使い続けます。読み込みに失敗した場合は現在の辞書を維持します。
+// @Tags         lexicon
+// @Produce      json
+// @Success      200  {object}  handlers.LexiconStatsResponse
+// @Failure      422  {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/lexicon/reloads [post]
+func (h *LexiconHandler) Reload(c *gin.Context) {
+	stats, err := h.lexicon.Reload()
+	if err != nil {
+		ErrorResponse(c, http.StatusUnprocessableEntity, "Lexicon Reload Failed", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, toLexiconStatsResponse(stats))
+}
+
+// lexiconErrorResponse は辞書操作のエラーをレスポンスに変換
+func lexiconErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, amygdala.ErrWordNotFound):
+		ErrorResponse(c, http.StatusNotFound, "Word Not Found", err.Error())
+	default:
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Lexicon Operation", err.Error())
+	}
+}
+
+// toLexiconEntryResponse は amygdala.LexiconEntry をレスポンス形式に変換
+func toLexiconEntryResponse(e amygdala.LexiconEntry) LexiconEntryResponse {
+	return LexiconEntryResponse{
+		Word:      e.Word,
+		BaseForm:  e.BaseForm,
+		Emotion:   string(e.Emotion),
+		Intensity: e.Intensity,
+		POS:       e.POS,
+		Affect:    e.Affect,
+		Language:  string(e.ResolvedLanguage()),
+	}
+}
+
+// toLexiconStatsResponse は amygdala.LexiconStats をレスポンス形式に変換
+func toLexiconStatsResponse(s amygdala.LexiconStats) LexiconStatsResponse {
+	layers := make([]LexiconLayerResponse, len(s.Layers))
+	for i, l := range s.Layers {
+		layers[i] = LexiconLayerResponse{
+			Layer:     string(l.Layer),
+			Character: l.Character,
+			Source:    l.Source,
+			Words:     l.Words,
+			Overrides: l.Overrides,
+		}
+	}
+	return LexiconStatsResponse{
+		LoadedAt: s.LoadedAt.Format(time.RFC3339),
+		Layers:   layers,
+	}
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/amygdala"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// newLexiconRouter は辞書の管理エンドポイントを登録したルーター
+func newLexiconRouter(lexicon *amygdala.Lexicon) *gin.Engine {
+	h := NewLexiconHandler(lexicon)
+	r := gin.New()
+	v1 := r.Group("/api/v1")
+	v1.GET("/lexicon", h.GetLexicon)
+	v1.GET("/lexicon/entries", h.ListEntries)
+	v1.POST("/lexicon/entries", h.AddEntry)
+	v1.DELETE("/lexicon/entries/:word", h.DeleteEntry)
+	v1.POST("/lexicon/reloads", h.Reload)
+	return r
+}
+
+// newTestLexicon は分野別の辞書ファイル (domain.tsv) から辞書を読み込み、そのファイルのパスを返す
+func newTestLexicon(t *testing.T, domain string) (*amygdala.Lexicon, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "domain.tsv")
+	writeFile(t, path, domain)
+	lexicon, err := amygdala.LoadLexicon(amygdala.LexiconSources{Domain: []string{path}})
+	if err != nil {
+		t.Fatalf("LoadLexicon() error = %v", err)
+	}
+	return lexicon, path
+}
+
+// writeFile はファイルを作成する (既にあれば置き換える)
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// listWords は辞書レイヤーの語を表層形で返す
+func listWords(t *testing.T, r http.Handler, query string) []string {
+	t.Helper()
+	resp := decodeJSON[struct {
+		Entries []LexiconEntryResponse `json:"entries"`
+		Count   int                    `json:"count"`
+	}](t, serve(r, http.MethodGet, "/api/v1/lexicon/entries?"+query, ""), http.StatusOK)
+
+	words := make([]string, len(resp.Entries))
+	for i, e := range resp.Entries {
+		words[i] = e.Word
+	}
+	if resp.Count != len(words) {
+		t.Errorf("count = %d, want %d", resp.Count, len(words))
+	}
+	return words
+}
+
+// layerStats はレイヤーの状態を返す
+func layerStats(t *testing.T, stats LexiconStatsResponse, layer, character string) LexiconLayerResponse {
+	t.Helper()
+	i := slices.IndexFunc(stats.Layers, func(l LexiconLayerResponse) bool {
+		return l.Layer == layer && l.Character == character
+	})
+	if i < 0 {
+		t.Fatalf("layers = %+v, want %s/%s", stats.Layers, layer, character)
+	}
+	return stats.Layers[i]
+}
+
+func TestLexicon_AddEntry(t *testing.T) {
+	lexicon, _ := newTestLexicon(t, "リリース\t\tJ\t60\n")
+	r := newLexiconRouter(lexicon)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantTitle  string
+		query      string // 追加後に一覧を確認するレイヤー
+		wantWords  []string
+	}{
+		{"分野別の語", `{"layer":"domain","word":"障害","emotion":"F","intensity":70}`, http.StatusCreated, "", "layer=domain", []string{"リリース", "障害"}},
+		{"同じ語・感情は置き換える", `{"layer":"domain","word":"障害","emotion":"F","intensity":30}`, http.StatusCreated, "", "layer=domain", []string{"リリース", "障害"}},
+		{"同じ語の別の感情", `{"layer":"domain","word":"障害","emotion":"A","intensity":40}`, http.StatusCreated, "", "layer=domain", []string{"リリース", "障害", "障害"}},
+		{"キャラクター別の語", `{"layer":"character","character":"calm","word":"障害","emotion":"J","intensity":20,"language":"ja"}`, http.StatusCreated, "", "layer=character&character=calm", []string{"障害"}},
+		{"ボディなし", ``, http.StatusBadRequest, "Invalid Request Body", "", nil},
+		{"未知のレイヤー", `{"layer":"user","word":"障害","emotion":"F","intensity":70}`, http.StatusBadRequest, "Invalid Request Body", "", nil},
+		{"キャラクターの指定なし", `{"layer":"character","word":"障害","emotion":"F","intensity":70}`, http.StatusBadRequest, "Invalid Request Body", "", nil},
+		{"未知の感情コード", `{"layer":"domain","word":"障害","emotion":"X","intensity":70}`, http.StatusBadRequest, "Invalid Request Body", "", nil},
+		{"強度の下限", `{"layer":"domain","word":"障害","emotion":"F","intensity":0}`, http.StatusBadRequest, "Invalid Request Body", "", nil},
+		{"強度の上限", `{"layer":"domain","word":"障害","emotion":"F","intensity":101}`, http.StatusBadRequest, "Invalid Request Body", "", nil},
+		{"範囲外の VAD", `{"layer":"domain","word":"障害","emotion":"F","intensity":70,"affect":{"valence":2}}`, http.StatusBadRequest, "Invalid Lexicon Operation", "", nil},
+		{"未知のフィールド", `{"layer":"domain","word":"障害","emotion":"F","intensity":70,"weight":1}`, http.StatusBadRequest, "Invalid Request Body", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodPost, "/api/v1/lexicon/entries", tt.body)
+			if tt.wantStatus != http.StatusCreated {
+				if problem := decodeProblem(t, w, tt.wantStatus); problem.Title != tt.wantTitle {
+					t.Errorf("Title = %q, want %q (detail %q)", problem.Title, tt.wantTitle, problem.Detail)
+				}
+				return
+			}
+			if entry := decodeJSON[LexiconEntryResponse](t, w, http.StatusCreated); entry.Word != "障害" || entry.Language != "ja" {
+				t.Errorf("entry = %+v, want 障害 (ja)", entry)
+			}
+			if got := listWords(t, r, tt.query); !slices.Equal(got, tt.wantWords) {
+				t.Errorf("entries(%s) = %v, want %v", tt.query, got, tt.wantWords)
+			}
+		})
+	}
+
+	// 追加した語は同じ辞書を使う扁桃体に即座に反映される
+	a, err := amygdala.New(amygdala.WithLexicon(lexicon))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := models.FromEmotionValues(a.Assess("障害").Emotions); got[models.EmotionFear] != 30 || got[models.EmotionAnger] != 40 {
+		t.Errorf("Assess(障害) = %v, want Fear 30, Anger 40", got)
+	}
+}
+
+func TestLexicon_DeleteEntry(t *testing.T) {
+	lexicon, _ := newTestLexicon(t, "リリース\t\tJ\t60\n障害\t\tF\t70\n")
+	r := newLexiconRouter(lexicon)
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+		wantTitle  string
+	}{
+		{"分野別の語", "/api/v1/lexicon/entries/障害?layer=domain", http.StatusNoContent, ""},
+		{"削除済みの語", "/api/v1/lexicon/entries/障害?layer=domain", http.StatusNotFound, "Word Not Found"},
+		{"他のレイヤーにない語", "/api/v1/lexicon/entries/リリース?layer=character&character=calm", http.StatusNotFound, "Word Not Found"},
+		{"レイヤーの指定なし", "/api/v1/lexicon/entries/リリース", http.StatusBadRequest, "Invalid Query Parameters"},
+		{"キャラクターの指定なし", "/api/v1/lexicon/entries/リリース?layer=character", http.StatusBadRequest, "Invalid Query Parameters"},
+		{"未知のレイヤー", "/api/v1/lexicon/entries/リリース?layer=user", http.StatusBadRequest, "Invalid Query Parameters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodDelete, tt.target, "")
+			if tt.wantStatus != http.StatusNoContent {
+				if problem := decodeProblem(t, w, tt.wantStatus); problem.Title != tt.wantTitle {
+					t.Errorf("Title = %q, want %q", problem.Title, tt.wantTitle)
+				}
+				return
+			}
+			if w.Code != http.StatusNoContent || w.Body.Len() != 0 {
+				t.Errorf("status = %d (body %q), want 204 without body", w.Code, w.Body)
+			}
+		})
+	}
+
+	if got := listWords(t, r, "layer=domain"); !slices.Equal(got, []string{"リリース"}) {
+		t.Errorf("entries after delete = %v, want [リリース]", got)
+	}
+	stats := decodeJSON[LexiconStatsResponse](t, serve(r, http.MethodGet, "/api/v1/lexicon", ""), http.StatusOK)
+	if domain := layerStats(t, stats, "domain", ""); domain.Words != 1 || domain.Overrides != 1 {
+		t.Errorf("domain layer = %+v, want 1 word, 1 override", domain)
+	}
+}
+
+func TestLexicon_Reload(t *testing.T) {
+	lexicon, path := newTestLexicon(t, "リリース\t\tJ\t60\n")
+	r := newLexiconRouter(lexicon)
+
+	// 実行時の追加と削除
+	serve(r, http.MethodPost, "/api/v1/lexicon/entries", `{"layer":"domain","word":"障害","emotion":"F","intensity":70}`)
+	if w := serve(r, http.MethodDelete, "/api/v1/lexicon/entries/リリース?layer=domain", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d", w.Code)
+	}
+	before := decodeJSON[LexiconStatsResponse](t, serve(r, http.MethodGet, "/api/v1/lexicon", ""), http.StatusOK)
+
+	// ファイルの変更は再読み込みで反映され、実行時の変更は維持される
+	writeFile(t, path, "リリース\t\tJ\t60\n納品\t\tJ\t50\n")
+	stats := decodeJSON[LexiconStatsResponse](t, serve(r, http.MethodPost, "/api/v1/lexicon/reloads", ""), http.StatusOK)
+	domain := layerStats(t, stats, "domain", "")
+	if domain.Source != path || domain.Words != 2 || domain.Overrides != 2 {
+		t.Errorf("domain layer = %+v, want source %s, 2 words, 2 overrides", domain, path)
+	}
+	if stats.LoadedAt < before.LoadedAt {
+		t.Errorf("loadedAt = %s, want not before %s", stats.LoadedAt, before.LoadedAt)
+	}
+	if got := listWords(t, r, "layer=domain"); !slices.Equal(got, []string{"納品", "障害"}) {
+		t.Errorf("entries after reload = %v, want [納品 障害]", got)
+	}
+
+	// 読み込みに失敗した場合は現在の辞書を使い続ける
+	writeFile(t, path, "壊れた\t\tX\t50\n")
+	if problem := decodeProblem(t, serve(r, http.MethodPost, "/api/v1/lexicon/reloads", ""), http.StatusUnprocessableEntity); problem.Title != "Lexicon Reload Failed" {
+		t.Errorf("Title = %q, want Lexicon Reload Failed", problem.Title)
+	}
+	if got := listWords(t, r, "layer=domain"); !slices.Equal(got, []string{"納品", "障害"}) {
+		t.Errorf("entries after failed reload = %v, want [納品 障害]", got)
+	}
+}
@@ -0,0 +1,416 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/core"
+	"github.com/umekku/mind-os/internal/models"
+	"github.com/umekku/mind-os/internal/store"
+)
+
+// ListMemoriesRequest は記憶一覧のクエリパラメータ
+type ListMemoriesRequest struct {
+	Type    string `form:"type" json:"type" validate:"omitempty,oneof=STM LTM"`
+	Tag     string `form:"tag" json:"tag" validate:"omitempty,max=50"`
+	Emotion string `form:"emotion" json:"emotion" validate:"omitempty,oneof=J S A F L D H G N"`
+	Sort    string `form:"sort,default=lastAccess" json:"sort" validate:"oneof=lastAccess createdAt weight"`
+	Order   string `form:"order,default=desc" json:"order" validate:"oneof=asc desc"`
+	Limit   int    `form:"limit,default=20" json:"limit" validate:"min=1,max=100"`
+	Cursor  string `form:"cursor" json:"cursor" validate:"omitempty,max=512"`
+}
+
+// ListMemoriesResponse は記憶一覧のレスポンス
+type ListMemoriesResponse struct {
+	Memories   []MemoryResponse `json:"memories"`
+	Count      int              `json:"count"`
+	NextCursor string           `json:"nextCursor,omitempty"` // 続きがある場合のみ
+}
+
+// PatchMemoryRequest は記憶の部分更新リクエスト
+type PatchMemoryRequest struct {
+	Tags   *[]string `json:"tags" validate:"omitempty,max=20,dive,min=1,max=50"`
+	Weight *float64  `json:"weight" validate:"omitempty,min=0,max=1"`
+}
+
+// ForgetMemoriesRequest は記憶の一括削除リクエスト
+type ForgetMemoriesRequest struct {
+	UUIDs []string `json:"uuids" validate:"required,min=1,max=100,dive,required,max=64"`
+}
+
+// memoryCursor はページングカーソルの内容 (クライアントには不透明な文字列として渡す)
+type memoryCursor struct {
+	Sort   string    `json:"s"`
+	Order  string    `json:"o"`
+	Time   time.Time `json:"t,omitzero"`
+	Weight float64   `json:"w,omitempty"`
+	UUID   string    `json:"u"`
+}
+
+// ListMemories は記憶の一覧を取得
+// GET /api/v1/memories
+// [神経科学] 海馬が保持する短期記憶(STM)と、固定化済みの長期記憶(LTM)を横断して参照します。
+// @Summary      List Memories
+// @Description  STMとLTMを横断して記憶を一覧します。タイプ・タグ・感情で絞り込み、並び順を指定できます。続きは nextCursor を cursor に指定して取得します。
+// @Tags         brain
+// @Produce      json
+// @Param        X-Brain-ID  header  string  false  "Brain ID (default: default)"
+// @Param        type        query   string  false  "記憶タイプ (STM, LTM)"
+// @Param        tag         query   string  false  "タグ (感情コード・概念)"
+// @Param        emotion     query   string  false  "感情コード (J, S, A, F, L, D, H, G, N)"
+// @Param        sort        query   string  false  "並び順の基準 (lastAccess, createdAt, weight. default: lastAccess)"
+// @Param        order       query   string  false  "昇順/降順 (asc, desc. default: desc)"
+// @Param        limit       query   int     false  "取得件数 (1-100, default: 20)"
+// @Param        cursor      query   string  false  "前のページの nextCursor"
+// @Success      200  {object}  handlers.ListMemoriesResponse
+// @Failure      400  {object}  models.ProblemDetails
+// @Failure      404  {object}  models.ProblemDetails
+// @Failure      500  {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/memories [get]
+func (h *BrainHandler) ListMemories(c *gin.Context) {
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
+	var req ListMemoriesRequest
+	if err := BindQueryStrict(c, &req); err != nil {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Query Parameters", err.Error())
+		return
+	}
+
+	query := store.MemoryListQuery{
+		Type:      models.MemoryType(req.Type),
+		Tag:       req.Tag,
+		Emotion:   models.EmotionCode(req.Emotion),
+		Sort:      store.MemorySort(req.Sort),
+		Ascending: req.Order == "asc",
+		Limit:     req.Limit,
+	}
+	if req.Cursor != "" {
+		after, err := decodeMemoryCursor(req.Cursor, req.Sort, req.Order)
+		if err != nil {
+			ErrorResponse(c, http.StatusBadRequest, "Invalid Cursor", err.Error())
+			return
+		}
+		query.After = after
+	}
+
+	memories, next, err := brain.ListMemories(query)
+	if err != nil {
+		ErrorResponse(c, http.StatusInternalServerError, "Memory Listing Failed", err.Error())
+		return
+	}
+
+	resp := ListMemoriesResponse{
+		Memories: toMemoryResponses(memories),
+		Count:    len(memories),
+	}
+	if next != nil {
+		resp.NextCursor = encodeMemoryCursor(*next, req.Sort, req.Order)
+	}
+	SuccessResponse(c, resp)
+}
+
+// GetMemory はUUIDで記憶を取得
+// GET /api/v1/memories/{uuid}
+// @Summary      Get Memory
+// @Description  UUIDで記憶（STMまたはLTM）を取得します。
+// @Tags         brain
+// @Produce      json
+// @Param        X-Brain-ID  header  string  false  "Brain ID (default: default)"
+// @Param        uuid        path    string  true   "Memory UUID"
+// @Success      200  {object}  handlers.MemoryResponse
+// @Failure      404  {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/memories/{uuid} [get]
+func (h *BrainHandler) GetMemory(c *gin.Context) {
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
+	memory := brain.GetMemory(c.Param("uuid"))
+	if memory == nil {
+		ErrorResponse(c, http.StatusNotFound, "Memory Not Found", fmt.Sprintf("memory %q does not exist", c.Param("uuid")))
+		return
+	}
+
+	SuccessResponse(c, toMemoryResponse(*memory))
+}
+
+// PatchMemory は記憶のタグと重みを更新
+// PATCH /api/v1/memories/{uuid}
+// [神経科学] 記憶の重要度(重み)を外部から調整します。重みは忘却曲線と長期記憶への固定化に影響します。
+// @Summary      Update Memory
+// @Description  記憶のタグと重みを更新します。指定しなかったフィールドは変更されません。
+// @Tags         brain
+// @Accept       json
+// @Produce      json
+// @Param        X-Brain-ID  header  string                       false  "Brain ID (default: default)"
+// @Param        uuid        path    string                       true   "Memory UUID"
+// @Param        input       body    handlers.PatchMemoryRequest  true   "Fields to update"
+// @Success      200  {object}  handlers.MemoryResponse
+// @Failure      400  {object}  models.ProblemDetails
+// @Failure      404  {object}  models.ProblemDetails
+// @Failure      500  {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/memories/{uuid} [patch]
+func (h *BrainHandler) PatchMemory(c *gin.Context) {
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
+	var req PatchMemoryRequest
+	if err := BindStrict(c, &req); err != nil {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", err.Error())
+		return
+	}
+	if req.Tags == nil && req.Weight == nil {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", "at least one of tags or weight is required")
+		return
+	}
+
+	memory, err := brain.PatchMemory(c.Param("uuid"), core.MemoryPatch{
+		Tags:   req.Tags,
+		Weight: req.Weight,
+	})
+	if err != nil {
+		memoryErrorResponse(c, err)
+		return
+	}
+
+	SuccessResponse(c, toMemoryResponse(*memory))
+}
+
+// DeleteMemory は要求に応じて記憶を忘却（削除）
+// DELETE /api/v1/memories/{uuid}
+// @Summary      Forget Memory
+// @Description  記憶を削除します（STMまたはLTM）。
+// @Tags         brain
+// @Param        X-Brain-ID  header  string  false  "Brain ID (default: default)"
+// @Param        uuid        path    string  true   "Memory UUID"
+// @Success      204
+// @Failure      404  {object}  models.ProblemDetails
+// @Failure      500  {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/memories/{uuid} [delete]
+func (h *BrainHandler) DeleteMemory(c *gin.Context) {
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
+	if err := brain.ForgetMemory(c.Param("uuid")); err != nil {
+		memoryErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ForgetMemories は複数の記憶を一括で忘却（削除）
+// POST /api/v1/memories/deletions
+// @Summary      Forget Memories (Bulk)
+// @Description  UUIDを指定して複数の記憶を一括削除します。存在しないUUIDは無視されます。
+// @Tags         brain
+// @Accept       json
+// @Produce      json
+// @Param        X-Brain-ID  header  string                          false  "Brain ID (default: default)"
+// @Param        input       body    handlers.ForgetMemoriesRequest  true   "UUIDs to delete"
+// @Success      200  {object}  models.SuccessResponse
+// @Failure      400  {object}  models.ProblemDetails
+// @Failure      500  {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/memories/deletions [post]
+func (h *BrainHandler) ForgetMemories(c *gin.Context) {
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
+	var req ForgetMemoriesRequest
+	if err := BindStrict(c, &req); err != nil {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", err.Error())
+		return
+	}
+
+	deleted, err := brain.ForgetMemories(req.UUIDs)
+	if err != nil {
+		ErrorResponse(c, http.StatusInternalServerError, "Memory Deletion Failed", err.Error())
+		return
+	}
+
+	SuccessResponse(c, gin.H{
+		"requestedCount": len(req.UUIDs),
+		"deletedCount":   deleted,
+	})
+}
+
+// SearchMemoriesRequest は記憶検索のクエリパラメータ
+type SearchMemoriesRequest struct {
+	Q           string     `form:"q" json:"q" validate:"required,max=100"`
+	Emotion     string     `form:"emotion" json:"emotion" validate:"omitempty,oneof=J S A F L D H G N"`
+	Type        string     `form:"type" json:"type" validate:"omitempty,oneof=STM LTM"`
+	MinWeight   *float64   `form:"minWeight" json:"minWeight" validate:"omitempty,min=0,max=1"`
+	MaxWeight   *float64   `form:"maxWeight" json:"maxWeight" validate:"omitempty,min=0,max=1"`
+	CreatedFrom *time.Time `form:"createdFrom" json:"createdFrom" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"createdTo" json:"createdTo" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit       int        `form:"limit,default=20" json:"limit" validate:"min=1,max=100"`
+	Offset      int        `form:"offset" json:"offset" validate:"min=0"`
+}
+
+// SearchMemoriesResponse は記憶検索のレスポンス
+type SearchMemoriesResponse struct {
+	Memories []MemoryResponse `json:"memories"`
+	Total    int              `json:"total"`
+	Limit    int              `json:"limit"`
+	Offset   int              `json:"offset"`
+}
+
+// SearchMemories は記憶を全文検索する
+// GET /api/v1/memories/search
+// [神経科学] 海馬に保持されたエピソード記憶を、手がかり(キーワード)から検索します。
+// 本文とタグ(感情コード・概念)を対象とし、感情・記憶タイプ・重み・作成日時で絞り込めます。
+// @Summary      Search Memories
+// @Description  記憶の本文とタグを全文検索します（3文字以上はFTS5のtrigram検索、それ未満は部分一致）。短期記憶が先、長期記憶が関連度順に続きます。
+// @Tags         brain
+// @Produce      json
+// @Param        X-Brain-ID   header  string   false  "Brain ID (default: default)"
+// @Param        q            query   string   true   "検索キーワード"
+// @Param        emotion      query   string   false  "感情コード (J, S, A, F, L, D, H, G, N)"
+// @Param        type         query   string   false  "記憶タイプ (STM, LTM)"
+// @Param        minWeight    query   number   false  "重みの下限 (0.0-1.0)"
+// @Param        maxWeight    query   number   false  "重みの上限 (0.0-1.0)"
+// @Param        createdFrom  query   string   false  "作成日時の下限 (RFC3339)"
+// @Param        createdTo    query   string   false  "作成日時の上限 (RFC3339)"
+// @Param        limit        query   int      false  "取得件数 (1-100, default: 20)"
+// @Param        offset       query   int      false  "取得開始位置"
+// @Success      200  {object}  handlers.SearchMemoriesResponse
+// @Failure      400  {object}  models.ProblemDetails
+// @Failure      404  {object}  models.ProblemDetails
+// @Failure      500  {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/memories/search [get]
+func (h *BrainHandler) SearchMemories(c *gin.Context) {
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
+	var req SearchMemoriesRequest
+	if err := BindQueryStrict(c, &req); err != nil {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Query Parameters", err.Error())
+		return
+	}
+	if req.MinWeight != nil && req.MaxWeight != nil && *req.MinWeight > *req.MaxWeight {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Query Parameters", "minWeight must be less than or equal to maxWeight")
+		return
+	}
+	if req.CreatedFrom != nil && req.CreatedTo != nil && req.CreatedFrom.After(*req.CreatedTo) {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Query Parameters", "createdFrom must be before createdTo")
+		return
+	}
+
+	result, err := brain.SearchMemories(store.MemorySearchQuery{
+		Text:        req.Q,
+		Emotion:     models.EmotionCode(req.Emotion),
+		Type:        models.MemoryType(req.Type),
+		MinWeight:   req.MinWeight,
+		MaxWeight:   req.MaxWeight,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		Limit:       req.Limit,
+		Offset:      req.Offset,
+	})
+	if err != nil {
+		ErrorResponse(c, http.StatusInternalServerError, "Memory Search Failed", err.Error())
+		return
+	}
+
+	SuccessResponse(c, SearchMemoriesResponse{
+		Memories: toMemoryResponses(result.Memories),
+		Total:    result.Total,
+		Limit:    req.Limit,
+		Offset:   req.Offset,
+	})
+}
+
+// toMemoryResponse は記憶をレスポンス形式に変換
+func toMemoryResponse(memory models.RuneMemory) MemoryResponse {
+	return MemoryResponse{
+		UUID:        memory.UUID,
+		Text:        memory.Text,
+		Emotions:    memory.Emotions,
+		Weight:      memory.Weight,
+		Type:        string(memory.Type),
+		CreatedAt:   memory.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		LastAccess:  memory.LastAccess.Format("2006-01-02T15:04:05Z07:00"),
+		RecallCount: memory.RecallCount,
+		Tags:        memory.Tags,
+	}
+}
+
+// toMemoryResponses は記憶の一覧をレスポンス形式に変換
+func toMemoryResponses(memories []models.RuneMemory) []MemoryResponse {
+	responses := make([]MemoryResponse, len(memories))
+	for i, memory := range memories {
+		responses[i] = toMemoryResponse(memory)
+	}
+	return responses
+}
+
+// memoryErrorResponse は記憶操作のエラーを適切なHTTPステータスに変換して返す
+func memoryErrorResponse(c *gin.Context, err error) {
+	if errors.Is(err, store.ErrMemoryNotFound) {
+		ErrorResponse(c, http.StatusNotFound, "Memory Not Found", fmt.Sprintf("memory %q does not exist", c.Param("uuid")))
+		return
+	}
+	if errors.Is(err, core.ErrBrainClosed) {
+		registryErrorResponse(c, err)
+		return
+	}
+	ErrorResponse(c, http.StatusInternalServerError, "Memory Operation Failed", err.Error())
+}
+
+// encodeMemoryCursor はページング位置を不透明なカーソル文字列に変換
+func encodeMemoryCursor(cursor store.MemoryCursor, sort, order string) string {
+	data, _ := json.Marshal(memoryCursor{
+		Sort:   sort,
+		Order:  order,
+		Time:   cursor.Time,
+		Weight: cursor.Weight,
+		UUID:   cursor.UUID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeMemoryCursor はカーソル文字列をページング位置に変換
+// カーソル発行時と異なる並び順が指定された場合はエラー
+func decodeMemoryCursor(encoded, sort, order string) (*store.MemoryCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	var cursor memoryCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.UUID == "" {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	if cursor.Sort != sort || cursor.Order != order {
+		return nil, fmt.Errorf("cursor was issued for sort=%s&order=%s", cursor.Sort, cursor.Order)
+	}
+
+	return &store.MemoryCursor{
+		Time:   cursor.Time,
+		Weight: cursor.Weight,
+		UUID:   cursor.UUID,
+	}, nil
+}
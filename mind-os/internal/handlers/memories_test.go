@@ -0,0 +1,484 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/config"
+	"github.com/umekku/mind-os/internal/core"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestRegistry はテスト用の Registry を作る (データベースは一時ディレクトリに作成)
+func newTestRegistry(t *testing.T) *core.Registry {
+	t.Helper()
+	r := core.NewRegistry(&config.Config{
+		DBPath:                 filepath.Join(t.TempDir(), "mind.db"),
+		MaxActiveBrains:        10,
+		DefaultProfile:         "default",
+		STMMaxSize:             100,
+		LTMMaxSize:             1000,
+		ConsolidationThreshold: 0.6,
+		MemoryStabilityHours:   24,
+		MemoryForgetFloor:      0.05,
+		HormoneDecayRate:       10,
+		PFCInitialSanity:       80,
+		PFCControlThreshold:    30,
+		PFCNegativeSuppression: 0.5,
+		PFCPositiveBoost:       0.1,
+		BasalAlpha:             0.5,
+		BasalBeta:              0.3,
+		BasalDecayRate:         0.95,
+		DayTimeStart:           6,
+		NightTimeStart:         22,
+	}, nil, nil)
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+// newTestBrain は Registry に脳 alice を作成して返す
+func newTestBrain(t *testing.T, r *core.Registry) *core.Brain {
+	t.Helper()
+	brain, err := r.Create("alice", "")
+	if err != nil {
+		t.Fatalf("Create(alice) error = %v", err)
+	}
+	return brain
+}
+
+// newBrainRouter は脳を操作するルートを持つルーター (main.go の registerBrainRoutes と同じ構成、認証なし)
+func newBrainRouter(h *BrainHandler) *gin.Engine {
+	r := gin.New()
+	v1 := r.Group("/api/v1")
+	for _, g := range []*gin.RouterGroup{v1, v1.Group("/brains/:brainId")} {
+		g.POST("/sensory-inputs", h.ProcessSensory)
+		g.POST("/sleep-cycles", h.Sleep)
+		g.GET("/brain-states/current", h.GetState)
+		g.POST("/daydreams", h.Daydream)
+		g.GET("/conversations/ws", h.Converse)
+		g.GET("/memories", h.ListMemories)
+		g.GET("/memories/search", h.SearchMemories)
+		g.POST("/memories/deletions", h.ForgetMemories)
+		g.GET("/memories/:uuid", h.GetMemory)
+		g.PATCH("/memories/:uuid", h.PatchMemory)
+		g.DELETE("/memories/:uuid", h.DeleteMemory)
+	}
+	return r
+}
+
+// serve はリクエストを処理して結果を返す (headers は名前と値の組)
+func serve(r http.Handler, method, target, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// decodeJSON はステータスを確認してレスポンスボディを読み取る
+func decodeJSON[T any](t *testing.T, w *httptest.ResponseRecorder, wantStatus int) T {
+	t.Helper()
+	var v T
+	if w.Code != wantStatus {
+		t.Fatalf("status = %d, want %d (body %s)", w.Code, wantStatus, w.Body)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+		t.Fatalf("decode body: %v (body %s)", err, w.Body)
+	}
+	return v
+}
+
+// decodeProblem はステータスと Content-Type を確認して RFC 9457 の問題詳細を読み取る
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder, wantStatus int) models.ProblemDetails {
+	t.Helper()
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Content-Type = %q, want application/problem+json", ct)
+	}
+	problem := decodeJSON[models.ProblemDetails](t, w, wantStatus)
+	if problem.Status != wantStatus {
+		t.Errorf("problem.Status = %d, want %d", problem.Status, wantStatus)
+	}
+	return problem
+}
+
+// seedMemories は長期記憶を保存する (作成日時を省略した場合は引数の順に1時間ずつ新しくなる)
+func seedMemories(t *testing.T, brain *core.Brain, memories ...models.RuneMemory) {
+	t.Helper()
+	base := time.Now().Add(-time.Duration(len(memories)) * time.Hour)
+	for i, m := range memories {
+		if m.Type == "" {
+			m.Type = models.MemoryLTM
+		}
+		if m.CreatedAt.IsZero() {
+			m.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		}
+		if m.LastAccess.IsZero() {
+			m.LastAccess = m.CreatedAt
+		}
+		if err := brain.DB.SaveMemory(m); err != nil {
+			t.Fatalf("SaveMemory(%s) error = %v", m.UUID, err)
+		}
+	}
+}
+
+// memoryUUIDs はレスポンスの記憶のUUIDを順に返す
+func memoryUUIDs(memories []MemoryResponse) []string {
+	ids := make([]string, len(memories))
+	for i, m := range memories {
+		ids[i] = m.UUID
+	}
+	return ids
+}
+
+// brainHeader は操作対象の脳を alice にするヘッダー
+var brainHeader = []string{BrainIDHeader, "alice"}
+
+func TestSearchMemories(t *testing.T) {
+	registry := newTestRegistry(t)
+	brain := newTestBrain(t, registry)
+	r := newBrainRouter(NewBrainHandler(registry, nil, nil))
+
+	joy := []models.EmotionValue{{Code: models.EmotionJoy, Value: 80}}
+	grief := []models.EmotionValue{{Code: models.EmotionGrief, Value: 60}}
+	seedMemories(t, brain,
+		models.RuneMemory{UUID: "percent", Text: "100%の力で走った", Emotions: joy, Weight: 0.9, Tags: []string{"J"}},
+		models.RuneMemory{UUID: "underscore", Text: "変数名は a_b にした", Emotions: joy, Weight: 0.5, Tags: []string{"J"}},
+		models.RuneMemory{UUID: "wildcard", Text: "axb という名前", Emotions: joy, Weight: 0.5, Tags: []string{"J"}},
+		models.RuneMemory{UUID: "quote", Text: `彼は"最高"と言った`, Emotions: joy, Weight: 0.6, Tags: []string{"J"}},
+		models.RuneMemory{UUID: "cat", Text: "猫が病気になった", Emotions: grief, Weight: 0.7, Tags: []string{"G", "猫"}},
+		models.RuneMemory{UUID: "dog", Text: "犬と散歩した", Emotions: joy, Weight: 0.3, Tags: []string{"J", "犬"}},
+	)
+
+	tests := []struct {
+		name  string
+		query url.Values
+		want  []string
+	}{
+		{"FTS (3文字以上)", url.Values{"q": {"100%の力"}}, []string{"percent"}},
+		{"部分一致 (3文字未満)", url.Values{"q": {"猫"}}, []string{"cat"}},
+		{"タグ", url.Values{"q": {"犬"}}, []string{"dog"}},
+		// 検索キーワードの記号は構文として解釈しない
+		{"LIKE の % をエスケープ", url.Values{"q": {"%"}}, []string{"percent"}},
+		{"LIKE の _ をエスケープ", url.Values{"q": {"_b"}}, []string{"underscore"}},
+		{"FTS の引用符をエスケープ", url.Values{"q": {`"最高"`}}, []string{"quote"}},
+		{"FTS の演算子をエスケープ", url.Values{"q": {"猫 OR 犬"}}, []string{}},
+		{"FTS の前方一致をエスケープ", url.Values{"q": {"犬と*"}}, []string{}},
+		{"FTS の列指定をエスケープ", url.Values{"q": {"text:猫"}}, []string{}},
+		// フィルタ
+		{"感情", url.Values{"q": {"った"}, "emotion": {"G"}}, []string{"cat"}},
+		{"重み範囲", url.Values{"q": {"った"}, "minWeight": {"0.65"}, "maxWeight": {"0.8"}}, []string{"cat"}},
+		{"記憶タイプ", url.Values{"q": {"猫"}, "type": {"STM"}}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodGet, "/api/v1/memories/search?"+tt.query.Encode(), "", brainHeader...)
+			resp := decodeJSON[SearchMemoriesResponse](t, w, http.StatusOK)
+			if got := memoryUUIDs(resp.Memories); !slices.Equal(got, tt.want) || resp.Total != len(tt.want) {
+				t.Errorf("search %s = %v (total %d), want %v", tt.query.Encode(), got, resp.Total, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchMemories_Pagination(t *testing.T) {
+	registry := newTestRegistry(t)
+	brain := newTestBrain(t, registry)
+	r := newBrainRouter(NewBrainHandler(registry, nil, nil))
+
+	seedMemories(t, brain,
+		models.RuneMemory{UUID: "rain-1", Text: "雨の日", Weight: 0.9, Tags: []string{"G"}},
+		models.RuneMemory{UUID: "rain-2", Text: "雨の夜", Weight: 0.6, Tags: []string{"G"}},
+		models.RuneMemory{UUID: "rain-3", Text: "雨の朝", Weight: 0.3, Tags: []string{"G"}},
+	)
+	// 短期記憶は長期記憶より先に並ぶ
+	stm, err := brain.AddMemory("雨が降ってきた")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"q=雨&limit=2", []string{stm.UUID, "rain-1"}},
+		{"q=雨&limit=2&offset=2", []string{"rain-2", "rain-3"}},
+		{"q=雨&limit=2&offset=4", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			w := serve(r, http.MethodGet, "/api/v1/brains/alice/memories/search?"+tt.query, "")
+			resp := decodeJSON[SearchMemoriesResponse](t, w, http.StatusOK)
+			if got := memoryUUIDs(resp.Memories); !slices.Equal(got, tt.want) || resp.Total != 4 || resp.Limit != 2 {
+				t.Errorf("search = %v (total %d, limit %d), want %v (total 4, limit 2)", got, resp.Total, resp.Limit, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchMemories_InvalidQuery(t *testing.T) {
+	registry := newTestRegistry(t)
+	newTestBrain(t, registry)
+	r := newBrainRouter(NewBrainHandler(registry, nil, nil))
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"キーワードなし", ""},
+		{"長すぎるキーワード", "q=" + strings.Repeat("猫", 101)},
+		{"未知の感情コード", "q=猫&emotion=X"},
+		{"重みの範囲外", "q=猫&minWeight=1.5"},
+		{"重みの下限 > 上限", "q=猫&minWeight=0.8&maxWeight=0.2"},
+		{"作成日時の下限 > 上限", "q=猫&createdFrom=2026-03-02T00:00:00Z&createdTo=2026-03-01T00:00:00Z"},
+		{"作成日時の形式", "q=猫&createdFrom=2026-03-01"},
+		{"件数の上限", "q=猫&limit=101"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodGet, "/api/v1/memories/search?"+tt.query, "", brainHeader...)
+			if problem := decodeProblem(t, w, http.StatusBadRequest); problem.Title != "Invalid Query Parameters" {
+				t.Errorf("Title = %q, want Invalid Query Parameters", problem.Title)
+			}
+		})
+	}
+
+	// 存在しない脳
+	w := serve(r, http.MethodGet, "/api/v1/brains/bob/memories/search?q=猫", "")
+	decodeProblem(t, w, http.StatusNotFound)
+}
+
+// listMemoriesFixture は一覧のテスト用に長期記憶5件と短期記憶1件を保存し、短期記憶のUUIDを返す
+// 長期記憶の作成日時・最終アクセス日時は ltm-1 が最も古く、重みは ltm-2 と ltm-4 が同じ
+func listMemoriesFixture(t *testing.T, brain *core.Brain) string {
+	t.Helper()
+	joy := []models.EmotionValue{{Code: models.EmotionJoy, Value: 80}}
+	grief := []models.EmotionValue{{Code: models.EmotionGrief, Value: 60}}
+	seedMemories(t, brain,
+		models.RuneMemory{UUID: "ltm-1", Text: "猫と遊んだ", Emotions: joy, Weight: 0.9, Tags: []string{"J", "猫"}},
+		models.RuneMemory{UUID: "ltm-2", Text: "猫が病気になった", Emotions: grief, Weight: 0.5, Tags: []string{"G", "猫"}},
+		models.RuneMemory{UUID: "ltm-3", Text: "雨の日", Emotions: grief, Weight: 0.3, Tags: []string{"G", "雨"}},
+		models.RuneMemory{UUID: "ltm-4", Text: "友達と話した", Emotions: joy, Weight: 0.5, Tags: []string{"J"}},
+		models.RuneMemory{UUID: "ltm-5", Text: "散歩した", Emotions: joy, Weight: 0.7, Tags: []string{"J"}},
+	)
+	stm, err := brain.AddMemory("嬉しい知らせ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stm.UUID
+}
+
+func TestListMemories(t *testing.T) {
+	registry := newTestRegistry(t)
+	stm := listMemoriesFixture(t, newTestBrain(t, registry))
+	r := newBrainRouter(NewBrainHandler(registry, nil, nil))
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"既定 (最終アクセスの新しい順)", "", []string{stm, "ltm-5", "ltm-4", "ltm-3", "ltm-2", "ltm-1"}},
+		{"長期記憶", "type=LTM", []string{"ltm-5", "ltm-4", "ltm-3", "ltm-2", "ltm-1"}},
+		{"短期記憶", "type=STM", []string{stm}},
+		{"タグ", "tag=猫", []string{"ltm-2", "ltm-1"}},
+		{"感情", "emotion=G", []string{"ltm-3", "ltm-2"}},
+		{"タグと感情", "tag=猫&emotion=J", []string{"ltm-1"}},
+		{"作成日時の古い順", "type=LTM&sort=createdAt&order=asc", []string{"ltm-1", "ltm-2", "ltm-3", "ltm-4", "ltm-5"}},
+		// 同じ重みは UUID 順
+		{"重みの大きい順", "type=LTM&sort=weight", []string{"ltm-1", "ltm-5", "ltm-4", "ltm-2", "ltm-3"}},
+		{"重みの小さい順", "type=LTM&sort=weight&order=asc", []string{"ltm-3", "ltm-2", "ltm-4", "ltm-5", "ltm-1"}},
+		{"件数", "type=LTM&limit=2", []string{"ltm-5", "ltm-4"}},
+		{"該当なし", "tag=存在しない", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodGet, "/api/v1/memories?"+tt.query, "", brainHeader...)
+			resp := decodeJSON[ListMemoriesResponse](t, w, http.StatusOK)
+			if got := memoryUUIDs(resp.Memories); !slices.Equal(got, tt.want) || resp.Count != len(tt.want) {
+				t.Errorf("list %q = %v (count %d), want %v", tt.query, got, resp.Count, tt.want)
+			}
+		})
+	}
+}
+
+func TestListMemories_Cursor(t *testing.T) {
+	registry := newTestRegistry(t)
+	listMemoriesFixture(t, newTestBrain(t, registry))
+	r := newBrainRouter(NewBrainHandler(registry, nil, nil))
+
+	tests := []struct {
+		query string
+		want  [][]string // ページごとのUUID
+	}{
+		{"type=LTM&limit=2", [][]string{{"ltm-5", "ltm-4"}, {"ltm-3", "ltm-2"}, {"ltm-1"}}},
+		{"type=LTM&sort=createdAt&order=asc&limit=3", [][]string{{"ltm-1", "ltm-2", "ltm-3"}, {"ltm-4", "ltm-5"}}},
+		// 同じ重みの記憶がページの境界をまたいでも重複・欠落しない
+		{"type=LTM&sort=weight&limit=3", [][]string{{"ltm-1", "ltm-5", "ltm-4"}, {"ltm-2", "ltm-3"}}},
+		{"type=LTM&sort=weight&order=asc&limit=2", [][]string{{"ltm-3", "ltm-2"}, {"ltm-4", "ltm-5"}, {"ltm-1"}}},
+		{"tag=猫&limit=1", [][]string{{"ltm-2"}, {"ltm-1"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			cursor := ""
+			for page, want := range tt.want {
+				target := "/api/v1/brains/alice/memories?" + tt.query
+				if cursor != "" {
+					target += "&cursor=" + url.QueryEscape(cursor)
+				}
+				resp := decodeJSON[ListMemoriesResponse](t, serve(r, http.MethodGet, target, ""), http.StatusOK)
+				if got := memoryUUIDs(resp.Memories); !slices.Equal(got, want) {
+					t.Fatalf("page %d = %v, want %v", page+1, got, want)
+				}
+
+				// 最後のページには nextCursor がない
+				last := page == len(tt.want)-1
+				if (resp.NextCursor == "") != last {
+					t.Fatalf("page %d nextCursor = %q, want it only before the last page", page+1, resp.NextCursor)
+				}
+				cursor = resp.NextCursor
+			}
+		})
+	}
+}
+
+func TestListMemories_InvalidQuery(t *testing.T) {
+	registry := newTestRegistry(t)
+	listMemoriesFixture(t, newTestBrain(t, registry))
+	r := newBrainRouter(NewBrainHandler(registry, nil, nil))
+
+	first := decodeJSON[ListMemoriesResponse](t, serve(r, http.MethodGet, "/api/v1/memories?limit=1", "", brainHeader...), http.StatusOK)
+	if first.NextCursor == "" {
+		t.Fatal("nextCursor is empty")
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantTitle string
+	}{
+		{"未知の記憶タイプ", "type=MTM", "Invalid Query Parameters"},
+		{"未知の感情コード", "emotion=X", "Invalid Query Parameters"},
+		{"未知の並び順", "sort=text", "Invalid Query Parameters"},
+		{"未知の昇順/降順", "order=up", "Invalid Query Parameters"},
+		{"件数の下限", "limit=0", "Invalid Query Parameters"},
+		{"件数の上限", "limit=101", "Invalid Query Parameters"},
+		{"壊れたカーソル", "cursor=%21%21%21", "Invalid Cursor"},
+		{"JSON でないカーソル", "cursor=bm90LWpzb24", "Invalid Cursor"},
+		// カーソルは発行時と同じ並び順でのみ使える
+		{"並び順の異なるカーソル", "sort=weight&cursor=" + first.NextCursor, "Invalid Cursor"},
+		{"昇順/降順の異なるカーソル", "order=asc&cursor=" + first.NextCursor, "Invalid Cursor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodGet, "/api/v1/memories?"+tt.query, "", brainHeader...)
+			if problem := decodeProblem(t, w, http.StatusBadRequest); problem.Title != tt.wantTitle {
+				t.Errorf("Title = %q (%s), want %q", problem.Title, problem.Detail, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestMemoryCRUD(t *testing.T) {
+	registry := newTestRegistry(t)
+	stm := listMemoriesFixture(t, newTestBrain(t, registry))
+	r := newBrainRouter(NewBrainHandler(registry, nil, nil))
+
+	get := func(uuid string) *httptest.ResponseRecorder {
+		return serve(r, http.MethodGet, "/api/v1/memories/"+uuid, "", brainHeader...)
+	}
+
+	// 取得 (短期記憶・長期記憶)
+	for _, uuid := range []string{stm, "ltm-1"} {
+		if got := decodeJSON[MemoryResponse](t, get(uuid), http.StatusOK); got.UUID != uuid {
+			t.Errorf("GET %s returned %s", uuid, got.UUID)
+		}
+	}
+	decodeProblem(t, get("missing"), http.StatusNotFound)
+
+	// 部分更新
+	patchTests := []struct {
+		name       string
+		uuid       string
+		body       string
+		wantStatus int
+		wantWeight float64
+		wantTags   []string
+	}{
+		{"重みのみ", "ltm-1", `{"weight":0.2}`, http.StatusOK, 0.2, []string{"J", "猫"}},
+		{"タグのみ", "ltm-1", `{"tags":["猫","思い出"]}`, http.StatusOK, 0.2, []string{"猫", "思い出"}},
+		{"短期記憶", stm, `{"weight":0.95,"tags":["J"]}`, http.StatusOK, 0.95, []string{"J"}},
+		{"フィールドなし", "ltm-1", `{}`, http.StatusBadRequest, 0, nil},
+		{"重みの範囲外", "ltm-1", `{"weight":1.5}`, http.StatusBadRequest, 0, nil},
+		{"空のタグ", "ltm-1", `{"tags":[""]}`, http.StatusBadRequest, 0, nil},
+		{"未知のフィールド", "ltm-1", `{"text":"書き換え"}`, http.StatusBadRequest, 0, nil},
+		{"存在しない記憶", "missing", `{"weight":0.5}`, http.StatusNotFound, 0, nil},
+	}
+	for _, tt := range patchTests {
+		t.Run("PATCH/"+tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodPatch, "/api/v1/memories/"+tt.uuid, tt.body, brainHeader...)
+			if tt.wantStatus != http.StatusOK {
+				decodeProblem(t, w, tt.wantStatus)
+				return
+			}
+			patched := decodeJSON[MemoryResponse](t, w, http.StatusOK)
+			stored := decodeJSON[MemoryResponse](t, get(tt.uuid), http.StatusOK)
+			for _, m := range []MemoryResponse{patched, stored} {
+				if m.Weight != tt.wantWeight || !slices.Equal(m.Tags, tt.wantTags) {
+					t.Errorf("memory = weight %v, tags %v, want %v, %v", m.Weight, m.Tags, tt.wantWeight, tt.wantTags)
+				}
+			}
+		})
+	}
+
+	// 削除
+	if w := serve(r, http.MethodDelete, "/api/v1/memories/ltm-1", "", brainHeader...); w.Code != http.StatusNoContent {
+		t.Errorf("DELETE ltm-1 status = %d, want 204", w.Code)
+	}
+	decodeProblem(t, get("ltm-1"), http.StatusNotFound)
+	decodeProblem(t, serve(r, http.MethodDelete, "/api/v1/memories/ltm-1", "", brainHeader...), http.StatusNotFound)
+
+	// 一括削除 (存在しないUUIDは無視する)
+	w := serve(r, http.MethodPost, "/api/v1/memories/deletions", `{"uuids":["ltm-2","ltm-3","`+stm+`","missing"]}`, brainHeader...)
+	deleted := decodeJSON[map[string]int](t, w, http.StatusOK)
+	if deleted["requestedCount"] != 4 || deleted["deletedCount"] != 3 {
+		t.Errorf("deletions = %v, want requestedCount 4, deletedCount 3", deleted)
+	}
+	list := decodeJSON[ListMemoriesResponse](t, serve(r, http.MethodGet, "/api/v1/memories", "", brainHeader...), http.StatusOK)
+	if got := memoryUUIDs(list.Memories); !slices.Equal(got, []string{"ltm-5", "ltm-4"}) {
+		t.Errorf("memories after deletions = %v, want [ltm-5 ltm-4]", got)
+	}
+	for _, body := range []string{`{}`, `{"uuids":[]}`, `{"uuids":[""]}`} {
+		decodeProblem(t, serve(r, http.MethodPost, "/api/v1/memories/deletions", body, brainHeader...), http.StatusBadRequest)
+	}
+
+	// 他の脳の記憶は操作できない
+	if _, err := registry.Create("bob", ""); err != nil {
+		t.Fatal(err)
+	}
+	decodeProblem(t, serve(r, http.MethodGet, "/api/v1/brains/bob/memories/ltm-5", ""), http.StatusNotFound)
+	decodeProblem(t, serve(r, http.MethodDelete, "/api/v1/brains/bob/memories/ltm-5", ""), http.StatusNotFound)
+	decodeJSON[MemoryResponse](t, get("ltm-5"), http.StatusOK)
+}
@@ -4,8 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/umekku/mind-os/internal/amygdala"
-	"github.com/umekku/mind-os/internal/hippocampus"
+	"github.com/umekku/mind-os/internal/core"
 	"github.com/umekku/mind-os/internal/models"
 )
 
@@ -16,13 +15,15 @@ type MemoryRequest struct {
 
 // MemoryResponse は記憶レスポンスの構造体
 type MemoryResponse struct {
-	UUID      string                `json:"uuid"`
-	Text      string                `json:"text"`
-	Emotions  []models.EmotionValue `json:"emotions"`
-	Weight    float64               `json:"weight"`
-	Type      string                `json:"type"`
-	CreatedAt string                `json:"createdAt"` // consistent with other models
-	Tags      []string              `json:"tags"`
+	UUID        string                `json:"uuid"`
+	Text        string                `json:"text"`
+	Emotions    []models.EmotionValue `json:"emotions"`
+	Weight      float64               `json:"weight"`
+	Type        string                `json:"type"`
+	CreatedAt   string                `json:"createdAt"` // consistent with other models
+	LastAccess  string                `json:"lastAccess,omitempty"`
+	RecallCount int                   `json:"recallCount"`
+	Tags        []string              `json:"tags"`
 }
 
 // MemoryStatsResponse は記憶統計レスポンスの構造体
@@ -31,106 +32,121 @@ type MemoryStatsResponse struct {
 	LTMCount int `json:"ltmCount"`
 }
 
-// MemoryHandler は記憶管理ハンドラー
+// MemoryHandler は記憶管理ハンドラー (旧API: /api/memory)
+// 統合API (/api/v1) と同じ Brain を操作するため、Registry から脳を解決する
 type MemoryHandler struct {
-	hippocampus *hippocampus.Hippocampus
-	amygdala    *amygdala.Amygdala
+	registry *core.Registry
 }
 
 // NewMemoryHandler は新しい MemoryHandler を作成
-// Note: DB接続は現在MemoryHandlerでは使用しない(STMのみ)か、将来的に注入する
-func NewMemoryHandler() *MemoryHandler {
-	am, _ := amygdala.New()
+func NewMemoryHandler(registry *core.Registry) *MemoryHandler {
 	return &MemoryHandler{
-		hippocampus: hippocampus.New(nil), // DBなし（STMのみモード）
-		amygdala:    am,                   // 記憶の感情付けに使用
+		registry: registry,
 	}
 }
 
 // AddMemory は新しいエピソード記憶を追加
-// POST /api/v1/memories
+// POST /api/memory/add
 // [神経科学] 入力された体験をエピソード記憶として海馬(Hippocampus)にエンコードします。
 // 同時に扁桃体(Amygdala)による感情評価を行い、記憶に感情的な重み付け（サリエンス）を付与します。
-// @Summary      Create New Memory
-// @Description  新しい記憶を作成し、感情評価を行って短期記憶(STM)として保存します。
-// @Tags         brain
+// @Summary      Create New Memory (Deprecated)
+// @Description  新しい記憶を作成し、感情評価を行って短期記憶(STM)として保存します。/api/v1/sensory-inputs を使用してください。
+// @Tags         legacy
 // @Accept       json
 // @Produce      json
-// @Param        input  body      handlers.MemoryRequest  true  "Memory Content"
+// @Param        X-Brain-ID  header    string                  false  "Brain ID (default: default)"
+// @Param        input       body      handlers.MemoryRequest  true   "Memory Content"
 // @Success      201    {object}  handlers.MemoryResponse
 // @Failure      400    {object}  models.ProblemDetails
-// @Router       /api/v1/memories [post]
+// @Deprecated
+// @Security     ApiKeyAuth
+// @Router       /api/memory/add [post]
 func (h *MemoryHandler) AddMemory(c *gin.Context) {
+	brain, ok := resolveBrain(c, h.registry)
+	if !ok {
+		return
+	}
+
 	var req MemoryRequest
 	if err := BindStrict(c, &req); err != nil {
 		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", err.Error())
 		return
 	}
 
-	// 1. 感情分析
-	emotions := h.amygdala.Assess(req.Text)
-
-	// 2. 記憶として追加
-	memory := h.hippocampus.AddMemory(req.Text, emotions)
-
-	// レスポンス構築
-	// モデル変換
-	emotionResponses := make([]models.EmotionValue, len(memory.Emotions))
-	for i, e := range memory.Emotions {
-		emotionResponses[i] = models.EmotionValue{Code: e.Code, Value: e.Value}
-	}
-
-	resp := MemoryResponse{
-		UUID:      memory.UUID,
-		Text:      memory.Text,
-		Emotions:  emotionResponses,
-		Weight:    memory.Weight,
-		Type:      string(memory.Type),
-		CreatedAt: memory.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		Tags:      memory.Tags,
+	memory, err := brain.AddMemory(req.Text)
+	if err != nil {
+		registryErrorResponse(c, err)
+		return
 	}
 
-	c.JSON(http.StatusCreated, resp)
+	c.JSON(http.StatusCreated, toMemoryResponse(memory))
 }
 
 // GetRecentMemories は直近の記憶を取得
-// GET /api/v1/memory/recent
+// GET /api/memory/recent
+// @Summary      Get Recent Memories (Deprecated)
+// @Description  直近の記憶を取得します。/api/v1/memories を使用してください。
+// @Tags         legacy
+// @Produce      json
+// @Param        X-Brain-ID  header  string  false  "Brain ID (default: default)"
+// @Success      200  {array}  handlers.MemoryResponse
+// @Deprecated
+// @Security     ApiKeyAuth
+// @Router       /api/memory/recent [get]
 func (h *MemoryHandler) GetRecentMemories(c *gin.Context) {
-	memories := h.hippocampus.GetRecentContext()
-
-	// レスポンス変換
-	responses := make([]MemoryResponse, len(memories))
-	for i, m := range memories {
-		emotionResponses := make([]models.EmotionValue, len(m.Emotions))
-		for j, e := range m.Emotions {
-			emotionResponses[j] = models.EmotionValue{Code: e.Code, Value: e.Value}
-		}
-		responses[i] = MemoryResponse{
-			UUID:      m.UUID,
-			Text:      m.Text,
-			Emotions:  emotionResponses,
-			Weight:    m.Weight,
-			Type:      string(m.Type),
-			CreatedAt: m.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			Tags:      m.Tags,
-		}
+	brain, ok := resolveBrain(c, h.registry)
+	if !ok {
+		return
 	}
 
-	c.JSON(http.StatusOK, responses)
+	c.JSON(http.StatusOK, toMemoryResponses(brain.GetRecentMemories()))
 }
 
 // GetMemoryStats は記憶の統計情報を取得
-// GET /api/v1/memory/stats
+// GET /api/memory/stats
+// @Summary      Get Memory Stats (Deprecated)
+// @Description  短期記憶・長期記憶の件数を取得します。/api/v1/brain-states/current を使用してください。
+// @Tags         legacy
+// @Produce      json
+// @Param        X-Brain-ID  header  string  false  "Brain ID (default: default)"
+// @Success      200  {object}  handlers.MemoryStatsResponse
+// @Deprecated
+// @Security     ApiKeyAuth
+// @Router       /api/memory/stats [get]
 func (h *MemoryHandler) GetMemoryStats(c *gin.Context) {
+	brain, ok := resolveBrain(c, h.registry)
+	if !ok {
+		return
+	}
+
+	state := brain.GetState()
 	c.JSON(http.StatusOK, MemoryStatsResponse{
-		STMCount: h.hippocampus.GetSTMCount(),
-		LTMCount: h.hippocampus.GetLTMCount(),
+		STMCount: state.STMCount,
+		LTMCount: state.LTMCount,
 	})
 }
 
 // Sleep は睡眠処理（固定化）を実行
-// POST /api/v1/memory/sleep
+// POST /api/memory/sleep
+// @Summary      Sleep (Deprecated)
+// @Description  記憶の固定化を実行します。/api/v1/sleep-cycles を使用してください。
+// @Tags         legacy
+// @Produce      json
+// @Param        X-Brain-ID  header  string  false  "Brain ID (default: default)"
+// @Success      200  {object}  models.SuccessResponse
+// @Deprecated
+// @Security     ApiKeyAuth
+// @Router       /api/memory/sleep [post]
 func (h *MemoryHandler) Sleep(c *gin.Context) {
-	h.hippocampus.SleepAndConsolidate()
-	c.JSON(http.StatusOK, gin.H{"message": "Sleep cycle completed", "stmCount": h.hippocampus.GetSTMCount(), "ltmCount": h.hippocampus.GetLTMCount()})
+	brain, ok := resolveBrain(c, h.registry)
+	if !ok {
+		return
+	}
+
+	result, err := brain.Sleep()
+	if err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Sleep cycle completed", "stmCount": result.STMCount, "ltmCount": result.LTMCount})
 }
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/core"
+)
+
+// newLegacyRouter は旧API (/api/memory, /api/motivation) と統合API (/api/v1) が同じ Registry を使うルーター
+func newLegacyRouter(registry *core.Registry) *gin.Engine {
+	r := newBrainRouter(NewBrainHandler(registry, nil, nil))
+
+	memoryHandler := NewMemoryHandler(registry)
+	memory := r.Group("/api/memory")
+	memory.POST("/add", memoryHandler.AddMemory)
+	memory.GET("/recent", memoryHandler.GetRecentMemories)
+	memory.GET("/stats", memoryHandler.GetMemoryStats)
+	memory.POST("/sleep", memoryHandler.Sleep)
+
+	motivationHandler := NewMotivationHandler(registry)
+	motivation := r.Group("/api/motivation")
+	motivation.GET("", motivationHandler.GetMotivation)
+	motivation.POST("/feedback", motivationHandler.UpdateMotivation)
+	motivation.POST("/emotion-reward", motivationHandler.RewardFromEmotion)
+	motivation.POST("/decay", motivationHandler.ApplyDecay)
+	motivation.POST("/reset", motivationHandler.Reset)
+	return r
+}
+
+func TestLegacyRoutes_ResolveBrain(t *testing.T) {
+	registry := newTestRegistry(t)
+	newTestBrain(t, registry)
+	r := newLegacyRouter(registry)
+
+	routes := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodPost, "/api/memory/add", `{"text":"覚えておいて"}`},
+		{http.MethodGet, "/api/memory/recent", ""},
+		{http.MethodGet, "/api/memory/stats", ""},
+		{http.MethodPost, "/api/memory/sleep", ""},
+		{http.MethodGet, "/api/motivation", ""},
+		{http.MethodPost, "/api/motivation/feedback", `{"isPositive":true}`},
+		{http.MethodPost, "/api/motivation/emotion-reward", `{"emotionValue":80}`},
+		{http.MethodPost, "/api/motivation/decay", ""},
+		{http.MethodPost, "/api/motivation/reset", ""},
+	}
+	tests := []struct {
+		name       string
+		brainID    string // X-Brain-ID (空の場合は付けない)
+		wantStatus int
+	}{
+		{"登録済みの脳", "alice", http.StatusOK},
+		{"既定の脳 (自動作成)", "", http.StatusOK},
+		{"存在しない脳", "bob", http.StatusNotFound},
+		{"不正な脳ID", "../alice", http.StatusBadRequest},
+	}
+
+	for _, route := range routes {
+		for _, tt := range tests {
+			t.Run(route.method+" "+route.path+"/"+tt.name, func(t *testing.T) {
+				var headers []string
+				if tt.brainID != "" {
+					headers = []string{BrainIDHeader, tt.brainID}
+				}
+				w := serve(r, route.method, route.path, route.body, headers...)
+
+				want := tt.wantStatus
+				if want == http.StatusOK && route.path == "/api/memory/add" {
+					want = http.StatusCreated
+				}
+				if want >= http.StatusBadRequest {
+					decodeProblem(t, w, want)
+				} else if w.Code != want {
+					t.Errorf("status = %d, want %d (body %s)", w.Code, want, w.Body)
+				}
+			})
+		}
+	}
+}
+
+func TestLegacyMemory_SharesBrain(t *testing.T) {
+	registry := newTestRegistry(t)
+	newTestBrain(t, registry)
+	if _, err := registry.Create("bob", ""); err != nil {
+		t.Fatal(err)
+	}
+	r := newLegacyRouter(registry)
+
+	// 旧APIで追加した記憶は、同じ脳の統合APIから参照できる
+	added := decodeJSON[MemoryResponse](t, serve(r, http.MethodPost, "/api/memory/add", `{"text":"初めての記憶"}`, brainHeader...), http.StatusCreated)
+	if got := decodeJSON[MemoryResponse](t, serve(r, http.MethodGet, "/api/v1/brains/alice/memories/"+added.UUID, ""), http.StatusOK); got.Text != "初めての記憶" {
+		t.Errorf("v1 memory text = %q, want 初めての記憶", got.Text)
+	}
+
+	tests := []struct {
+		brainID    string
+		wantRecent []string
+		wantSTM    int
+	}{
+		{"alice", []string{added.UUID}, 1},
+		// 他の脳からは見えない
+		{"bob", []string{}, 0},
+		{"default", []string{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.brainID, func(t *testing.T) {
+			recent := decodeJSON[[]MemoryResponse](t, serve(r, http.MethodGet, "/api/memory/recent", "", BrainIDHeader, tt.brainID), http.StatusOK)
+			if got := memoryUUIDs(recent); !slices.Equal(got, tt.wantRecent) {
+				t.Errorf("recent = %v, want %v", got, tt.wantRecent)
+			}
+			stats := decodeJSON[MemoryStatsResponse](t, serve(r, http.MethodGet, "/api/memory/stats", "", BrainIDHeader, tt.brainID), http.StatusOK)
+			if stats.STMCount != tt.wantSTM {
+				t.Errorf("stmCount = %d, want %d", stats.STMCount, tt.wantSTM)
+			}
+		})
+	}
+}
+
+func TestLegacyMotivation_SharesBrain(t *testing.T) {
+	registry := newTestRegistry(t)
+	alice := newTestBrain(t, registry)
+	bob, err := registry.Create("bob", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := newLegacyRouter(registry)
+	initial := bob.GetMotivation()
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"フィードバック", "/api/motivation/feedback", `{"isPositive":true}`},
+		{"感情報酬", "/api/motivation/emotion-reward", `{"emotionValue":90}`},
+		{"減衰", "/api/motivation/decay", ""},
+		{"リセット", "/api/motivation/reset", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serve(r, http.MethodPost, tt.path, tt.body, brainHeader...)
+
+			// 旧APIの結果は Registry 上の同じ Brain の状態と一致する
+			got := decodeJSON[MotivationResponse](t, serve(r, http.MethodGet, "/api/motivation", "", brainHeader...), http.StatusOK)
+			if want := alice.GetMotivation(); got.Motivation != want.Motivation || got.Level != want.Level {
+				t.Errorf("GET /api/motivation = %+v, want %+v", got, want)
+			}
+			// 他の脳は変化しない
+			if got := bob.GetMotivation(); got != initial {
+				t.Errorf("bob motivation = %+v, want unchanged %+v", got, initial)
+			}
+		})
+	}
+
+	// 変更後の状態は統合APIの脳の状態にも反映される
+	serve(r, http.MethodPost, "/api/motivation/emotion-reward", `{"emotionValue":100}`, brainHeader...)
+	state := decodeJSON[map[string]any](t, serve(r, http.MethodGet, "/api/v1/brains/alice/brain-states/current", ""), http.StatusOK)
+	if got, want := state["motivation"], float64(alice.GetMotivation().Motivation); got != want {
+		t.Errorf("v1 motivation = %v, want %v", got, want)
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/umekku/mind-os/internal/basal"
+	"github.com/umekku/mind-os/internal/core"
 )
 
 // MotivationRequest は意欲更新リクエストの構造体
@@ -23,113 +23,165 @@ type MotivationResponse struct {
 	Level      string `json:"level"`
 }
 
-// MotivationHandler は意欲管理ハンドラー
+// MotivationHandler は意欲管理ハンドラー (旧API: /api/motivation)
+// 統合API (/api/v1) と同じ Brain を操作するため、Registry から脳を解決する
 type MotivationHandler struct {
-	basalGanglia *basal.BasalGanglia
+	registry *core.Registry
 }
 
 // NewMotivationHandler は新しい MotivationHandler を作成
-func NewMotivationHandler() *MotivationHandler {
+func NewMotivationHandler(registry *core.Registry) *MotivationHandler {
 	return &MotivationHandler{
-		basalGanglia: basal.New(),
+		registry: registry,
 	}
 }
 
 // UpdateMotivation は意欲を直接更新
-// POST /api/v1/motivation
+// POST /api/motivation/feedback
 // [神経科学] フィードバック（報酬/罰）に基づいて線条体でのドーパミン放出を調整します。
 // ポジティブな結果は意欲を高め、ネガティブな結果は意欲を減退させます。
-// @Summary      Update Motivation (Direct Feedback)
-// @Description  外部要因（ユーザーからの直接的なフィードバックなど）に基づいて意欲レベルを更新します。
-// @Tags         brain
+// @Summary      Update Motivation (Deprecated)
+// @Description  外部要因（ユーザーからの直接的なフィードバックなど）に基づいて意欲レベルを更新します。/api/v1/feedback を使用してください。
+// @Tags         legacy
 // @Accept       json
 // @Produce      json
-// @Param        input  body      handlers.MotivationRequest  true  "Motivation Update"
+// @Param        X-Brain-ID  header    string                      false  "Brain ID (default: default)"
+// @Param        input       body      handlers.MotivationRequest  true   "Motivation Update"
 // @Success      200    {object}  handlers.MotivationResponse
 // @Failure      400    {object}  models.ProblemDetails
-// @Router       /api/v1/motivation [post]
+// @Deprecated
+// @Security     ApiKeyAuth
+// @Router       /api/motivation/feedback [post]
 func (h *MotivationHandler) UpdateMotivation(c *gin.Context) {
+	brain, ok := resolveBrain(c, h.registry)
+	if !ok {
+		return
+	}
+
 	var req MotivationRequest
 	if err := BindStrict(c, &req); err != nil {
 		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", err.Error())
 		return
 	}
 
-	// bool -> reward value conversion
-	reward := 0.0
-	if req.IsPositive {
-		reward = 100.0
+	if _, err := brain.UpdateMotivation(req.IsPositive); err != nil {
+		registryErrorResponse(c, err)
+		return
 	}
-	h.basalGanglia.UpdateMotivation(reward)
-	motivation := h.basalGanglia.GetMotivation()
 
-	c.JSON(http.StatusOK, MotivationResponse{
-		Motivation: int(motivation),
-		Level:      h.basalGanglia.GetMotivationLevel(),
-	})
+	c.JSON(http.StatusOK, toMotivationResponse(brain.GetMotivation()))
 }
 
 // GetMotivation は現在の意欲を取得
-// GET /api/v1/motivation
+// GET /api/motivation
+// @Summary      Get Motivation (Deprecated)
+// @Description  現在の意欲を取得します。/api/v1/brain-states/current を使用してください。
+// @Tags         legacy
+// @Produce      json
+// @Param        X-Brain-ID  header  string  false  "Brain ID (default: default)"
+// @Success      200  {object}  handlers.MotivationResponse
+// @Deprecated
+// @Security     ApiKeyAuth
+// @Router       /api/motivation [get]
 func (h *MotivationHandler) GetMotivation(c *gin.Context) {
-	motivation := h.basalGanglia.GetMotivation()
-	c.JSON(http.StatusOK, MotivationResponse{
-		Motivation: int(motivation),
-		Level:      h.basalGanglia.GetMotivationLevel(),
-	})
+	brain, ok := resolveBrain(c, h.registry)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, toMotivationResponse(brain.GetMotivation()))
 }
 
 // Reset は意欲をリセット
-// POST /api/v1/motivation/reset
+// POST /api/motivation/reset
+// @Summary      Reset Motivation (Deprecated)
+// @Description  意欲と報酬予測を初期値に戻します。
+// @Tags         legacy
+// @Produce      json
+// @Param        X-Brain-ID  header  string  false  "Brain ID (default: default)"
+// @Success      200  {object}  models.SuccessResponse
+// @Deprecated
+// @Security     ApiKeyAuth
+// @Router       /api/motivation/reset [post]
 func (h *MotivationHandler) Reset(c *gin.Context) {
-	h.basalGanglia.Reset()
+	brain, ok := resolveBrain(c, h.registry)
+	if !ok {
+		return
+	}
+
+	if _, err := brain.ResetMotivation(); err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{"message": "Motivation reset"})
 }
 
 // RewardFromEmotion は感情価に基づき報酬系を刺激
-// POST /api/v1/motivation/reward
+// POST /api/motivation/emotion-reward
 // [神経科学] 扁桃体や眼窩前頭皮質から送られる感情シグナル（快/不快）を報酬予測誤差(RPE)として処理し、意欲を調整します。
-// @Summary      Process Emotional Reward
+// @Summary      Process Emotional Reward (Deprecated)
 // @Description  感情的なポジティブ/ネガティブな出来事を報酬として処理し、意欲レベルに反映させます。
-// @Tags         brain
+// @Tags         legacy
 // @Accept       json
 // @Produce      json
-// @Param        input  body      handlers.EmotionRewardRequest  true  "Emotional Value"
+// @Param        X-Brain-ID  header    string                         false  "Brain ID (default: default)"
+// @Param        input       body      handlers.EmotionRewardRequest  true   "Emotional Value"
 // @Success      200    {object}  handlers.MotivationResponse
 // @Failure      400    {object}  models.ProblemDetails
-// @Router       /api/v1/motivation/reward [post]
+// @Deprecated
+// @Security     ApiKeyAuth
+// @Router       /api/motivation/emotion-reward [post]
 func (h *MotivationHandler) RewardFromEmotion(c *gin.Context) {
+	brain, ok := resolveBrain(c, h.registry)
+	if !ok {
+		return
+	}
+
 	var req EmotionRewardRequest
 	if err := BindStrict(c, &req); err != nil {
 		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", err.Error())
 		return
 	}
 
-	h.basalGanglia.RewardFromEmotion(float64(req.EmotionValue))
-	motivation := h.basalGanglia.GetMotivation()
-
-	c.JSON(http.StatusOK, MotivationResponse{
-		Motivation: int(motivation),
-		Level:      h.basalGanglia.GetMotivationLevel(),
-	})
+	state, err := brain.RewardFromEmotion(req.EmotionValue)
+	if err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toMotivationResponse(state))
 }
 
 // ApplyDecay は時間経過による意欲減衰を適用
-// POST /api/v1/motivation/decay
+// POST /api/motivation/decay
 // [神経科学] 刺激がない状態が続くと、ドーパミン受容体の感度低下やトニックドーパミンレベルの自然減少により、
 // 意欲は徐々にベースラインに戻ろうとします。
-// @Summary      Apply Motivation Decay
+// @Summary      Apply Motivation Decay (Deprecated)
 // @Description  時間経過による意欲の自然減衰をシミュレートします。定期的に呼び出されることを想定しています。
-// @Tags         brain
+// @Tags         legacy
 // @Produce      json
+// @Param        X-Brain-ID  header  string  false  "Brain ID (default: default)"
 // @Success      200    {object}  handlers.MotivationResponse
-// @Router       /api/v1/motivation/decay [post]
+// @Deprecated
+// @Security     ApiKeyAuth
+// @Router       /api/motivation/decay [post]
 func (h *MotivationHandler) ApplyDecay(c *gin.Context) {
-	h.basalGanglia.ApplyDecay()
-	motivation := h.basalGanglia.GetMotivation()
+	brain, ok := resolveBrain(c, h.registry)
+	if !ok {
+		return
+	}
+
+	state, err := brain.DecayMotivation()
+	if err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toMotivationResponse(state))
+}
 
-	c.JSON(http.StatusOK, MotivationResponse{
-		Motivation: int(motivation),
-		Level:      h.basalGanglia.GetMotivationLevel(),
-	})
+// toMotivationResponse は意欲の状態をレスポンス形式に変換
+func toMotivationResponse(state core.MotivationState) MotivationResponse {
+	return MotivationResponse{
+		Motivation: state.Motivation,
+		Level:      state.Level,
+	}
 }
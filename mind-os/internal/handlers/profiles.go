@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/personality"
+)
+
+// ListProfiles は読み込み済みのパーソナリティプロファイルを一覧表示
+// GET /api/v1/profiles
+// [神経科学] 扁桃体の反応性やホルモンの基準値、報酬学習の速さといった気質（生まれ持った神経系の個人差）の一覧です。
+// @Summary      List Personality Profiles
+// @Description  脳の作成時に指定できるパーソナリティプロファイル（ホルモン基準値・感受性・学習率・理性・口調）を一覧表示します。
+// @Tags         profiles
+// @Produce      json
+// @Success      200  {object}  models.SuccessResponse
+// @Security     ApiKeyAuth
+// @Router       /api/v1/profiles [get]
+func (h *BrainHandler) ListProfiles(c *gin.Context) {
+	profiles := h.registry.Profiles().List()
+
+	SuccessResponse(c, gin.H{
+		"profiles": profiles,
+		"count":    len(profiles),
+	})
+}
+
+// GetProfile はパーソナリティプロファイルの詳細を取得
+// GET /api/v1/profiles/{name}
+// @Summary      Get Personality Profile
+// @Description  指定した名前のパーソナリティプロファイルを取得します。
+// @Tags         profiles
+// @Produce      json
+// @Param        name  path      string  true  "Profile name"
+// @Success      200   {object}  personality.Profile
+// @Failure      404   {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/profiles/{name} [get]
+func (h *BrainHandler) GetProfile(c *gin.Context) {
+	profile, err := h.registry.Profiles().Get(c.Param("name"))
+	if err != nil {
+		if errors.Is(err, personality.ErrNotFound) {
+			ErrorResponse(c, http.StatusNotFound, "Profile Not Found", err.Error())
+			return
+		}
+		ErrorResponse(c, http.StatusInternalServerError, "Profile Error", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/core"
+)
+
+// SchedulerActivityResponse は自律活動1件のレスポンス
+type SchedulerActivityResponse struct {
+	Time    string `json:"time"`
+	BrainID string `json:"brainId"`
+	Kind    string `json:"kind"` // decay, sleep, wander
+	Detail  string `json:"detail"`
+}
+
+// SchedulerStatusResponse はスケジューラの稼働状況のレスポンス
+type SchedulerStatusResponse struct {
+	Enabled                bool                        `json:"enabled"`
+	Running                bool                        `json:"running"`
+	TickSeconds            int                         `json:"tickSeconds"`
+	DecayIntervalMinutes   int                         `json:"decayIntervalMinutes"`
+	IdleWanderAfterMinutes int                         `json:"idleWanderAfterMinutes"`
+	NightWindow            string                      `json:"nightWindow"` // 睡眠を行う時間帯 (例: 22:00-06:00)
+	StartedAt              string                      `json:"startedAt,omitempty"`
+	LastTickAt             string                      `json:"lastTickAt,omitempty"`
+	Counts                 map[core.ActivityKind]int   `json:"counts"` // 起動以降の活動回数
+	Recent                 []SchedulerActivityResponse `json:"recent"` // 直近の活動 (新しい順)
+}
+
+// SchedulerHandler は自律活動スケジューラのハンドラー
+type SchedulerHandler struct {
+	scheduler *core.Scheduler
+}
+
+// NewSchedulerHandler は新しい SchedulerHandler を作成
+func NewSchedulerHandler(scheduler *core.Scheduler) *SchedulerHandler {
+	return &SchedulerHandler{
+		scheduler: scheduler,
+	}
+}
+
+// GetStatus はスケジューラの稼働状況と直近の自律活動を取得
+// GET /api/v1/scheduler
+// [神経科学] 外部刺激がない間も、意欲・ホルモンの減衰、夜間の睡眠、退屈時のマインドワンダリングが自律的に進みます。
+// @Summary      Get Scheduler Status
+// @Description  自律活動スケジューラの設定・稼働状況と、全ての脳で行われた直近の活動を返します。
+// @Tags         system
+// @Produce      json
+// @Success      200  {object}  handlers.SchedulerStatusResponse
+// @Security     ApiKeyAuth
+// @Router       /api/v1/scheduler [get]
+func (h *SchedulerHandler) GetStatus(c *gin.Context) {
+	status := h.scheduler.Status()
+
+	recent := make([]SchedulerActivityResponse, len(status.Recent))
+	for i, a := range status.Recent {
+		recent[i] = SchedulerActivityResponse{
+			Time:    a.Time.Format(time.RFC3339),
+			BrainID: a.BrainID,
+			Kind:    string(a.Kind),
+			Detail:  a.Detail,
+		}
+	}
+
+	resp := SchedulerStatusResponse{
+		Enabled:                status.Config.Enabled,
+		Running:                status.Running,
+		TickSeconds:            int(status.Config.Tick / time.Second),
+		DecayIntervalMinutes:   int(status.Config.DecayInterval / time.Minute),
+		IdleWanderAfterMinutes: int(status.Config.IdleWanderAfter / time.Minute),
+		NightWindow:            fmt.Sprintf("%02d:00-%02d:00", status.Config.NightStart, status.Config.DayStart),
+		Counts:                 status.Counts,
+		Recent:                 recent,
+	}
+	if !status.StartedAt.IsZero() {
+		resp.StartedAt = status.StartedAt.Format(time.RFC3339)
+	}
+	if !status.LastTick.IsZero() {
+		resp.LastTickAt = status.LastTick.Format(time.RFC3339)
+	}
+
+	SuccessResponse(c, resp)
+}
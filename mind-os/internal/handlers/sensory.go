@@ -12,15 +12,30 @@ import (
 // [神経科学] 視覚や聴覚などの感覚情報を視床(Thalamus)が受け取り、粗いフィルタリングを行った後、
 // 扁桃体(Amygdala)での情動評価と海馬(Hippocampus)での文脈照合を経て、最終的な意識(MindState)を形成します。
 // @Summary      Process Sensory Input
-// @Description  感覚入力を受信し、脳内の感情・意欲・記憶システムを通じて処理し、マインドステートと応答テキストを返します。
+// @Description  感覚入力を受信し、脳内の感情・意欲・記憶システムを通じて処理し、マインドステートと応答テキストを返します。テキストは日本語 (ja) と英語 (en) に対応し、language を省略した場合は文字種から判定します。応答テキストは入力と同じ言語で生成されます。explain=true を指定すると、扁桃体の評価 (トークン・辞書の一致・修飾・感情ごとの寄与)、視床のゲイン、情動伝染、概日リズムの感度、前頭前皮質の調整を debug.explanation に返します。
 // @Tags         brain
 // @Accept       json
 // @Produce      json
-// @Param        input  body      handlers.SensoryRequest  true  "Sensory Input"
+// @Param        X-Brain-ID  header    string                   false  "Brain ID (default: default)"
+// @Param        input       body      handlers.SensoryRequest  true   "Sensory Input"
+// @Param        explain     query     bool                     false  "Return the emotion processing trace in debug.explanation"
 // @Success      200    {object}  models.SuccessResponse{mindState=models.MindStateResponse,debug=models.DebugInfo}
 // @Failure      400    {object}  models.ProblemDetails
+// @Failure      404    {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
 // @Router       /api/v1/sensory-inputs [post]
 func (h *BrainHandler) ProcessSensory(c *gin.Context) {
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
+	var query ExplainQuery
+	if err := BindQueryStrict(c, &query); err != nil {
+		ErrorResponse(c, http.StatusBadRequest, "Invalid Query Parameters", err.Error())
+		return
+	}
+
 	var req SensoryRequest
 	if err := BindStrict(c, &req); err != nil {
 		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", err.Error())
@@ -32,6 +47,8 @@ func (h *BrainHandler) ProcessSensory(c *gin.Context) {
 		Type:        models.SignalType(req.Type),
 		InputText:   req.Text,
 		SignalValue: req.SignalValue,
+		Language:    models.Language(req.Language),
+		Explain:     query.Explain,
 	}
 	// デフォルト値
 	if input.Type == "" {
@@ -39,9 +56,29 @@ func (h *BrainHandler) ProcessSensory(c *gin.Context) {
 	}
 
 	// 脳で処理
-	mindState := h.brain.ProcessInput(input)
+	mindState, err := brain.ProcessInput(input)
+	if err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
 
-	// レスポンス用に変換
+	resp := models.SuccessResponse{
+		MindState: toMindStateResponse(mindState),
+		Reply:     mindState.ReplyText,
+		Debug: &models.DebugInfo{
+			Cortisol:        mindState.Cortisol,
+			Oxytocin:        mindState.Oxytocin,
+			PredictedReward: mindState.PredictedReward,
+			DaydreamLog:     mindState.DaydreamLog,
+			AffectSignals:   mindState.AffectSignals,
+			Explanation:     mindState.Explanation,
+		},
+	}
+	SuccessResponse(c, resp)
+}
+
+// toMindStateResponse は脳の処理結果をレスポンス用に変換 (デバッグ用フィールドは含めない)
+func toMindStateResponse(mindState models.MindStateResponse) *models.MindStateResponse {
 	currentReaction := make([]models.EmotionValue, len(mindState.CurrentReaction))
 	for i, emotion := range mindState.CurrentReaction {
 		currentReaction[i] = models.EmotionValue{
@@ -58,22 +95,17 @@ func (h *BrainHandler) ProcessSensory(c *gin.Context) {
 		}
 	}
 
-	resp := models.SuccessResponse{
-		MindState: &models.MindStateResponse{
-			CurrentReaction: currentReaction,
-			MoodStability:   mindState.MoodStability,
-			PersonalityBias: personalityBias,
-			Motivation:      mindState.Motivation,
-			Sanity:          mindState.Sanity,
-			ReplyText:       mindState.ReplyText,
-		},
-		Reply: mindState.ReplyText,
-		Debug: &models.DebugInfo{
-			Cortisol:        mindState.Cortisol,
-			Oxytocin:        mindState.Oxytocin,
-			PredictedReward: mindState.PredictedReward,
-			DaydreamLog:     mindState.DaydreamLog,
-		},
+	return &models.MindStateResponse{
+		CurrentReaction: currentReaction,
+		MoodStability:   mindState.MoodStability,
+		PersonalityBias: personalityBias,
+		Motivation:      mindState.Motivation,
+		Sanity:          mindState.Sanity,
+		CoreAffect:      mindState.CoreAffect,
+		CoreEmotion:     mindState.CoreEmotion,
+		ReplyText:       mindState.ReplyText,
+		Language:        mindState.Language,
+
+		RecalledMemories: mindState.RecalledMemories,
 	}
-	SuccessResponse(c, resp)
 }
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// stageNames は処理段階の名前を順に返す
+func stageNames(e *models.Explanation) []string {
+	names := make([]string, len(e.Stages))
+	for i, s := range e.Stages {
+		names[i] = s.Stage
+	}
+	return names
+}
+
+func TestProcessSensory_Explain(t *testing.T) {
+	registry := newTestRegistry(t)
+	newTestBrain(t, registry)
+	r := newBrainRouter(NewBrainHandler(registry, nil, nil))
+
+	tests := []struct {
+		name        string
+		query       string
+		body        string
+		wantExplain bool
+		wantStages  []string
+		wantMatch   string // 扁桃体の評価で辞書と一致する語 (空の場合は評価なし)
+	}{
+		{"指定なし", "", `{"type":"chat","text":"とても嬉しい"}`, false, nil, ""},
+		{"explain=false", "?explain=false", `{"type":"chat","text":"とても嬉しい"}`, false, nil, ""},
+		{"会話の入力", "?explain=true", `{"type":"chat","text":"とても嬉しい"}`, true, []string{"amygdala", "empathy", "sensitivity", "pfc"}, "嬉しい"},
+		{"英語の入力", "?explain=true", `{"type":"chat","text":"I am so happy","language":"en"}`, true, []string{"amygdala", "empathy", "sensitivity", "pfc"}, "happy"},
+		{"物理的な刺激", "?explain=true", `{"type":"physical","text":"叩く","signalValue":-80}`, true, []string{"physical", "pfc"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodPost, "/api/v1/sensory-inputs"+tt.query, tt.body, brainHeader...)
+			resp := decodeJSON[models.SuccessResponse](t, w, http.StatusOK)
+			if resp.Debug == nil || resp.MindState == nil {
+				t.Fatalf("response = %s, want mindState and debug", w.Body)
+			}
+			// 根拠はデバッグ情報にのみ含め、マインドステートには含めない
+			if resp.MindState.Explanation != nil {
+				t.Error("mindState.explanation is set, want only debug.explanation")
+			}
+
+			e := resp.Debug.Explanation
+			if !tt.wantExplain {
+				if e != nil {
+					t.Errorf("debug.explanation = %+v, want omitted", e)
+				}
+				return
+			}
+			if e == nil {
+				t.Fatal("debug.explanation is missing")
+			}
+			if got := stageNames(e); !slices.Equal(got, tt.wantStages) {
+				t.Errorf("stages = %v, want %v", got, tt.wantStages)
+			}
+			// 最終的な反応感情は応答のマインドステートと一致する
+			if !slices.Equal(e.Emotions, resp.MindState.CurrentReaction) {
+				t.Errorf("explanation emotions = %v, want currentReaction %v", e.Emotions, resp.MindState.CurrentReaction)
+			}
+			if last := e.Stages[len(e.Stages)-1]; !slices.Equal(last.Emotions, e.Emotions) {
+				t.Errorf("last stage emotions = %v, want %v", last.Emotions, e.Emotions)
+			}
+			if e.ThalamusGain <= 0 || e.Arbitration.Sanity <= 0 {
+				t.Errorf("thalamusGain = %v, arbitration = %+v", e.ThalamusGain, e.Arbitration)
+			}
+
+			if tt.wantMatch == "" {
+				if e.Assessment != nil || e.Empathy != nil || e.Sensitivity != nil {
+					t.Errorf("assessment/empathy/sensitivity = %v/%v/%v, want omitted for physical input", e.Assessment, e.Empathy, e.Sensitivity)
+				}
+				return
+			}
+			if e.Assessment == nil || e.Empathy == nil || e.Sensitivity == nil {
+				t.Fatalf("assessment/empathy/sensitivity = %v/%v/%v, want all set", e.Assessment, e.Empathy, e.Sensitivity)
+			}
+			if !slices.ContainsFunc(e.Assessment.Matches, func(m models.LexiconMatch) bool { return m.Word == tt.wantMatch }) {
+				t.Errorf("assessment matches = %+v, want %s", e.Assessment.Matches, tt.wantMatch)
+			}
+			if len(e.Assessment.Tokens) == 0 || len(e.Assessment.Contributions) == 0 {
+				t.Errorf("assessment = %+v, want tokens and contributions", e.Assessment)
+			}
+		})
+	}
+}
+
+func TestProcessSensory_InvalidExplain(t *testing.T) {
+	registry := newTestRegistry(t)
+	newTestBrain(t, registry)
+	r := newBrainRouter(NewBrainHandler(registry, nil, nil))
+
+	w := serve(r, http.MethodPost, "/api/v1/sensory-inputs?explain=maybe", `{"type":"chat","text":"こんにちは"}`, brainHeader...)
+	if problem := decodeProblem(t, w, http.StatusBadRequest); problem.Title != "Invalid Query Parameters" {
+		t.Errorf("Title = %q, want Invalid Query Parameters", problem.Title)
+	}
+}
+
+func TestAssessEmotion_Explain(t *testing.T) {
+	h := NewEmotionHandler(nil)
+	r := gin.New()
+	r.POST("/api/v1/emotions/assess", h.Assess)
+
+	tests := []struct {
+		name        string
+		query       string
+		wantExplain bool
+		wantStatus  int
+	}{
+		{"指定なし", "", false, http.StatusOK},
+		{"explain=true", "?explain=true", true, http.StatusOK},
+		{"explain=1", "?explain=1", true, http.StatusOK},
+		{"不正な値", "?explain=maybe", false, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodPost, "/api/v1/emotions/assess"+tt.query, `{"text":"全然嬉しくない"}`)
+			if tt.wantStatus != http.StatusOK {
+				decodeProblem(t, w, tt.wantStatus)
+				return
+			}
+			resp := decodeJSON[EmotionResponse](t, w, http.StatusOK)
+			if got := resp.Explanation != nil; got != tt.wantExplain {
+				t.Fatalf("explanation present = %v, want %v", got, tt.wantExplain)
+			}
+			if !tt.wantExplain {
+				return
+			}
+			// 根拠の評価結果は応答の感情と一致し、否定による修飾を記録する
+			if !slices.Equal(resp.Explanation.Emotions, resp.Emotions) {
+				t.Errorf("explanation emotions = %v, want %v", resp.Explanation.Emotions, resp.Emotions)
+			}
+			i := slices.IndexFunc(resp.Explanation.Matches, func(m models.LexiconMatch) bool { return m.Word == "嬉しい" })
+			if i < 0 || resp.Explanation.Matches[i].Negations == 0 {
+				t.Errorf("matches = %+v, want 嬉しい with negation", resp.Explanation.Matches)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -15,16 +14,30 @@ import (
 // @Description  睡眠サイクルを実行し、記憶の定着（Consolidation）と神経伝達物質の回復を行います。
 // @Tags         brain
 // @Produce      json
+// @Param        X-Brain-ID  header  string  false  "Brain ID (default: default)"
 // @Success      200  {object}  models.SuccessResponse
+// @Failure      404  {object}  models.ProblemDetails
 // @Failure      500  {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
 // @Router       /api/v1/sleep-cycles [post]
 func (h *BrainHandler) Sleep(c *gin.Context) {
-	result := h.brain.Sleep()
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
+	result, err := brain.Sleep()
+	if err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
 
 	SuccessResponse(c, gin.H{
 		"message":           "Sleep consolidation completed",
 		"consolidatedCount": result.ConsolidatedCount,
 		"forgottenCount":    result.ForgottenCount,
+		"fadedCount":        result.FadedCount,
+		"removedCount":      result.RemovedCount,
 		"stmCount":          result.STMCount,
 		"ltmCount":          result.LTMCount,
 	})
@@ -39,19 +52,30 @@ func (h *BrainHandler) Sleep(c *gin.Context) {
 // @Tags         brain
 // @Accept       json
 // @Produce      json
-// @Param        input  body      handlers.RestRequest  true  "Rest Parameters"
+// @Param        X-Brain-ID  header    string                false  "Brain ID (default: default)"
+// @Param        input       body      handlers.RestRequest  true   "Rest Parameters"
 // @Success      200    {object}  models.SuccessResponse
 // @Failure      400    {object}  models.ProblemDetails
+// @Failure      404    {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
 // @Router       /api/v1/rest [post]
 func (h *BrainHandler) Rest(c *gin.Context) {
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
 	var req RestRequest
 	if err := BindStrict(c, &req); err != nil {
 		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", err.Error())
 		return
 	}
 
-	h.brain.Rest(req.Quality)
-	state := h.brain.GetState()
+	if err := brain.Rest(req.Quality); err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
+	state := brain.GetState()
 
 	SuccessResponse(c, gin.H{
 		"message":     "Rest applied",
@@ -60,27 +84,3 @@ func (h *BrainHandler) Rest(c *gin.Context) {
 		"sanityLevel": state.SanityLevel,
 	})
 }
-
-// Daydream は白昼夢処理を実行（新規追加）
-// POST /api/v1/daydreams
-// [神経科学] デフォルトモードネットワーク(DMN)の活性化により、過去の記憶や未来のシミュレーションをランダムに想起します。
-// 創造性の向上や、記憶の再構築による新たな洞察の獲得プロセスをモデル化しています。
-// @Summary      Daydream
-// @Description  DMNを活性化し、白昼夢（Daydreaming）処理を行います。創造性や洞察の獲得を促します。
-// @Tags         brain
-// @Produce      json
-// @Success      200  {object}  models.SuccessResponse
-// @Router       /api/v1/daydreams [post]
-func (h *BrainHandler) Daydream(c *gin.Context) {
-	// 白昼夢処理の実装 (internal/core/brain.go に未実装の場合はスタブ)
-	// 現状の brain.go には Daydream メソッドが見当たらないため、
-	// ここでは単純なレスポンス、または拡張が必要。
-	// Task: "Check implementation" -> assuming simple log or stub for now if core support is missing.
-
-	slog.Info("Daydreaming triggered")
-	// TODO: core.Brain に Daydream メソッドを追加する
-
-	SuccessResponse(c, gin.H{
-		"message": "Daydreaming... (Stub)",
-	})
-}
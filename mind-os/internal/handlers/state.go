@@ -14,24 +14,35 @@ import (
 // [神経科学] このエンドポイントは、前頭前野(PFC)が監視する現在の脳の全体状態をスナップショットとして提供します。
 // 意欲(線条体)、理性(PFC)、記憶負荷(海馬)の統合的なステータスを示し、ホメオスタシスの維持状況を確認できます。
 // @Summary      Get Current Brain State
-// @Description  現在の脳の状態（意欲、理性、記憶負荷など）を取得します。ETagによるキャッシュ制御をサポートしています。
+// @Description  現在の脳の状態（パーソナリティプロファイル、意欲、理性、記憶負荷、コアアフェクトなど）を取得します。ETagによるキャッシュ制御をサポートしています。
 // @Tags         brain
 // @Produce      json
+// @Param        X-Brain-ID  header  string  false  "Brain ID (default: default)"
 // @Success      200  {object}  models.SuccessResponse
 // @Success      304  {string}  string "Not Modified"
+// @Failure      404  {object}  models.ProblemDetails
 // @Header       200,304  {string}  ETag  "State Hash"
+// @Security     ApiKeyAuth
 // @Router       /api/v1/brain-states/current [get]
 func (h *BrainHandler) GetState(c *gin.Context) {
-	state := h.brain.GetState()
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
+	state := brain.GetState()
 
 	// レスポンスデータの構築
 	respData := gin.H{
+		"brainId":         brain.ID,
+		"profile":         state.Profile,
 		"motivation":      state.Motivation,
 		"motivationLevel": state.MotivationLevel,
 		"sanity":          state.Sanity,
 		"sanityLevel":     state.SanityLevel,
 		"stmCount":        state.STMCount,
 		"ltmCount":        state.LTMCount,
+		"coreAffect":      state.CoreAffect,
 	}
 
 	// ETag生成
@@ -58,19 +69,30 @@ func (h *BrainHandler) GetState(c *gin.Context) {
 // @Tags         brain
 // @Accept       json
 // @Produce      json
-// @Param        input  body      handlers.StressRequest  true  "Stress Level"
+// @Param        X-Brain-ID  header    string                  false  "Brain ID (default: default)"
+// @Param        input       body      handlers.StressRequest  true   "Stress Level"
 // @Success      200    {object}  models.SuccessResponse
 // @Failure      400    {object}  models.ProblemDetails
+// @Failure      404    {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
 // @Router       /api/v1/stress [post]
 func (h *BrainHandler) ApplyStress(c *gin.Context) {
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
 	var req StressRequest
 	if err := BindStrict(c, &req); err != nil {
 		ErrorResponse(c, http.StatusBadRequest, "Invalid Request Body", err.Error())
 		return
 	}
 
-	h.brain.ApplyStress(req.Level)
-	state := h.brain.GetState()
+	if err := brain.ApplyStress(req.Level); err != nil {
+		registryErrorResponse(c, err)
+		return
+	}
+	state := brain.GetState()
 
 	SuccessResponse(c, gin.H{
 		"message":     "Stress applied",
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/core"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+const (
+	// streamHeartbeatInterval はイベントがない間に送るキープアライブの間隔
+	// (プロキシのアイドルタイムアウトによる切断を防ぐ)
+	streamHeartbeatInterval = 15 * time.Second
+
+	// streamWriteTimeout は1回の書き込みの期限
+	// (サーバー全体の WriteTimeout はストリームの間だけ書き込みごとに延長する)
+	streamWriteTimeout = 10 * time.Second
+)
+
+// BrainStateEventResponse は状態変化イベントのレスポンス
+type BrainStateEventResponse struct {
+	ID      uint64             `json:"id"`
+	Type    string             `json:"type"` // sensory_input, sleep, stress, rest, feedback, motivation, decay, daydream, memory (接続直後は snapshot)
+	BrainID string             `json:"brainId"`
+	Time    string             `json:"time"`
+	State   BrainStateResponse `json:"state"`
+
+	Thoughts []string `json:"thoughts,omitempty"` // daydream で回想した記憶の要約
+}
+
+// BrainStateResponse は脳の状態のレスポンス
+type BrainStateResponse struct {
+	Profile         string        `json:"profile"`
+	Motivation      int           `json:"motivation"`
+	MotivationLevel string        `json:"motivationLevel"`
+	Sanity          int           `json:"sanity"`
+	SanityLevel     string        `json:"sanityLevel"`
+	STMCount        int           `json:"stmCount"`
+	LTMCount        int           `json:"ltmCount"`
+	Cortisol        float64       `json:"cortisol"`
+	Oxytocin        float64       `json:"oxytocin"`
+	CoreAffect      models.Affect `json:"coreAffect"` // コアアフェクト (VAD)
+}
+
+// StreamState は脳の状態変化を Server-Sent Events で配信
+// GET /api/v1/brain-states/stream
+// [神経科学] 神経調節物質(ドーパミン・コルチゾール・オキシトシン)の変化や記憶の固定化を、ポーリングではなく発生した時点で観測します。
+// 接続直後に現在の状態を `snapshot` イベントとして送り、以降は状態が変化するたびにその原因をイベント名として送ります。
+// 処理が追いつかずバッファが溢れた購読者は `dropped` イベントを送って切断されます (再接続して snapshot から再開してください)。
+// @Summary      Stream Brain State Changes
+// @Description  入力処理・睡眠・ストレス・休息・フィードバック・減衰・マインドワンダリング・記憶操作による状態変化を SSE で配信します。
+// @Tags         brain
+// @Produce      text/event-stream
+// @Param        X-Brain-ID  header  string  false  "Brain ID (default: default)"
+// @Success      200  {object}  handlers.BrainStateEventResponse
+// @Failure      404  {object}  models.ProblemDetails
+// @Security     ApiKeyAuth
+// @Router       /api/v1/brain-states/stream [get]
+func (h *BrainHandler) StreamState(c *gin.Context) {
+	brain, ok := h.resolveBrain(c)
+	if !ok {
+		return
+	}
+
+	// 初期状態より後の変化を取りこぼさないよう、状態の取得より先に購読する
+	sub := brain.Events.Subscribe()
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // nginx 等のバッファリングを無効化
+
+	rc := http.NewResponseController(c.Writer)
+	write := func(render func()) bool {
+		if err := rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil {
+			slog.Debug("Failed to extend write deadline", "error", err)
+		}
+		render()
+		c.Writer.Flush()
+		return c.Request.Context().Err() == nil
+	}
+
+	slog.Info("State stream opened", "brain_id", brain.ID)
+	defer slog.Info("State stream closed", "brain_id", brain.ID)
+
+	snapshot := core.BrainEvent{Type: "snapshot", BrainID: brain.ID, Time: time.Now(), State: brain.GetState()}
+	if !write(func() { renderBrainEvent(c, snapshot) }) {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-h.streamsClosed:
+			return
+		case <-heartbeat.C:
+			if !write(func() { _, _ = c.Writer.WriteString(": keepalive\n\n") }) {
+				return
+			}
+		case event, open := <-sub.Events():
+			if !open {
+				if sub.Dropped() {
+					write(func() { c.Render(-1, sse.Event{Event: "dropped", Data: gin.H{"brainId": brain.ID}}) })
+				}
+				return
+			}
+			if !write(func() { renderBrainEvent(c, event) }) {
+				return
+			}
+		}
+	}
+}
+
+// CloseStreams は全ての SSE ストリームと会話 (WebSocket) を終了させる
+// http.Server.Shutdown は SSE の完了を待ち続け、ハイジャックされた WebSocket 接続は閉じないため、RegisterOnShutdown で登録して使う
+func (h *BrainHandler) CloseStreams() {
+	h.closeStreams.Do(func() { close(h.streamsClosed) })
+}
+
+// renderBrainEvent は状態変化イベントを SSE 形式で書き込む
+// 接続直後の snapshot は通し番号を持たないため id を付けない
+func renderBrainEvent(c *gin.Context, e core.BrainEvent) {
+	event := sse.Event{
+		Event: string(e.Type),
+		Data:  toBrainStateEventResponse(e),
+	}
+	if e.ID > 0 {
+		event.Id = strconv.FormatUint(e.ID, 10)
+	}
+	c.Render(-1, event)
+}
+
+// toBrainStateEventResponse は core.BrainEvent をレスポンス形式に変換
+func toBrainStateEventResponse(e core.BrainEvent) BrainStateEventResponse {
+	return BrainStateEventResponse{
+		ID:       e.ID,
+		Type:     string(e.Type),
+		BrainID:  e.BrainID,
+		Time:     e.Time.Format(time.RFC3339Nano),
+		State:    toBrainStateResponse(e.State),
+		Thoughts: e.Thoughts,
+	}
+}
+
+// toBrainStateResponse は core.BrainState をレスポンス形式に変換
+func toBrainStateResponse(s core.BrainState) BrainStateResponse {
+	return BrainStateResponse{
+		Profile:         s.Profile,
+		Motivation:      s.Motivation,
+		MotivationLevel: s.MotivationLevel,
+		Sanity:          s.Sanity,
+		SanityLevel:     s.SanityLevel,
+		STMCount:        s.STMCount,
+		LTMCount:        s.LTMCount,
+		Cortisol:        s.Cortisol,
+		Oxytocin:        s.Oxytocin,
+		CoreAffect:      s.CoreAffect,
+	}
+}
@@ -48,9 +48,14 @@ func BindStrict(c *gin.Context, obj interface{}) error {
 	// 1. リクエストボディの読み込み
 	// Bodyは一度読むと消えるため、必要に応じてバッファリングするが、
 	// ここでは Decode して終わるので直接読み込む
+	return DecodeStrict(c.Request.Body, obj)
+}
 
+// DecodeStrict は JSON をパースし、未知のフィールドの拒否とバリデーションを行う
+// (WebSocket のメッセージなど、リクエストボディ以外の入力用)
+func DecodeStrict(r io.Reader, obj interface{}) error {
 	// JSONデコーダーの設定
-	decoder := json.NewDecoder(c.Request.Body)
+	decoder := json.NewDecoder(r)
 	decoder.DisallowUnknownFields() // 未知のフィールドを許可しない
 
 	// デコード実行
@@ -68,3 +73,17 @@ func BindStrict(c *gin.Context, obj interface{}) error {
 
 	return nil
 }
+
+// BindQueryStrict はクエリパラメータを構造体にバインドし、バリデーションを行う
+// フィールドは `form` タグでクエリパラメータ名を指定する
+func BindQueryStrict(c *gin.Context, obj interface{}) error {
+	if err := c.ShouldBindQuery(obj); err != nil {
+		return fmt.Errorf("query parse error: %w", err)
+	}
+
+	if err := GlobalValidator.ValidateStruct(obj); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+
+	return nil
+}
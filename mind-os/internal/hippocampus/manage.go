@@ -0,0 +1,118 @@
+// manage.go: 外部からの記憶の閲覧・編集・削除 (STMとLTMを横断して扱う)
+package hippocampus
+
+import (
+	"slices"
+
+	"github.com/umekku/mind-os/internal/models"
+	"github.com/umekku/mind-os/internal/store"
+)
+
+// ListMemories はSTMとLTMを横断して記憶の一覧を取得する
+// 【処理内容】
+// 同じ条件・並び順でSTMとLTMからそれぞれ最大 limit+1 件を取り出してマージし、
+// 先頭 limit 件と、続きがある場合は次ページのカーソルを返す
+func (h *Hippocampus) ListMemories(q store.MemoryListQuery) ([]models.RuneMemory, *store.MemoryCursor, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = store.DefaultSearchLimit
+	}
+
+	memories := make([]models.RuneMemory, 0, limit+1)
+	for _, m := range h.STM {
+		if q.Matches(m) {
+			memories = append(memories, m)
+		}
+	}
+
+	if h.store != nil && q.Type != models.MemorySTM {
+		ltmQuery := q
+		ltmQuery.Limit = limit + 1
+		ltm, err := h.store.ListMemories(ltmQuery)
+		if err != nil {
+			return nil, nil, err
+		}
+		memories = append(memories, ltm...)
+	}
+
+	slices.SortStableFunc(memories, func(a, b models.RuneMemory) int {
+		if q.Less(a, b) {
+			return -1
+		}
+		if q.Less(b, a) {
+			return 1
+		}
+		return 0
+	})
+
+	if len(memories) <= limit {
+		return memories, nil, nil
+	}
+
+	memories = memories[:limit]
+	next := q.CursorFor(memories[limit-1])
+	return memories, &next, nil
+}
+
+// UpdateMemory は記憶を更新する (STMはメモリ上、LTMはDB)
+// 記憶が存在しない場合は store.ErrMemoryNotFound を返す
+func (h *Hippocampus) UpdateMemory(memory models.RuneMemory) error {
+	if h.updateSTMEntry(memory) {
+		return nil
+	}
+	if h.store == nil {
+		return store.ErrMemoryNotFound
+	}
+	return h.store.UpdateMemory(memory)
+}
+
+// ForgetMemory は要求に応じて記憶を忘却（削除）する
+// 記憶が存在しない場合は store.ErrMemoryNotFound を返す
+func (h *Hippocampus) ForgetMemory(uuid string) error {
+	for i := range h.STM {
+		if h.STM[i].UUID == uuid {
+			h.STM = slices.Delete(h.STM, i, i+1)
+			return nil
+		}
+	}
+	if h.store == nil {
+		return store.ErrMemoryNotFound
+	}
+	return h.store.DeleteMemory(uuid)
+}
+
+// ForgetMemories は複数の記憶を忘却し、削除した件数を返す
+// 存在しないUUIDは無視される
+func (h *Hippocampus) ForgetMemories(uuids []string) (int, error) {
+	targets := make(map[string]bool, len(uuids))
+	for _, uuid := range uuids {
+		targets[uuid] = true
+	}
+
+	stmBefore := len(h.STM)
+	h.STM = slices.DeleteFunc(h.STM, func(m models.RuneMemory) bool {
+		if targets[m.UUID] {
+			delete(targets, m.UUID)
+			return true
+		}
+		return false
+	})
+	forgotten := stmBefore - len(h.STM)
+
+	if h.store == nil || len(targets) == 0 {
+		return forgotten, nil
+	}
+
+	remaining := make([]string, 0, len(targets))
+	for _, uuid := range uuids {
+		if targets[uuid] {
+			remaining = append(remaining, uuid)
+			delete(targets, uuid)
+		}
+	}
+	deleted, err := h.store.DeleteMemories(remaining)
+	if err != nil {
+		return forgotten, err
+	}
+	return forgotten + deleted, nil
+}
@@ -3,9 +3,11 @@ package hippocampus
 
 import (
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/umekku/mind-os/internal/language"
 	"github.com/umekku/mind-os/internal/models"
 	"github.com/umekku/mind-os/internal/store"
 )
@@ -21,21 +23,73 @@ type Hippocampus struct {
 	consolidationThreshold float64 // LTMへの移行閾値
 	maxSTMSize             int     // STMの最大サイズ
 	maxLTMSize             int     // LTMの最大サイズ
+
+	forgetting store.ForgettingCurve // 忘却曲線のパラメータ
+}
+
+// Option は Hippocampus の設定を変更する関数
+type Option func(*Hippocampus)
+
+// WithForgettingCurve は忘却曲線のパラメータを設定する
+func WithForgettingCurve(curve store.ForgettingCurve) Option {
+	return func(h *Hippocampus) {
+		h.forgetting = curve
+	}
+}
+
+// WithSTMMaxSize は短期記憶の最大件数を設定する (超えると古い記憶から失われる)
+func WithSTMMaxSize(n int) Option {
+	return func(h *Hippocampus) {
+		h.maxSTMSize = n
+	}
+}
+
+// WithLTMMaxSize は長期記憶の最大件数を設定する (睡眠時に超過分を古い順に削除する)
+func WithLTMMaxSize(n int) Option {
+	return func(h *Hippocampus) {
+		h.maxLTMSize = n
+	}
+}
+
+// WithConsolidationThreshold は長期記憶へ固定化する重みの閾値 (0-1) を設定する
+func WithConsolidationThreshold(threshold float64) Option {
+	return func(h *Hippocampus) {
+		h.consolidationThreshold = threshold
+	}
 }
 
 // New は新しい Hippocampus インスタンスを作成
-func New(db *store.DB) *Hippocampus {
-	return &Hippocampus{
+func New(db *store.DB, opts ...Option) *Hippocampus {
+	h := &Hippocampus{
 		STM:                    make([]models.RuneMemory, 0),
 		store:                  db,
 		consolidationThreshold: 0.6,  // 重み0.6以上でLTMへ移行
 		maxSTMSize:             100,  // STM最大100件
 		maxLTMSize:             1000, // LTM最大1000件
+		forgetting:             store.DefaultForgettingCurve(),
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ConsolidationReport は睡眠による記憶整理の結果
+type ConsolidationReport struct {
+	Consolidated int // STMからLTMへ固定化された記憶数
+	Forgotten    int // 固定化されずに忘却されたSTMの記憶数
+	Faded        int // 忘却曲線により重みが減衰したLTMの記憶数
+	Removed      int // 重みが下限を下回り削除されたLTMの記憶数
+}
+
+// AddEpisode は新しいエピソード記憶をSTMに追加 (記憶内容の言語はテキストから判定)
+// concepts はウェルニッケ野が抽出した概念で、連想想起の手がかりとしてタグに付与される
+func (h *Hippocampus) AddEpisode(text string, emotions []models.EmotionValue, concepts ...string) {
+	h.AddEpisodeIn(language.Detect(text), text, emotions, concepts...)
 }
 
-// AddEpisode は新しいエピソード記憶をSTMに追加
-func (h *Hippocampus) AddEpisode(text string, emotions []models.EmotionValue) {
+// AddEpisodeIn は言語 lang のエピソード記憶をSTMに追加
+func (h *Hippocampus) AddEpisodeIn(lang models.Language, text string, emotions []models.EmotionValue, concepts ...string) {
 	now := time.Now()
 
 	// 感情の強度から重みを計算 (0.0-1.0)
@@ -50,7 +104,8 @@ func (h *Hippocampus) AddEpisode(text string, emotions []models.EmotionValue) {
 		Type:       models.MemorySTM,
 		CreatedAt:  now,
 		LastAccess: now,
-		Tags:       h.extractTags(text, emotions),
+		Tags:       appendUnique(h.extractTags(text, emotions), concepts...),
+		Language:   lang,
 	}
 
 	// STMに追加
@@ -78,6 +133,7 @@ func (h *Hippocampus) AddMemory(text string, emotions []models.EmotionValue) mod
 		CreatedAt:  now,
 		LastAccess: now,
 		Tags:       h.extractTags(text, emotions),
+		Language:   language.Detect(text),
 	}
 
 	h.STM = append(h.STM, memory)
@@ -123,12 +179,18 @@ func (h *Hippocampus) GetRecentContext() []models.RuneMemory {
 	return allMemories[:limit]
 }
 
-// SleepAndConsolidate は睡眠処理 - STMからLTMへの記憶の固定化
-func (h *Hippocampus) SleepAndConsolidate() {
+// SleepAndConsolidate は睡眠処理 - STMからLTMへの記憶の固定化と忘却
+func (h *Hippocampus) SleepAndConsolidate() ConsolidationReport {
+	var report ConsolidationReport
 	if h.store == nil {
-		return
+		return report
 	}
 
+	// 既存のLTMを先に風化させる (今回固定化する記憶は減衰させない)
+	faded := h.FadeOldMemories()
+	report.Faded = faded.Faded
+	report.Removed = faded.Removed
+
 	// STMの記憶を重みでフィルタリング
 	for _, memory := range h.STM {
 		if memory.Weight >= h.consolidationThreshold {
@@ -140,27 +202,45 @@ func (h *Hippocampus) SleepAndConsolidate() {
 			// DBに保存
 			if err := h.store.SaveMemory(ltmMemory); err != nil {
 				slog.Error("Failed to save LTM", "error", err)
+				report.Forgotten++
+				continue
 			}
+			report.Consolidated++
+			continue
 		}
-		// 閾値未満の記憶は忘却（何もしない）
+		// 閾値未満の記憶は忘却
+		report.Forgotten++
 	}
 
 	// STMをクリア
 	h.STM = make([]models.RuneMemory, 0)
 
-	// 古い記憶の風化処理
-	h.FadeOldMemories()
-
 	// LTMサイズ制限チェック（古い記憶の削除）
 	if err := h.store.DeleteOldMemories(h.maxLTMSize); err != nil {
 		slog.Warn("Failed to prune old LTM", "error", err)
 	}
+
+	return report
 }
 
-// FadeOldMemories は古い記憶の重みを減衰させる
-func (h *Hippocampus) FadeOldMemories() {
-	// DB側の処理が必要だが、ここでは簡易的実装
-	// 実際にはDB内の全レコードの重みを下げるクエリなどを発行
+// FadeOldMemories は忘却曲線に従って長期記憶の重みを減衰させる
+// 【脳科学的意味】
+// エビングハウスの忘却曲線 R = exp(-t/S) に従い、想起されない記憶は時間とともに薄れる。
+// 何度も想起された記憶や感情的に強い記憶ほど安定度 S が大きく、忘れにくい。
+// 重みが下限を下回った記憶はシナプスの刈り込みとして削除される。
+func (h *Hippocampus) FadeOldMemories() store.FadeResult {
+	if h.store == nil {
+		return store.FadeResult{}
+	}
+
+	result, err := h.store.FadeMemories(h.forgetting, time.Now())
+	if err != nil {
+		slog.Warn("Failed to fade old memories", "error", err)
+		return store.FadeResult{}
+	}
+
+	slog.Debug("Memories faded", "faded", result.Faded, "removed", result.Removed)
+	return result
 }
 
 // calculateWeight は感情の強度から記憶の重みを計算
@@ -214,11 +294,45 @@ func (h *Hippocampus) extractTags(text string, emotions []models.EmotionValue) [
 	return tags
 }
 
+// appendUnique は重複しない値のみをスライスに追加
+func appendUnique(tags []string, values ...string) []string {
+	for _, v := range values {
+		if v == "" || slices.Contains(tags, v) {
+			continue
+		}
+		tags = append(tags, v)
+	}
+	return tags
+}
+
 // GetSTMCount はSTMの記憶数を返す
 func (h *Hippocampus) GetSTMCount() int {
 	return len(h.STM)
 }
 
+// SnapshotSTM はSTMのコピーを返す（永続化用）
+func (h *Hippocampus) SnapshotSTM() []models.RuneMemory {
+	stm := make([]models.RuneMemory, len(h.STM))
+	copy(stm, h.STM)
+	return stm
+}
+
+// RestoreSTM は永続化されたSTMを復元する
+// 最大サイズを超える場合は新しい記憶を優先して保持する
+func (h *Hippocampus) RestoreSTM(memories []models.RuneMemory) {
+	if len(memories) > h.maxSTMSize {
+		memories = memories[len(memories)-h.maxSTMSize:]
+	}
+	h.STM = make([]models.RuneMemory, len(memories))
+	copy(h.STM, memories)
+	for i := range h.STM {
+		// 言語を記録する前に保存された記憶は日本語
+		if h.STM[i].Language == "" {
+			h.STM[i].Language = models.DefaultLanguage
+		}
+	}
+}
+
 // GetLTMCount はLTMの記憶数を返す
 func (h *Hippocampus) GetLTMCount() int {
 	if h.store == nil {
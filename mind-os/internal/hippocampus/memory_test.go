@@ -1,6 +1,7 @@
 package hippocampus
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -54,6 +55,26 @@ func TestNew(t *testing.T) {
 	}
 }
 
+// TestNew_WithOptions は設定による記憶の閾値・件数の変更をテスト
+func TestNew_WithOptions(t *testing.T) {
+	h := New(nil,
+		WithSTMMaxSize(2),
+		WithLTMMaxSize(50),
+		WithConsolidationThreshold(0.9),
+	)
+
+	if h.maxSTMSize != 2 || h.maxLTMSize != 50 || h.consolidationThreshold != 0.9 {
+		t.Errorf("options not applied: STM=%d LTM=%d threshold=%v", h.maxSTMSize, h.maxLTMSize, h.consolidationThreshold)
+	}
+
+	for _, text := range []string{"一", "二", "三"} {
+		h.AddEpisode(text, nil)
+	}
+	if len(h.STM) != 2 || h.STM[0].Text != "二" {
+		t.Errorf("STM = %d memories (oldest %q), want 2 (oldest \"二\")", len(h.STM), h.STM[0].Text)
+	}
+}
+
 // TestAddEpisode はエピソード追加をテスト
 func TestAddEpisode(t *testing.T) {
 	h, cleanup := setupTest(t)
@@ -230,7 +251,10 @@ func TestSleepAndConsolidate_AllForgotten(t *testing.T) {
 		h.AddEpisode("低重み記憶", emotions)
 	}
 
-	h.SleepAndConsolidate()
+	report := h.SleepAndConsolidate()
+	if report.Consolidated != 0 || report.Forgotten != 5 {
+		t.Errorf("Report = %+v, want Consolidated=0 Forgotten=5", report)
+	}
 
 	if len(h.STM) != 0 {
 		t.Errorf("STM count = %d, want 0", len(h.STM))
@@ -339,6 +363,30 @@ func TestExtractTags(t *testing.T) {
 	}
 }
 
+// TestAddEpisode_Language は記憶内容の言語の記録をテスト
+func TestAddEpisode_Language(t *testing.T) {
+	h, cleanup := setupTest(t)
+	defer cleanup()
+
+	joy := []models.EmotionValue{{Code: models.EmotionJoy, Value: 70}}
+	h.AddEpisode("楽しかった", joy)
+	h.AddEpisode("I had fun", joy)
+	h.AddEpisodeIn(models.LanguageEnglish, "OK", joy)
+
+	want := []models.Language{models.LanguageJapanese, models.LanguageEnglish, models.LanguageEnglish}
+	for i, lang := range want {
+		if h.STM[i].Language != lang {
+			t.Errorf("STM[%d].Language = %q, want %q", i, h.STM[i].Language, lang)
+		}
+	}
+
+	// 言語を記録する前に保存された記憶は日本語として復元する
+	h.RestoreSTM([]models.RuneMemory{{UUID: "legacy", Text: "古い記憶"}})
+	if h.STM[0].Language != models.LanguageJapanese {
+		t.Errorf("restored Language = %q, want ja", h.STM[0].Language)
+	}
+}
+
 // TestGetSTMCount はSTMカウント取得をテスト
 func TestGetSTMCount(t *testing.T) {
 	h, cleanup := setupTest(t)
@@ -403,3 +451,169 @@ func TestGetMemoryByUUID(t *testing.T) {
 		t.Error("Should return nil for non-existent UUID")
 	}
 }
+
+// TestReconsolidateMemory は再固定化の書き戻しをテスト
+func TestReconsolidateMemory(t *testing.T) {
+	h, cleanup := setupTest(t)
+	defer cleanup()
+
+	current := []models.EmotionValue{{Code: models.EmotionJoy, Value: 0}}
+
+	t.Run("STM", func(t *testing.T) {
+		h.AddEpisode("短期の記憶", []models.EmotionValue{
+			{Code: models.EmotionJoy, Value: 50},
+		})
+		memory := h.STM[0]
+
+		h.ReconsolidateMemory(&memory, current)
+
+		if h.STM[0].RecallCount != 1 {
+			t.Errorf("STM RecallCount = %d, want 1", h.STM[0].RecallCount)
+		}
+		if h.STM[0].Emotions[0].Value != 45 {
+			t.Errorf("STM Joy = %d, want 45", h.STM[0].Emotions[0].Value)
+		}
+	})
+
+	t.Run("LTM", func(t *testing.T) {
+		h.STM = nil
+		h.AddEpisode("大切な記憶", []models.EmotionValue{
+			{Code: models.EmotionJoy, Value: 90},
+		})
+		uuid := h.STM[0].UUID
+		h.SleepAndConsolidate()
+
+		memory := h.GetMemoryByUUID(uuid)
+		if memory == nil {
+			t.Fatal("Consolidated memory not found")
+		}
+		before := memory.Weight
+
+		h.ReconsolidateMemory(memory, current)
+		h.ReconsolidateMemory(memory, current)
+
+		stored := h.GetMemoryByUUID(uuid)
+		if stored.RecallCount != 2 {
+			t.Errorf("LTM RecallCount = %d, want 2", stored.RecallCount)
+		}
+		if stored.Weight < before {
+			t.Errorf("LTM Weight = %v, want >= %v", stored.Weight, before)
+		}
+		if stored.Emotions[0].Value >= 90 {
+			t.Errorf("LTM Joy = %d, want blended below 90", stored.Emotions[0].Value)
+		}
+	})
+}
+
+// TestSleepAndConsolidate_Forgetting は睡眠時の忘却曲線の適用をテスト
+func TestSleepAndConsolidate_Forgetting(t *testing.T) {
+	h, cleanup := setupTest(t)
+	defer cleanup()
+
+	// 1週間アクセスされていない感情の薄い長期記憶
+	weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+	if err := h.store.SaveMemory(models.RuneMemory{
+		UUID:       "old-memory",
+		Text:       "古い記憶",
+		Weight:     0.7,
+		Type:       models.MemoryLTM,
+		CreatedAt:  weekAgo,
+		LastAccess: weekAgo,
+	}); err != nil {
+		t.Fatalf("SaveMemory failed: %v", err)
+	}
+
+	h.AddEpisode("今日の出来事", []models.EmotionValue{
+		{Code: models.EmotionJoy, Value: 90},
+	})
+
+	report := h.SleepAndConsolidate()
+
+	if report.Consolidated != 1 {
+		t.Errorf("Consolidated = %d, want 1", report.Consolidated)
+	}
+	if report.Removed != 1 {
+		t.Errorf("Removed = %d, want 1", report.Removed)
+	}
+	if h.GetMemoryByUUID("old-memory") != nil {
+		t.Error("Old memory should be forgotten")
+	}
+}
+
+// TestListMemories はSTMとLTMを横断した一覧取得をテスト
+func TestListMemories(t *testing.T) {
+	h, cleanup := setupTest(t)
+	defer cleanup()
+
+	joy := []models.EmotionValue{{Code: models.EmotionJoy, Value: 90}}
+	h.AddEpisode("固定化される記憶1", joy)
+	h.AddEpisode("固定化される記憶2", joy)
+	h.SleepAndConsolidate()
+	h.AddEpisode("短期の記憶", joy)
+
+	all, next, err := h.ListMemories(store.MemoryListQuery{})
+	if err != nil {
+		t.Fatalf("ListMemories failed: %v", err)
+	}
+	if len(all) != 3 || next != nil {
+		t.Fatalf("ListMemories returned %d memories (next %v), want 3 and no cursor", len(all), next)
+	}
+	if all[0].Type != models.MemorySTM {
+		t.Errorf("First memory type = %v, want newest STM", all[0].Type)
+	}
+
+	// ページングでSTMとLTMが重複・欠落なく辿れること
+	q := store.MemoryListQuery{Limit: 2}
+	page, next, err := h.ListMemories(q)
+	if err != nil || len(page) != 2 || next == nil {
+		t.Fatalf("First page = %d memories, next %v, err %v", len(page), next, err)
+	}
+	q.After = next
+	rest, next, err := h.ListMemories(q)
+	if err != nil || len(rest) != 1 || next != nil {
+		t.Fatalf("Second page = %d memories, next %v, err %v", len(rest), next, err)
+	}
+	if rest[0].UUID != all[2].UUID {
+		t.Errorf("Second page = %v, want %v", rest[0].UUID, all[2].UUID)
+	}
+
+	stmOnly, _, _ := h.ListMemories(store.MemoryListQuery{Type: models.MemorySTM})
+	if len(stmOnly) != 1 {
+		t.Errorf("STM only = %d memories, want 1", len(stmOnly))
+	}
+}
+
+// TestForgetMemory は要求による忘却をテスト
+func TestForgetMemory(t *testing.T) {
+	h, cleanup := setupTest(t)
+	defer cleanup()
+
+	joy := []models.EmotionValue{{Code: models.EmotionJoy, Value: 90}}
+	h.AddEpisode("固定化される記憶", joy)
+	h.SleepAndConsolidate()
+	h.AddEpisode("短期の記憶1", joy)
+	h.AddEpisode("短期の記憶2", joy)
+
+	ltm, _, _ := h.ListMemories(store.MemoryListQuery{Type: models.MemoryLTM})
+	if len(ltm) != 1 {
+		t.Fatalf("LTM count = %d, want 1", len(ltm))
+	}
+
+	if err := h.ForgetMemory(h.STM[0].UUID); err != nil {
+		t.Fatalf("ForgetMemory(STM) failed: %v", err)
+	}
+	if err := h.ForgetMemory("non-existent-uuid"); !errors.Is(err, store.ErrMemoryNotFound) {
+		t.Errorf("ForgetMemory(missing) error = %v, want ErrMemoryNotFound", err)
+	}
+
+	forgotten, err := h.ForgetMemories([]string{h.STM[0].UUID, ltm[0].UUID, "non-existent-uuid"})
+	if err != nil {
+		t.Fatalf("ForgetMemories failed: %v", err)
+	}
+	if forgotten != 2 {
+		t.Errorf("ForgetMemories = %d, want 2", forgotten)
+	}
+	if h.GetSTMCount() != 0 || h.GetLTMCount() != 0 {
+		t.Errorf("STM = %d, LTM = %d, want both 0", h.GetSTMCount(), h.GetLTMCount())
+	}
+}
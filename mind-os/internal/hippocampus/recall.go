@@ -0,0 +1,173 @@
+package hippocampus
+
+import (
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// 連想想起のスコア配分 (合計1.0)
+const (
+	recallConceptWeight  = 0.45 // 概念・タグの重なり
+	recallEmotionWeight  = 0.25 // 感情の一致度 (気分一致効果)
+	recallStrengthWeight = 0.15 // 記憶の重み
+	recallRecencyWeight  = 0.15 // 新近性
+
+	recallRecencyHalfLife = 7 * 24 * time.Hour // 新近性スコアが半減する期間
+	recallMinScore        = 0.3                // これ未満の記憶は想起されない
+	recallMinCongruence   = 0.5                // 概念が一致しない場合に必要な感情の一致度
+	recallCandidateLimit  = 50                 // DBから取得する候補数の上限
+)
+
+// Cue は連想想起の手がかり
+type Cue struct {
+	Concepts []string              // ウェルニッケ野が抽出した概念
+	Emotions []models.EmotionValue // 現在の感情
+}
+
+// RecallResult は想起された記憶と関連度
+type RecallResult struct {
+	Memory          models.RuneMemory
+	Score           float64
+	MatchedConcepts []string
+}
+
+// Recall は手がかりに基づいて関連する記憶を想起する
+// 【脳科学的意味】
+// 海馬のパターン補完(Pattern Completion)により、部分的な手がかりから過去のエピソード全体が呼び起こされる。
+// 話題(概念)の一致に加え、現在の気分と一致する記憶ほど思い出しやすい(気分一致効果)。
+// 【アルゴリズム】
+// 1. 候補の収集: STM全体 + 概念タグを含むLTM + 直近のLTM
+// 2. スコアリング: 概念の重なり・感情の一致度・重み・新近性の加重和
+// 3. 閾値以上の記憶をスコア順に上位k件返す
+func (h *Hippocampus) Recall(cue Cue, k int) []RecallResult {
+	if k <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	results := make([]RecallResult, 0)
+	for _, memory := range h.recallCandidates(cue.Concepts) {
+		matched := matchConcepts(cue.Concepts, memory.Tags)
+		congruence := emotionalCongruence(cue.Emotions, memory.Emotions)
+
+		// 話題も気分も一致しない記憶は想起されない
+		if len(matched) == 0 && congruence < recallMinCongruence {
+			continue
+		}
+
+		conceptScore := 0.0
+		if len(cue.Concepts) > 0 {
+			conceptScore = float64(len(matched)) / float64(len(cue.Concepts))
+		}
+		recency := math.Exp(-math.Ln2 * float64(now.Sub(memory.LastAccess)) / float64(recallRecencyHalfLife))
+
+		score := recallConceptWeight*conceptScore +
+			recallEmotionWeight*congruence +
+			recallStrengthWeight*math.Min(memory.Weight, 1.0) +
+			recallRecencyWeight*math.Min(recency, 1.0)
+
+		if score < recallMinScore {
+			continue
+		}
+
+		results = append(results, RecallResult{
+			Memory:          memory,
+			Score:           score,
+			MatchedConcepts: matched,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+
+	if len(results) > k {
+		results = results[:k]
+	}
+	return results
+}
+
+// recallCandidates はスコアリング対象の記憶をUUIDで重複排除して収集
+func (h *Hippocampus) recallCandidates(concepts []string) []models.RuneMemory {
+	seen := make(map[string]bool)
+	candidates := make([]models.RuneMemory, 0, len(h.STM))
+
+	add := func(memories []models.RuneMemory) {
+		for _, m := range memories {
+			if seen[m.UUID] {
+				continue
+			}
+			seen[m.UUID] = true
+			candidates = append(candidates, m)
+		}
+	}
+
+	add(h.STM)
+
+	if h.store != nil {
+		tagged, err := h.store.FindMemoriesByTags(concepts, recallCandidateLimit)
+		if err != nil {
+			slog.Warn("Failed to fetch LTM by tags", "error", err)
+		}
+		add(tagged)
+
+		recent, err := h.store.GetRecentMemories(10)
+		if err != nil {
+			slog.Warn("Failed to fetch recent LTM", "error", err)
+		}
+		add(recent)
+	}
+
+	return candidates
+}
+
+// matchConcepts は手がかりの概念のうち、タグに含まれるものを返す
+func matchConcepts(concepts []string, tags []string) []string {
+	if len(concepts) == 0 || len(tags) == 0 {
+		return nil
+	}
+
+	tagSet := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tagSet[tag] = true
+	}
+
+	var matched []string
+	for _, c := range concepts {
+		if tagSet[c] {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
+
+// emotionalCongruence は2つの感情状態のコサイン類似度 (0.0-1.0) を計算
+func emotionalCongruence(a, b []models.EmotionValue) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0.0
+	}
+
+	bMap := make(map[models.EmotionCode]float64, len(b))
+	normB := 0.0
+	for _, e := range b {
+		v := float64(e.Value)
+		bMap[e.Code] += v
+		normB += v * v
+	}
+
+	dot, normA := 0.0, 0.0
+	for _, e := range a {
+		v := float64(e.Value)
+		dot += v * bMap[e.Code]
+		normA += v * v
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0.0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,102 @@
+package hippocampus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// TestRecall_ConceptMatch は概念の一致による想起をテスト
+func TestRecall_ConceptMatch(t *testing.T) {
+	h, cleanup := setupTest(t)
+	defer cleanup()
+
+	// 古い長期記憶 (直近10件には入らないが、タグで検索される)
+	monthAgo := time.Now().Add(-30 * 24 * time.Hour)
+	if err := h.store.SaveMemory(models.RuneMemory{
+		UUID:       "cat-memory",
+		Text:       "猫と遊んで楽しかった",
+		Emotions:   []models.EmotionValue{{Code: models.EmotionJoy, Value: 80}},
+		Weight:     0.8,
+		Type:       models.MemoryLTM,
+		CreatedAt:  monthAgo,
+		LastAccess: monthAgo,
+		Tags:       []string{"Jo", "猫"},
+	}); err != nil {
+		t.Fatalf("SaveMemory failed: %v", err)
+	}
+
+	// 無関係な短期記憶
+	h.AddEpisode("今日は雨", []models.EmotionValue{
+		{Code: models.EmotionGrief, Value: 40},
+	}, "今日", "雨")
+
+	results := h.Recall(Cue{
+		Concepts: []string{"猫"},
+		Emotions: []models.EmotionValue{{Code: models.EmotionJoy, Value: 60}},
+	}, 3)
+
+	if len(results) != 1 {
+		t.Fatalf("Recall returned %d results, want 1: %+v", len(results), results)
+	}
+	if results[0].Memory.UUID != "cat-memory" {
+		t.Errorf("Recalled UUID = %v, want cat-memory", results[0].Memory.UUID)
+	}
+	if len(results[0].MatchedConcepts) != 1 || results[0].MatchedConcepts[0] != "猫" {
+		t.Errorf("MatchedConcepts = %v, want [猫]", results[0].MatchedConcepts)
+	}
+}
+
+// TestRecall_Ranking は関連度の高い順に上位k件が返ることをテスト
+func TestRecall_Ranking(t *testing.T) {
+	h, cleanup := setupTest(t)
+	defer cleanup()
+
+	joy := []models.EmotionValue{{Code: models.EmotionJoy, Value: 70}}
+	h.AddEpisode("猫とカフェに行った", joy, "猫", "カフェ")
+	h.AddEpisode("猫を見かけた", joy, "猫")
+	h.AddEpisode("カフェで仕事した", joy, "カフェ")
+	h.AddEpisode("怖い夢を見た", []models.EmotionValue{
+		{Code: models.EmotionFear, Value: 70},
+	}, "夢")
+
+	cue := Cue{
+		Concepts: []string{"猫", "カフェ"},
+		Emotions: joy,
+	}
+
+	results := h.Recall(cue, 2)
+	if len(results) != 2 {
+		t.Fatalf("Recall returned %d results, want 2", len(results))
+	}
+	if results[0].Memory.Text != "猫とカフェに行った" {
+		t.Errorf("Top result = %v, want 猫とカフェに行った", results[0].Memory.Text)
+	}
+	if results[0].Score < results[1].Score {
+		t.Errorf("Results not sorted by score: %v < %v", results[0].Score, results[1].Score)
+	}
+
+	// 話題も気分も一致しない記憶は想起されない
+	for _, r := range h.Recall(cue, 10) {
+		if r.Memory.Text == "怖い夢を見た" {
+			t.Error("Unrelated memory should not be recalled")
+		}
+	}
+}
+
+// TestEmotionalCongruence は感情の一致度計算をテスト
+func TestEmotionalCongruence(t *testing.T) {
+	joy := []models.EmotionValue{{Code: models.EmotionJoy, Value: 80}}
+	fear := []models.EmotionValue{{Code: models.EmotionFear, Value: 80}}
+
+	if got := emotionalCongruence(joy, joy); got < 0.99 {
+		t.Errorf("Same emotions congruence = %v, want 1.0", got)
+	}
+	if got := emotionalCongruence(joy, fear); got != 0 {
+		t.Errorf("Different emotions congruence = %v, want 0", got)
+	}
+	if got := emotionalCongruence(nil, joy); got != 0 {
+		t.Errorf("Empty emotions congruence = %v, want 0", got)
+	}
+}
@@ -35,14 +35,32 @@ func (h *Hippocampus) ReconsolidateMemory(memory *models.RuneMemory, currentEmot
 		memory.Weight = 1.0
 	}
 
-	// 4. DB更新（LTMの場合のみ）
-	// TODO: Implement UpdateMemory in store package
-	if memory.Type == models.MemoryLTM && h.store != nil {
-		// if err := h.store.UpdateMemory(*memory); err != nil {
-		// 	slog.Warn("Failed to update memory during reconsolidation", "error", err)
-		// }
-		slog.Info("Memory reconsolidated", "uuid", memory.UUID, "recallCount", memory.RecallCount)
+	// 4. 書き戻し
+	// 呼び出し元が受け取るのはコピーのため、STMは該当エントリを、LTMはDBの行を更新する
+	switch memory.Type {
+	case models.MemorySTM:
+		h.updateSTMEntry(*memory)
+	case models.MemoryLTM:
+		if h.store == nil {
+			return
+		}
+		if err := h.store.UpdateMemory(*memory); err != nil {
+			slog.Warn("Failed to update memory during reconsolidation", "uuid", memory.UUID, "error", err)
+			return
+		}
+		slog.Debug("Memory reconsolidated", "uuid", memory.UUID, "recallCount", memory.RecallCount)
+	}
+}
+
+// updateSTMEntry はUUIDが一致するSTMのエントリを置き換え、見つかったかを返す
+func (h *Hippocampus) updateSTMEntry(memory models.RuneMemory) bool {
+	for i := range h.STM {
+		if h.STM[i].UUID == memory.UUID {
+			h.STM[i] = memory
+			return true
+		}
 	}
+	return false
 }
 
 // blendEmotions は2つの感情リストをブレンド
@@ -5,7 +5,7 @@ import (
 	"time"
 )
 
-// 概日リズムの定数
+// 概日リズムの既定の時間帯 (WithDayNight で変更できる)
 const (
 	DayTimeStart   = 6  // 日中開始 (6:00)
 	NightTimeStart = 22 // 夜間開始 (22:00)
@@ -19,62 +19,94 @@ func (h *Homeostasis) UpdateCircadianRhythm(currentTime time.Time) {
 
 	hour := currentTime.Hour()
 
-	// 夜間 (22:00 - 06:00)
-	if hour >= NightTimeStart || hour < DayTimeStart {
+	// 夜間 (既定: 22:00 - 06:00)
+	if IsNightHour(hour, h.dayStart, h.nightStart) {
 		// メラトニン上昇（睡眠促進）
-		// 深夜2時前後で最大値
-		h.Melatonin = calculateNightMelatonin(hour)
+		// 夜間の開始から2時間後 (既定: 0時) 前後で最大値
+		h.Melatonin = calculateNightMelatonin(hour, h.dayStart, h.nightStart)
 
 		// セロトニン低下（覚醒度低下）
 		h.Serotonin = 20.0
 
 	} else {
-		// 日中 (06:00 - 22:00)
+		// 日中 (既定: 06:00 - 22:00)
 		// メラトニン低下（覚醒）
 		h.Melatonin = 10.0
 
 		// セロトニン上昇（覚醒・安定）
 		// 正午前後で最大値
-		h.Serotonin = calculateDaySerotonin(hour)
+		h.Serotonin = calculateDaySerotonin(hour, h.dayStart, h.nightStart)
 	}
 
 	h.clamp()
 }
 
+// IsNightHour は時刻(時)が夜間の時間帯に含まれるかを判定
+// 夜間は nightStart 時から dayStart 時まで (日付をまたぐ場合も考慮)
+func IsNightHour(hour, dayStart, nightStart int) bool {
+	if nightStart > dayStart {
+		return hour >= nightStart || hour < dayStart
+	}
+	return hour >= nightStart && hour < dayStart
+}
+
+// NightBegan は t を含む (または t の直前の) 夜間が始まった時刻を返す
+func NightBegan(t time.Time, nightStart int) time.Time {
+	begin := time.Date(t.Year(), t.Month(), t.Day(), nightStart, 0, 0, 0, t.Location())
+	if t.Before(begin) {
+		begin = begin.AddDate(0, 0, -1)
+	}
+	return begin
+}
+
+// hoursBetween は from 時から to 時までの時間 (日付をまたぐ場合も考慮, 0-23)
+func hoursBetween(from, to int) int {
+	return ((to-from)%24 + 24) % 24
+}
+
 // calculateNightMelatonin は夜間のメラトニン値を計算
-// 深夜2時前後（2-2時）で最大値 (80-100)
-func calculateNightMelatonin(hour int) float64 {
-	// 22時以降、翌日0時に向けて上昇
-	if hour >= NightTimeStart {
-		// 22時: 0.8, 23時: 0.9, 24時:0時: 1.0のイメージ
-		progress := float64(hour-NightTimeStart) / 2.0
+// 夜間の開始から2時間後 (既定: 0時) 前後で最大値 (80-100)
+func calculateNightMelatonin(hour, dayStart, nightStart int) float64 {
+	nightLength := float64(hoursBetween(nightStart, dayStart))
+	sinceNight := float64(hoursBetween(nightStart, hour))
+	untilDay := float64(hoursBetween(hour, dayStart))
+
+	// 夜間の開始から最大値に向けて上昇 (夜間が短い場合は前半で)
+	// 既定: 22時: 0.8, 23時: 0.9, 0時: 1.0のイメージ
+	rise := math.Min(2.0, nightLength/2.0)
+	if sinceNight < rise {
+		progress := sinceNight / rise
 		return 80.0 + (progress * 20.0)
 	}
 
-	// 0-6時の朝に向けて下降
-	// 0時: 1.0, 3時: 0.9, 6時: 0.5
-	progress := float64(DayTimeStart-hour) / float64(DayTimeStart)
+	// 朝に向けて下降
+	// 既定: 0時: 1.0, 3時: 0.75, 6時: 0.5
+	progress := untilDay / (nightLength - rise)
 	return 50.0 + (progress * 50.0)
 }
 
 // calculateDaySerotonin は日中のセロトニン値を計算
-// 正午前後（10-14時）で最大値 (60-100)
-func calculateDaySerotonin(hour int) float64 {
-	// 朝6時から正午に向けて上昇
-	if hour >= DayTimeStart && hour < 12 {
-		// 6時: 60, 12時: 100
-		progress := float64(hour-DayTimeStart) / 6.0
-		return 60.0 + (progress * 40.0)
+// 正午前後で最大値 (60-100)。正午が日中に含まれない場合は日中の中間で最大
+func calculateDaySerotonin(hour, dayStart, nightStart int) float64 {
+	dayLength := float64(hoursBetween(dayStart, nightStart))
+	sinceDay := float64(hoursBetween(dayStart, hour))
+
+	peak := float64(hoursBetween(dayStart, 12))
+	if peak >= dayLength {
+		peak = dayLength / 2.0
 	}
 
-	// 正午から夜22時に向けて緩やかに下降
-	if hour >= 12 && hour < NightTimeStart {
-		// 12時: 100, 22時: 60
-		progress := float64(hour-12) / float64(NightTimeStart-12)
-		return 100.0 - (progress * 40.0)
+	// 朝から正午に向けて上昇
+	// 既定: 6時: 60, 12時: 100
+	if sinceDay < peak {
+		progress := sinceDay / peak
+		return 60.0 + (progress * 40.0)
 	}
 
-	return 60.0 // デフォルト
+	// 正午から夜に向けて緩やかに下降
+	// 既定: 12時: 100, 22時: 60
+	progress := (sinceDay - peak) / (dayLength - peak)
+	return 100.0 - (progress * 40.0)
 }
 
 // GetCircadianEffects は概日リズムによる効果を返す
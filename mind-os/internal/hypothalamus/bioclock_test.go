@@ -0,0 +1,66 @@
+package hypothalamus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsNightHour(t *testing.T) {
+	tests := []struct {
+		name                 string
+		dayStart, nightStart int
+		hour                 int
+		want                 bool
+	}{
+		// 日付をまたぐ夜間 (22時 → 6時)
+		{"日付またぎ/夜間開始", 6, 22, 22, true},
+		{"日付またぎ/深夜", 6, 22, 0, true},
+		{"日付またぎ/明け方", 6, 22, 5, true},
+		{"日付またぎ/日中開始", 6, 22, 6, false},
+		{"日付またぎ/正午", 6, 22, 12, false},
+		{"日付またぎ/夜間開始の直前", 6, 22, 21, false},
+		// 日付をまたがない夜間 (1時 → 5時)
+		{"日付内/夜間開始", 5, 1, 1, true},
+		{"日付内/夜間", 5, 1, 4, true},
+		{"日付内/日中開始", 5, 1, 5, false},
+		{"日付内/夜間開始の前", 5, 1, 0, false},
+		{"日付内/夜", 5, 1, 23, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNightHour(tt.hour, tt.dayStart, tt.nightStart); got != tt.want {
+				t.Errorf("IsNightHour(%d, %d, %d) = %v, want %v", tt.hour, tt.dayStart, tt.nightStart, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNightBegan(t *testing.T) {
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2026, time.March, day, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		t          time.Time
+		nightStart int
+		want       time.Time
+	}{
+		{"夜間開始ちょうど", at(10, 22, 0), 22, at(10, 22, 0)},
+		{"夜間開始の当日", at(10, 23, 30), 22, at(10, 22, 0)},
+		{"日付をまたいだ後", at(11, 3, 0), 22, at(10, 22, 0)},
+		{"日中は直前の夜間", at(11, 12, 0), 22, at(10, 22, 0)},
+		{"日付をまたがない夜間", at(11, 3, 0), 1, at(11, 1, 0)},
+		{"日付をまたがない夜間の前", at(11, 0, 30), 1, at(10, 1, 0)},
+		{"月をまたぐ", time.Date(2026, time.April, 1, 2, 0, 0, 0, time.UTC), 22, at(31, 22, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NightBegan(tt.t, tt.nightStart); !got.Equal(tt.want) {
+				t.Errorf("NightBegan(%v, %d) = %v, want %v", tt.t, tt.nightStart, got, tt.want)
+			}
+		})
+	}
+}
@@ -17,21 +17,66 @@ type Homeostasis struct {
 	Serotonin   float64   // 覚醒・安心ホルモン (0-100): 日中に上昇、気分調整に関与
 	LastUpdated time.Time // 最終更新時間
 
+	// 内部パラメータ
+	baselineCortisol float64 // 刺激がない時に戻る Cortisol (気質: 不安になりやすさ)
+	baselineOxytocin float64 // 刺激がない時に戻る Oxytocin (気質: 人懐っこさ)
+	decayRate        float64 // 1時間あたりの Cortisol/Oxytocin の減衰量
+	dayStart         int     // 日中の開始時刻 (時)
+	nightStart       int     // 夜間の開始時刻 (時)
+
 	// テスト用の時間プロバイダー
 	// 実環境では time.Now() を使用するが、テスト時に時間を固定できるようにする
 	TimeProvider func() time.Time
 }
 
+// DefaultDecayRate は1時間あたりのホルモン減衰量の既定値
+const DefaultDecayRate = 10.0
+
+// Option は Homeostasis の設定を変更する関数
+type Option func(*Homeostasis)
+
+// WithDecayRate は1時間あたりの Cortisol/Oxytocin の減衰量を設定する
+func WithDecayRate(perHour float64) Option {
+	return func(h *Homeostasis) {
+		h.decayRate = perHour
+	}
+}
+
+// WithBaseline は刺激がない時に戻る Cortisol/Oxytocin の基準値 (0-100) を設定する (初期値も基準値になる)
+func WithBaseline(cortisol, oxytocin float64) Option {
+	return func(h *Homeostasis) {
+		h.baselineCortisol = cortisol
+		h.baselineOxytocin = oxytocin
+		h.Cortisol = cortisol
+		h.Oxytocin = oxytocin
+	}
+}
+
+// WithDayNight は日中・夜間の開始時刻 (時) を設定する
+func WithDayNight(dayStart, nightStart int) Option {
+	return func(h *Homeostasis) {
+		h.dayStart = dayStart
+		h.nightStart = nightStart
+	}
+}
+
 // NewHomeostasis は新しい Homeostasis インスタンスを作成
-func NewHomeostasis() *Homeostasis {
-	return &Homeostasis{
+func NewHomeostasis(opts ...Option) *Homeostasis {
+	h := &Homeostasis{
 		Cortisol:     0,
 		Oxytocin:     0,
 		Melatonin:    0,
 		Serotonin:    50,
 		LastUpdated:  time.Now(),
+		decayRate:    DefaultDecayRate,
+		dayStart:     DayTimeStart,
+		nightStart:   NightTimeStart,
 		TimeProvider: time.Now, // デフォルト: システム時間
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // Update は外部刺激により値を変動させる
@@ -61,6 +106,7 @@ func (h *Homeostasis) Update(stressor float64, affection float64) {
 
 // Decay は時間経過による自然減衰を計算する
 // 【神経科学的意味】ホルモンの血中濃度の半減期をシミュレート
+// 値は基準値 (既定: 0) に向かって戻る
 func (h *Homeostasis) Decay() {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -73,25 +119,56 @@ func (h *Homeostasis) Decay() {
 		return
 	}
 
-	// 1時間あたり-10 程度 (半減期ではなく線形減衰を採用)
-	decayRate := 10.0
-	decayAmount := decayRate * elapsed
+	// 1時間あたり decayRate ずつ (半減期ではなく線形減衰を採用)
+	decayAmount := h.decayRate * elapsed
+
+	h.Cortisol = decayToward(h.Cortisol, h.baselineCortisol, decayAmount)
+	h.Oxytocin = decayToward(h.Oxytocin, h.baselineOxytocin, decayAmount)
 
-	if h.Cortisol > 0 {
-		h.Cortisol -= decayAmount
-		if h.Cortisol < 0 {
-			h.Cortisol = 0
-		}
+	h.LastUpdated = now
+}
+
+// decayToward は value を baseline に向かって amount だけ近づける (baseline を越えない)
+func decayToward(value, baseline, amount float64) float64 {
+	if value > baseline {
+		return math.Max(baseline, value-amount)
 	}
+	return math.Min(baseline, value+amount)
+}
 
-	if h.Oxytocin > 0 {
-		h.Oxytocin -= decayAmount
-		if h.Oxytocin < 0 {
-			h.Oxytocin = 0
-		}
+// HormoneSnapshot はホルモン状態の永続化用スナップショット
+type HormoneSnapshot struct {
+	Cortisol    float64
+	Oxytocin    float64
+	Melatonin   float64
+	Serotonin   float64
+	LastUpdated time.Time
+}
+
+// Snapshot は現在のホルモン状態を返す
+func (h *Homeostasis) Snapshot() HormoneSnapshot {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return HormoneSnapshot{
+		Cortisol:    h.Cortisol,
+		Oxytocin:    h.Oxytocin,
+		Melatonin:   h.Melatonin,
+		Serotonin:   h.Serotonin,
+		LastUpdated: h.LastUpdated,
 	}
+}
 
-	h.LastUpdated = now
+// Restore はスナップショットからホルモン状態を復元する
+// LastUpdated も復元されるため、直後の Decay で停止中の経過時間分が減衰する
+func (h *Homeostasis) Restore(s HormoneSnapshot) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.Cortisol = s.Cortisol
+	h.Oxytocin = s.Oxytocin
+	h.Melatonin = s.Melatonin
+	h.Serotonin = s.Serotonin
+	h.LastUpdated = s.LastUpdated
+	h.clamp()
 }
 
 // GetStatus は現在の値を返す
@@ -0,0 +1,360 @@
+package language
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// 英語の品詞 (Universal Dependencies の品詞タグ)
+// 規則ベースの解析のため、機能語と頻出の動詞・形容詞以外の内容語 (主に名詞) は POSOther になる
+const (
+	POSPronoun       = "PRON"  // 代名詞 (I, it, this)
+	POSDeterminer    = "DET"   // 限定詞 (a, the, no)
+	POSAuxiliary     = "AUX"   // 助動詞・be動詞 (is, do, can)
+	POSParticle      = "PART"  // 不変化詞 (not, to)
+	POSAdposition    = "ADP"   // 前置詞 (in, of, with)
+	POSCoordinating  = "CCONJ" // 等位接続詞 (and, but)
+	POSSubordinating = "SCONJ" // 従属接続詞 (because, if)
+	POSAdverb        = "ADV"   // 副詞 (very, maybe)
+	POSInterjection  = "INTJ"  // 間投詞 (hello, wow)
+	POSVerb          = "VERB"  // 頻出の動詞 (feel, think)
+	POSAdjective     = "ADJ"   // 頻出の形容詞 (good, happy)
+	POSPunctuation   = "PUNCT" // 句読点 (. , ! ?)
+	POSSymbol        = "SYM"   // 記号・絵文字
+	POSOther         = "X"     // その他の内容語
+)
+
+// english は規則ベースの英語の解析器
+// 【処理内容】空白・句読点で語に分割し、短縮形 (don't, I'm) を展開してから、
+// 機能語の表と語尾の規則で品詞と見出し語 (基本形) を求める
+type english struct{}
+
+// NewEnglish は英語の解析器を作成
+func NewEnglish() Analyzer {
+	return english{}
+}
+
+// Language は英語を返す
+func (english) Language() models.Language {
+	return models.LanguageEnglish
+}
+
+// Tokenize はテキストを語と句読点に分割する
+func (english) Tokenize(text string) []Token {
+	var tokens []Token
+	word := make([]rune, 0, 16)
+
+	flush := func() {
+		if len(word) > 0 {
+			tokens = appendWord(tokens, string(word))
+			word = word[:0]
+		}
+	}
+
+	runes := []rune(text)
+	for i, r := range runes {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), unicode.Is(unicode.Mn, r):
+			word = append(word, r)
+		case isApostrophe(r) || r == '-':
+			// 語中のアポストロフィ・ハイフン (don't, well-known) は語の一部
+			if len(word) > 0 && i+1 < len(runes) && unicode.IsLetter(runes[i+1]) {
+				word = append(word, r)
+				continue
+			}
+			flush()
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			pos := POSSymbol
+			if unicode.IsPunct(r) {
+				pos = POSPunctuation
+			}
+			tokens = append(tokens, Token{Surface: string(r), BaseForm: string(r), Features: []string{pos}})
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// isApostrophe はアポストロフィ (' または ’) か判定
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
+
+// contractionSuffixes は短縮形の後半とその見出し語
+var contractionSuffixes = []struct {
+	suffix string
+	lemma  string
+	pos    string
+}{
+	{"n't", "not", POSParticle},
+	{"'m", "be", POSAuxiliary},
+	{"'re", "be", POSAuxiliary},
+	{"'s", "be", POSAuxiliary}, // 所有格の 's も同じく扱う (感情の評価・概念の抽出では読み飛ばす)
+	{"'ve", "have", POSAuxiliary},
+	{"'ll", "will", POSAuxiliary},
+	{"'d", "would", POSAuxiliary},
+}
+
+// contractionStems は n't の前で形が変わる語 (can't, won't)
+var contractionStems = map[string]string{
+	"ca":  "can",
+	"wo":  "will",
+	"sha": "shall",
+	"ai":  "be",
+}
+
+// appendWord は語をトークンとして追加する (短縮形は2つのトークンに分ける)
+func appendWord(tokens []Token, word string) []Token {
+	normalized := strings.ToLower(strings.ReplaceAll(word, "’", "'"))
+
+	if normalized == "cannot" {
+		return append(tokens, newEnglishToken(word[:3], "can"), newEnglishToken(word[3:], "not"))
+	}
+
+	for _, c := range contractionSuffixes {
+		if !strings.HasSuffix(normalized, c.suffix) || len(normalized) == len(c.suffix) {
+			continue
+		}
+		// アポストロフィは ’ (3バイト) の場合があるため、後半の文字数で分ける
+		runes := []rune(word)
+		split := len(runes) - len([]rune(c.suffix))
+		stem := string(runes[:split])
+		lemma := strings.ToLower(stem)
+		if c.suffix == "n't" {
+			if s, ok := contractionStems[lemma]; ok {
+				lemma = s
+			}
+		}
+		return append(tokens,
+			newEnglishToken(stem, lemma),
+			Token{Surface: string(runes[split:]), BaseForm: c.lemma, Features: []string{c.pos}},
+		)
+	}
+
+	return append(tokens, newEnglishToken(word, normalized))
+}
+
+// newEnglishToken は語 (小文字化済みの形 lower) の品詞と見出し語を求めてトークンを作る
+func newEnglishToken(surface, lower string) Token {
+	if pos, ok := closedClass[lower]; ok {
+		base := lower
+		if l, ok := irregularLemmas[lower]; ok {
+			base = l
+		}
+		return Token{Surface: surface, BaseForm: base, Features: []string{pos}}
+	}
+
+	base := lemma(lower)
+	pos := POSOther
+	switch {
+	case commonVerbs[base]:
+		pos = POSVerb
+	case commonAdjectives[lower], commonAdjectives[base]:
+		pos = POSAdjective
+	case len(lower) > 4 && strings.HasSuffix(lower, "ly") && !lyNonAdverbs[lower]:
+		pos = POSAdverb
+	}
+	return Token{Surface: surface, BaseForm: base, Features: []string{pos}}
+}
+
+// lemma は語形変化 (複数形・三人称単数・過去形・進行形) を規則で取り除いた見出し語を返す
+// 【制約】辞書を使わないため完全ではない (excited → excit のように語末の e を復元できない語がある)
+// 辞書との照合には語末の e の有無を無視する Fold を使う
+func lemma(word string) string {
+	if l, ok := irregularLemmas[word]; ok {
+		return l
+	}
+	if keepForms[word] || !hasVowel(word) {
+		return word
+	}
+
+	n := len(word)
+	switch {
+	case n > 4 && (strings.HasSuffix(word, "ies") || strings.HasSuffix(word, "ied")):
+		return word[:n-3] + "y" // worries, worried → worry
+	case n > 5 && strings.HasSuffix(word, "ing") && hasVowel(word[:n-3]):
+		return restoreStem(word[:n-3]) // loving → love, stopping → stop
+	case n > 4 && strings.HasSuffix(word, "ed") && !strings.HasSuffix(word, "eed"):
+		return restoreStem(word[:n-2]) // hated → hate, killed → kill
+	case n > 4 && hasAnySuffix(word, "sses", "shes", "ches", "xes", "zes"):
+		return word[:n-2] // misses → miss, watches → watch
+	case n > 3 && strings.HasSuffix(word, "s") && !hasAnySuffix(word, "ss", "us", "is"):
+		return word[:n-1] // loves → love, bugs → bug
+	}
+	return word
+}
+
+// restoreStem は -ed / -ing を除いた語幹を整える
+// 子音字の重なりを戻し (stopp → stop)、1音節で「子音・母音・子音」で終わる語幹には e を補う (lov → love)
+func restoreStem(stem string) string {
+	n := len(stem)
+	if n >= 2 && stem[n-1] == stem[n-2] && isConsonant(stem[n-1]) && !strings.ContainsRune("lsfz", rune(stem[n-1])) {
+		return stem[:n-1]
+	}
+	if n >= 3 && isConsonant(stem[n-3]) && !isConsonant(stem[n-2]) && isConsonant(stem[n-1]) &&
+		!strings.ContainsRune("wxy", rune(stem[n-1])) && vowelGroups(stem) == 1 {
+		return stem + "e"
+	}
+	return stem
+}
+
+// foldEnglish は照合用の正規形 (見出し語から語末の e を除いた形) を返す
+func foldEnglish(word string) string {
+	base := lemma(strings.ToLower(word))
+	if len(base) > 3 && strings.HasSuffix(base, "e") && !strings.HasSuffix(base, "ee") {
+		return base[:len(base)-1]
+	}
+	return base
+}
+
+// isConsonant は ASCII の子音字か判定 (y は子音として扱う)
+func isConsonant(c byte) bool {
+	return c >= 'a' && c <= 'z' && !strings.ContainsRune("aeiou", rune(c))
+}
+
+// hasVowel は母音字 (y を含む) を含むか判定
+func hasVowel(word string) bool {
+	return strings.ContainsAny(word, "aeiouy")
+}
+
+// vowelGroups は連続する母音字のまとまりの数 (音節数の目安) を返す
+func vowelGroups(word string) int {
+	groups := 0
+	inVowel := false
+	for i := 0; i < len(word); i++ {
+		vowel := strings.ContainsRune("aeiou", rune(word[i]))
+		if vowel && !inVowel {
+			groups++
+		}
+		inVowel = vowel
+	}
+	return groups
+}
+
+// hasAnySuffix はいずれかの接尾辞で終わるか判定
+func hasAnySuffix(word string, suffixes ...string) bool {
+	for _, s := range suffixes {
+		if strings.HasSuffix(word, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// closedClass は機能語の品詞 (キー: 小文字の表層形)
+var closedClass = map[string]string{
+	// 代名詞
+	"i": POSPronoun, "me": POSPronoun, "my": POSPronoun, "mine": POSPronoun, "myself": POSPronoun,
+	"you": POSPronoun, "your": POSPronoun, "yours": POSPronoun, "yourself": POSPronoun, "u": POSPronoun,
+	"he": POSPronoun, "him": POSPronoun, "his": POSPronoun, "she": POSPronoun, "her": POSPronoun, "hers": POSPronoun,
+	"it": POSPronoun, "its": POSPronoun, "itself": POSPronoun,
+	"we": POSPronoun, "us": POSPronoun, "our": POSPronoun, "ours": POSPronoun,
+	"they": POSPronoun, "them": POSPronoun, "their": POSPronoun, "theirs": POSPronoun,
+	"this": POSPronoun, "that": POSPronoun, "these": POSPronoun, "those": POSPronoun,
+	"something": POSPronoun, "anything": POSPronoun, "nothing": POSPronoun, "everything": POSPronoun,
+	"someone": POSPronoun, "anyone": POSPronoun, "everyone": POSPronoun, "nobody": POSPronoun, "everybody": POSPronoun,
+	"what": POSPronoun, "who": POSPronoun, "whom": POSPronoun, "whose": POSPronoun, "which": POSPronoun,
+
+	// 限定詞
+	"a": POSDeterminer, "an": POSDeterminer, "the": POSDeterminer, "some": POSDeterminer, "any": POSDeterminer,
+	"no": POSDeterminer, "every": POSDeterminer, "each": POSDeterminer, "all": POSDeterminer, "both": POSDeterminer,
+	"another": POSDeterminer, "such": POSDeterminer,
+
+	// 助動詞・be動詞
+	"am": POSAuxiliary, "is": POSAuxiliary, "are": POSAuxiliary, "was": POSAuxiliary, "were": POSAuxiliary,
+	"be": POSAuxiliary, "been": POSAuxiliary, "being": POSAuxiliary,
+	"do": POSAuxiliary, "does": POSAuxiliary, "did": POSAuxiliary,
+	"have": POSAuxiliary, "has": POSAuxiliary, "had": POSAuxiliary,
+	"can": POSAuxiliary, "could": POSAuxiliary, "will": POSAuxiliary, "would": POSAuxiliary,
+	"shall": POSAuxiliary, "should": POSAuxiliary, "may": POSAuxiliary, "might": POSAuxiliary, "must": POSAuxiliary,
+
+	// 不変化詞
+	"not": POSParticle, "to": POSParticle,
+
+	// 前置詞
+	"in": POSAdposition, "on": POSAdposition, "at": POSAdposition, "of": POSAdposition, "for": POSAdposition,
+	"with": POSAdposition, "about": POSAdposition, "from": POSAdposition, "by": POSAdposition, "into": POSAdposition,
+	"over": POSAdposition, "under": POSAdposition, "after": POSAdposition, "before": POSAdposition,
+	"without": POSAdposition, "through": POSAdposition, "during": POSAdposition, "than": POSAdposition,
+	"as": POSAdposition, "around": POSAdposition, "between": POSAdposition,
+
+	// 接続詞
+	"and": POSCoordinating, "but": POSCoordinating, "or": POSCoordinating, "nor": POSCoordinating, "yet": POSCoordinating,
+	"because": POSSubordinating, "although": POSSubordinating, "though": POSSubordinating, "if": POSSubordinating,
+	"when": POSSubordinating, "while": POSSubordinating, "since": POSSubordinating, "unless": POSSubordinating,
+	"whether": POSSubordinating, "until": POSSubordinating, "cause": POSSubordinating,
+
+	// 副詞
+	"very": POSAdverb, "really": POSAdverb, "so": POSAdverb, "too": POSAdverb, "quite": POSAdverb,
+	"just": POSAdverb, "also": POSAdverb, "still": POSAdverb, "even": POSAdverb, "ever": POSAdverb,
+	"never": POSAdverb, "always": POSAdverb, "often": POSAdverb, "sometimes": POSAdverb, "again": POSAdverb,
+	"already": POSAdverb, "maybe": POSAdverb, "perhaps": POSAdverb, "probably": POSAdverb, "possibly": POSAdverb,
+	"here": POSAdverb, "there": POSAdverb, "now": POSAdverb, "then": POSAdverb, "how": POSAdverb,
+	"why": POSAdverb, "where": POSAdverb, "kinda": POSAdverb, "super": POSAdverb, "pretty": POSAdverb,
+	"rather": POSAdverb, "almost": POSAdverb, "hardly": POSAdverb, "barely": POSAdverb,
+
+	// 間投詞
+	"hi": POSInterjection, "hello": POSInterjection, "hey": POSInterjection, "thanks": POSInterjection,
+	"thx": POSInterjection, "oh": POSInterjection, "wow": POSInterjection, "yeah": POSInterjection,
+	"yes": POSInterjection, "ok": POSInterjection, "okay": POSInterjection, "please": POSInterjection,
+	"bye": POSInterjection, "goodbye": POSInterjection, "lol": POSInterjection, "haha": POSInterjection,
+	"ugh": POSInterjection, "oops": POSInterjection, "yay": POSInterjection, "ouch": POSInterjection,
+	"hmm": POSInterjection,
+}
+
+// irregularLemmas は不規則に変化する語の見出し語
+var irregularLemmas = map[string]string{
+	"am": "be", "is": "be", "are": "be", "was": "be", "were": "be", "been": "be", "being": "be",
+	"has": "have", "had": "have", "having": "have",
+	"does": "do", "did": "do", "done": "do", "doing": "do",
+	"goes": "go", "went": "go", "gone": "go",
+	"felt": "feel", "made": "make", "got": "get", "gotten": "get", "thought": "think",
+	"said": "say", "saw": "see", "seen": "see", "came": "come", "took": "take", "taken": "take",
+	"gave": "give", "given": "give", "knew": "know", "known": "know", "told": "tell",
+	"found": "find", "lost": "lose", "left": "leave", "kept": "keep", "bought": "buy", "brought": "bring",
+	"fell": "fall", "fallen": "fall", "broke": "break", "broken": "break", "won": "win",
+	"ate": "eat", "eaten": "eat", "ran": "run", "sat": "sit", "slept": "sleep", "meant": "mean",
+	"men": "man", "women": "woman", "children": "child", "feet": "foot", "teeth": "tooth",
+}
+
+// keepForms は語尾の規則を適用しない語 (語尾が変化形と同じ綴りの語)
+var keepForms = map[string]bool{
+	"news": true, "series": true, "species": true, "lens": true, "chaos": true, "gas": true,
+	"morning": true, "evening": true, "wedding": true, "ceiling": true, "thing": true, "nothing": true,
+	"something": true, "anything": true, "everything": true, "king": true, "ring": true, "sing": true,
+	"bring": true, "spring": true, "string": true, "wing": true, "swing": true, "sibling": true,
+}
+
+// lyNonAdverbs は -ly で終わるが副詞ではない語
+var lyNonAdverbs = map[string]bool{
+	"family": true, "reply": true, "supply": true, "belly": true, "jelly": true, "bully": true,
+	"july": true, "italy": true, "lovely": true, "lonely": true, "ugly": true, "silly": true,
+	"friendly": true, "holy": true,
+}
+
+// commonVerbs は頻出の動詞 (キー: 見出し語)
+var commonVerbs = map[string]bool{
+	"feel": true, "think": true, "like": true, "love": true, "hate": true, "want": true, "need": true,
+	"know": true, "get": true, "go": true, "make": true, "say": true, "see": true, "look": true,
+	"come": true, "take": true, "give": true, "tell": true, "try": true, "let": true, "seem": true,
+	"become": true, "keep": true, "mean": true, "thank": true, "guess": true, "suppose": true,
+	"believe": true, "wish": true, "enjoy": true, "miss": true, "worry": true, "hope": true,
+	"sound": true, "appear": true,
+}
+
+// commonAdjectives は頻出の形容詞 (キー: 小文字の表層形または見出し語)
+var commonAdjectives = map[string]bool{
+	"good": true, "bad": true, "great": true, "nice": true, "happy": true, "sad": true, "angry": true,
+	"scared": true, "afraid": true, "glad": true, "sorry": true, "fine": true, "sure": true,
+	"tired": true, "bored": true, "excited": true, "awesome": true, "amazing": true, "terrible": true,
+	"awful": true, "horrible": true, "lonely": true, "worried": true, "anxious": true, "nervous": true,
+	"upset": true, "cool": true, "best": true, "better": true, "worse": true, "worst": true,
+	"beautiful": true, "cute": true, "wonderful": true, "fantastic": true, "lovely": true, "ugly": true,
+	"new": true, "old": true, "big": true, "small": true, "little": true,
+}
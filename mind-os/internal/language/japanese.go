@@ -0,0 +1,53 @@
+package language
+
+import (
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// japanese は Kagome (IPA辞書) による日本語の形態素解析器
+type japanese struct {
+	tokenizer *tokenizer.Tokenizer
+}
+
+// NewJapanese は日本語の形態素解析器を作成
+func NewJapanese() (Analyzer, error) {
+	t, err := tokenizer.New(ipa.Dict(), tokenizer.OmitBosEos())
+	if err != nil {
+		return nil, err
+	}
+	return &japanese{tokenizer: t}, nil
+}
+
+// Language は日本語を返す
+func (j *japanese) Language() models.Language {
+	return models.LanguageJapanese
+}
+
+// Tokenize はテキストを形態素に分割する (辞書にない区切りのトークンは除く)
+// 素性は IPA辞書の並び [品詞, 品詞細分類1, 品詞細分類2, 品詞細分類3, 活用型, 活用形, 原形, 読み, 発音]
+func (j *japanese) Tokenize(text string) []Token {
+	tokens := j.tokenizer.Tokenize(text)
+	result := make([]Token, 0, len(tokens))
+	for _, t := range tokens {
+		if t.Class == tokenizer.DUMMY {
+			continue
+		}
+		features := t.Features()
+		result = append(result, Token{
+			Surface:  t.Surface,
+			BaseForm: baseForm(t.Surface, features),
+			Features: features,
+		})
+	}
+	return result
+}
+
+// baseForm は素性から基本形 (原形) を取り出す (原形がない未知語は表層形)
+func baseForm(surface string, features []string) string {
+	if len(features) > 6 && features[6] != "*" {
+		return features[6]
+	}
+	return surface
+}
@@ -0,0 +1,84 @@
+// Package language は入力テキストの言語判定と、言語ごとの形態素解析 (トークン化) を提供する
+// 【役割】扁桃体・ウェルニッケ野が言語に依存しない形でテキストを語の列として扱えるようにする
+// 日本語は Kagome (IPA辞書)、英語は規則ベースの解析器で解析する
+package language
+
+import (
+	"unicode"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// Token は形態素 (語) の解析結果
+type Token struct {
+	Surface  string   // 表層形 (入力テキスト中の形)
+	BaseForm string   // 基本形 (活用・語形変化を戻した形。英語は小文字化した見出し語)
+	Features []string // 品詞などの素性 (日本語は IPA辞書の素性の並び、英語は [品詞])
+}
+
+// Analyzer は1つの言語の形態素解析器
+type Analyzer interface {
+	// Language は解析器が対象とする言語を返す
+	Language() models.Language
+	// Tokenize はテキストを語の列に分割する (区切りのみのトークンは含めない)
+	Tokenize(text string) []Token
+}
+
+// Analyzers は対応する言語ごとの解析器
+type Analyzers map[models.Language]Analyzer
+
+// NewAnalyzers は対応する全ての言語の解析器を作成
+func NewAnalyzers() (Analyzers, error) {
+	ja, err := NewJapanese()
+	if err != nil {
+		return nil, err
+	}
+	return Analyzers{
+		models.LanguageJapanese: ja,
+		models.LanguageEnglish:  NewEnglish(),
+	}, nil
+}
+
+// For は言語の解析器を返す (対応していない言語の場合は既定の言語の解析器)
+func (a Analyzers) For(lang models.Language) Analyzer {
+	if analyzer, ok := a[lang]; ok {
+		return analyzer
+	}
+	return a[models.DefaultLanguage]
+}
+
+// latinPerJapanese は日本語と判定するのに必要な、日本語の文字1文字あたりのラテン文字の数の上限
+// 「OKだよ」「Bugが出た」のように日本語の文に英単語が混ざることは多いため、日本語の文字を重く数える
+const latinPerJapanese = 2
+
+// fullwidthForms は全角英数字の先頭 (「ｗｗｗ」のような全角のラテン文字は日本語の文中で使われるため数えない)
+const fullwidthForms = '\uFF00'
+
+// Detect はテキストの言語を判定する
+// 【処理内容】ひらがな・カタカナ・漢字と (半角の) ラテン文字の数を比べ、ラテン文字が十分に多ければ英語とする
+// どちらの文字も含まない (数字・記号・絵文字のみの) 場合は既定の言語
+func Detect(text string) models.Language {
+	japanese, latin := 0, 0
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Han), r == 'ー':
+			japanese++
+		case unicode.In(r, unicode.Latin) && r < fullwidthForms:
+			latin++
+		}
+	}
+	if latin > japanese*latinPerJapanese {
+		return models.LanguageEnglish
+	}
+	return models.DefaultLanguage
+}
+
+// Fold は辞書の語と解析結果の基本形を照合するための正規形を返す
+// 日本語は辞書に基本形を明示するためそのまま、英語は見出し語から語末の e を除いた語幹
+// (規則ベースの見出し語化が hate / hated → hate / hat のように揺れても同じ語として照合できる)
+func Fold(lang models.Language, word string) string {
+	if lang != models.LanguageEnglish {
+		return word
+	}
+	return foldEnglish(word)
+}
@@ -0,0 +1,106 @@
+package language
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		text string
+		want models.Language
+	}{
+		{"今日は楽しかった", models.LanguageJapanese},
+		{"I had fun today", models.LanguageEnglish},
+		{"OKだよ", models.LanguageJapanese},
+		{"Bugが出た", models.LanguageJapanese},
+		{"ｗｗｗ", models.LanguageJapanese},
+		{"Thanks! 今日はありがとう", models.LanguageJapanese},
+		{"Deploy failed again, ugh. 最悪", models.LanguageEnglish},
+		{"123!", models.DefaultLanguage},
+		{"", models.DefaultLanguage},
+	}
+
+	for _, tt := range tests {
+		if got := Detect(tt.text); got != tt.want {
+			t.Errorf("Detect(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestEnglish_Tokenize(t *testing.T) {
+	tokens := NewEnglish().Tokenize("I don't feel well-known, can't you? It’s")
+
+	var surfaces, bases, pos []string
+	for _, tok := range tokens {
+		surfaces = append(surfaces, tok.Surface)
+		bases = append(bases, tok.BaseForm)
+		pos = append(pos, tok.Features[0])
+	}
+
+	wantSurfaces := []string{"I", "do", "n't", "feel", "well-known", ",", "ca", "n't", "you", "?", "It", "’s"}
+	wantBases := []string{"i", "do", "not", "feel", "well-known", ",", "can", "not", "you", "?", "it", "be"}
+	wantPOS := []string{
+		POSPronoun, POSAuxiliary, POSParticle, POSVerb, POSOther, POSPunctuation,
+		POSAuxiliary, POSParticle, POSPronoun, POSPunctuation, POSPronoun, POSAuxiliary,
+	}
+	if !slices.Equal(surfaces, wantSurfaces) {
+		t.Errorf("surfaces = %q, want %q", surfaces, wantSurfaces)
+	}
+	if !slices.Equal(bases, wantBases) {
+		t.Errorf("base forms = %q, want %q", bases, wantBases)
+	}
+	if !slices.Equal(pos, wantPOS) {
+		t.Errorf("POS = %q, want %q", pos, wantPOS)
+	}
+}
+
+func TestLemma(t *testing.T) {
+	tests := map[string]string{
+		"worries":  "worry",
+		"worried":  "worry",
+		"loving":   "love",
+		"loved":    "love",
+		"stopping": "stop",
+		"killed":   "kill",
+		"watches":  "watch",
+		"bugs":     "bug",
+		"felt":     "feel",
+		"news":     "news",
+		"morning":  "morning",
+		"agreed":   "agreed",
+		"bus":      "bus",
+	}
+
+	for word, want := range tests {
+		if got := lemma(word); got != want {
+			t.Errorf("lemma(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	// 見出し語化で語末の e が揺れても同じ正規形になる
+	for _, pair := range [][2]string{{"excited", "excite"}, {"hated", "hate"}, {"Loves", "love"}, {"scared", "scare"}} {
+		if a, b := Fold(models.LanguageEnglish, pair[0]), Fold(models.LanguageEnglish, pair[1]); a != b {
+			t.Errorf("Fold(%q) = %q, Fold(%q) = %q, want equal", pair[0], a, pair[1], b)
+		}
+	}
+	if got := Fold(models.LanguageJapanese, "嬉しい"); got != "嬉しい" {
+		t.Errorf("Fold(ja) = %q, want unchanged", got)
+	}
+}
+
+func TestJapanese_Tokenize(t *testing.T) {
+	ja, err := NewJapanese()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokens := ja.Tokenize("嬉しかった")
+	if len(tokens) == 0 || tokens[0].BaseForm != "嬉しい" || tokens[0].Features[0] != "形容詞" {
+		t.Errorf("Tokenize(嬉しかった) = %+v, want adjective with base form 嬉しい", tokens)
+	}
+}
@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/config"
+	"github.com/umekku/mind-os/internal/handlers"
+)
+
+// APIKeyHeader はAPIキーを指定するヘッダー (Authorization: Bearer の代わりに使用可能)
+const APIKeyHeader = "X-API-Key"
+
+// gin.Context に保存する認証情報のキー
+const (
+	AuthScopeKey = "auth.scope"  // config.Scope
+	AuthKeyIDKey = "auth.key_id" // ログ用のキー識別子 (キーのハッシュの先頭)
+)
+
+// AuthConfig は認証設定を保持する構造体
+type AuthConfig struct {
+	Keys []config.APIKeyGrant
+	// 認証を行わないパス ("/" で終わる場合はそのパス以下全て)
+	ExemptPaths []string
+}
+
+// hashedKey は比較用にハッシュ化したAPIキー
+type hashedKey struct {
+	hash  [sha256.Size]byte
+	id    string
+	scope config.Scope
+}
+
+// AuthMiddleware はAPIキーによる認証を行うミドルウェア
+// 【処理内容】
+// 1. Authorization: Bearer <key> または X-API-Key: <key> からキーを取り出す
+// 2. 登録済みの全てのキーと定数時間で比較する (一致したキーやキーの長さがタイミングから漏れないように)
+// 3. 参照系 (GET/HEAD) は read、それ以外は write 以上のスコープを要求する
+// キーが1つも設定されていない場合は認証を行わない (開発用)
+func AuthMiddleware(cfg AuthConfig) gin.HandlerFunc {
+	keys := make([]hashedKey, len(cfg.Keys))
+	for i, k := range cfg.Keys {
+		hash := sha256.Sum256([]byte(k.Key))
+		keys[i] = hashedKey{hash: hash, id: hex.EncodeToString(hash[:4]), scope: k.Scope}
+	}
+
+	if len(keys) == 0 {
+		slog.Warn("API authentication is disabled (no API keys configured)")
+		return func(c *gin.Context) {
+			c.Set(AuthScopeKey, config.ScopeAdmin)
+			c.Next()
+		}
+	}
+
+	return func(c *gin.Context) {
+		if isExemptPath(c.Request.URL.Path, cfg.ExemptPaths) {
+			c.Next()
+			return
+		}
+
+		token := extractAPIKey(c)
+		if token == "" {
+			unauthorized(c, "Missing API Key", "Provide an API key via 'Authorization: Bearer <key>' or '"+APIKeyHeader+": <key>'")
+			return
+		}
+
+		key, ok := matchAPIKey(keys, token)
+		if !ok {
+			unauthorized(c, "Invalid API Key", "The provided API key is not valid")
+			return
+		}
+
+		c.Set(AuthScopeKey, key.scope)
+		c.Set(AuthKeyIDKey, key.id)
+
+		required := config.ScopeWrite
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			required = config.ScopeRead
+		}
+		if !hasScope(c, required) {
+			forbidden(c, required)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireScope はルートに必要なスコープを指定するミドルウェア (AuthMiddleware の後に適用する)
+// 管理操作や、GET でも状態を変えるエンドポイント (WebSocket など) に使う
+func RequireScope(required config.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !hasScope(c, required) {
+			forbidden(c, required)
+			return
+		}
+		c.Next()
+	}
+}
+
+// extractAPIKey はリクエストからAPIキーを取り出す (Authorization ヘッダーを優先)
+func extractAPIKey(c *gin.Context) string {
+	if auth := c.GetHeader("Authorization"); auth != "" {
+		scheme, token, ok := strings.Cut(auth, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return strings.TrimSpace(c.GetHeader(APIKeyHeader))
+}
+
+// matchAPIKey は全てのキーと定数時間で比較し、一致したキーを返す
+func matchAPIKey(keys []hashedKey, token string) (hashedKey, bool) {
+	hash := sha256.Sum256([]byte(token))
+
+	var matched hashedKey
+	found := 0
+	for _, k := range keys {
+		// 一致しても比較を打ち切らない
+		eq := subtle.ConstantTimeCompare(hash[:], k.hash[:])
+		if eq == 1 {
+			matched = k
+		}
+		found |= eq
+	}
+	return matched, found == 1
+}
+
+// hasScope は認証済みのスコープが required 以上かを返す
+func hasScope(c *gin.Context, required config.Scope) bool {
+	scope, ok := c.Get(AuthScopeKey)
+	if !ok {
+		// 認証対象外のパス
+		return false
+	}
+	s, ok := scope.(config.Scope)
+	return ok && s >= required
+}
+
+// isExemptPath はパスが認証対象外かを返す
+func isExemptPath(path string, exempt []string) bool {
+	for _, p := range exempt {
+		if path == p || (strings.HasSuffix(p, "/") && strings.HasPrefix(path, p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// unauthorized は 401 を返して処理を中断する
+func unauthorized(c *gin.Context, title, detail string) {
+	c.Header("WWW-Authenticate", `Bearer realm="mind-os"`)
+	handlers.ErrorResponse(c, http.StatusUnauthorized, title, detail)
+	c.Abort()
+}
+
+// forbidden は 403 を返して処理を中断する
+func forbidden(c *gin.Context, required config.Scope) {
+	detail := fmt.Sprintf("This operation requires the '%s' scope", required)
+	if scope, ok := c.Get(AuthScopeKey); ok {
+		detail += fmt.Sprintf(" (API key has '%s')", scope)
+	}
+	handlers.ErrorResponse(c, http.StatusForbidden, "Insufficient Scope", detail)
+	c.Abort()
+}
@@ -0,0 +1,223 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/config"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// testAuthConfig はスコープごとに1つずつキーを持つ認証設定
+var testAuthConfig = AuthConfig{
+	Keys: []config.APIKeyGrant{
+		{Key: "read-key", Scope: config.ScopeRead},
+		{Key: "write-key", Scope: config.ScopeWrite},
+		{Key: "admin-key", Scope: config.ScopeAdmin},
+	},
+	ExemptPaths: []string{"/health", "/swagger/"},
+}
+
+// newAuthRouter は参照 (GET)・書き込み (POST)・管理 (DELETE + RequireScope) のルートを持つルーター
+func newAuthRouter(cfg AuthConfig) *gin.Engine {
+	r := gin.New()
+	r.Use(AuthMiddleware(cfg))
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/health", ok)
+	r.GET("/healthz", ok)
+	r.GET("/swagger/*any", ok)
+	r.GET("/api/v1/brain-states/current", ok)
+	r.POST("/api/v1/sensory-inputs", ok)
+	r.DELETE("/api/v1/brains/:id", RequireScope(config.ScopeAdmin), ok)
+	r.GET("/api/v1/conversations", RequireScope(config.ScopeWrite), ok)
+	return r
+}
+
+// serve はリクエストを処理して結果を返す (headers は名前と値の組)
+func serve(r http.Handler, method, path string, headers ...string) *httptest.ResponseRecorder {
+	return serveHost(r, method, path, "example.com", headers...)
+}
+
+// serveHost はリクエスト先のホストを指定してリクエストを処理する (同一オリジンの判定用)
+func serveHost(r http.Handler, method, path, host string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Host = host
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func bearer(key string) []string { return []string{"Authorization", "Bearer " + key} }
+func apiKey(key string) []string { return []string{APIKeyHeader, key} }
+
+func TestAuthMiddleware_ExtractKey(t *testing.T) {
+	r := newAuthRouter(testAuthConfig)
+
+	tests := []struct {
+		name    string
+		headers []string
+		want    int
+	}{
+		{"Bearer", bearer("read-key"), http.StatusOK},
+		{"Bearer (小文字)", []string{"Authorization", "bearer read-key"}, http.StatusOK},
+		{"X-API-Key", apiKey("read-key"), http.StatusOK},
+		{"前後の空白", apiKey("  read-key "), http.StatusOK},
+		{"Authorization を優先", append(bearer("read-key"), apiKey("unknown")...), http.StatusOK},
+		{"Bearer 以外の方式は X-API-Key を見る", append([]string{"Authorization", "Basic cmVhZC1rZXk="}, apiKey("read-key")...), http.StatusOK},
+		{"Bearer 以外の方式のみ", []string{"Authorization", "Basic read-key"}, http.StatusUnauthorized},
+		{"キーなし", nil, http.StatusUnauthorized},
+		{"未登録のキー", bearer("unknown"), http.StatusUnauthorized},
+		{"キーの前方一致", bearer("read"), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodGet, "/api/v1/brain-states/current", tt.headers...)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_Unauthorized(t *testing.T) {
+	r := newAuthRouter(testAuthConfig)
+
+	tests := []struct {
+		name      string
+		headers   []string
+		wantTitle string
+	}{
+		{"キーなし", nil, "Missing API Key"},
+		{"未登録のキー", apiKey("unknown"), "Invalid API Key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodGet, "/api/v1/brain-states/current", tt.headers...)
+			problem := decodeProblem(t, w, http.StatusUnauthorized)
+			if problem.Title != tt.wantTitle || problem.Instance != "/api/v1/brain-states/current" {
+				t.Errorf("problem = %+v, want title %q", problem, tt.wantTitle)
+			}
+			if got := w.Header().Get("WWW-Authenticate"); got != `Bearer realm="mind-os"` {
+				t.Errorf("WWW-Authenticate = %q", got)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_Scopes(t *testing.T) {
+	r := newAuthRouter(testAuthConfig)
+
+	// admin ⊃ write ⊃ read
+	tests := []struct {
+		key    string
+		method string
+		path   string
+		want   int
+	}{
+		{"read-key", http.MethodGet, "/api/v1/brain-states/current", http.StatusOK},
+		{"read-key", http.MethodPost, "/api/v1/sensory-inputs", http.StatusForbidden},
+		{"read-key", http.MethodDelete, "/api/v1/brains/alice", http.StatusForbidden},
+		{"read-key", http.MethodGet, "/api/v1/conversations", http.StatusForbidden},
+		{"write-key", http.MethodGet, "/api/v1/brain-states/current", http.StatusOK},
+		{"write-key", http.MethodPost, "/api/v1/sensory-inputs", http.StatusOK},
+		{"write-key", http.MethodDelete, "/api/v1/brains/alice", http.StatusForbidden},
+		{"write-key", http.MethodGet, "/api/v1/conversations", http.StatusOK},
+		{"admin-key", http.MethodGet, "/api/v1/brain-states/current", http.StatusOK},
+		{"admin-key", http.MethodPost, "/api/v1/sensory-inputs", http.StatusOK},
+		{"admin-key", http.MethodDelete, "/api/v1/brains/alice", http.StatusOK},
+		{"admin-key", http.MethodGet, "/api/v1/conversations", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"/"+tt.method+" "+tt.path, func(t *testing.T) {
+			w := serve(r, tt.method, tt.path, bearer(tt.key)...)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+
+	// 不足しているスコープと付与されたスコープを伝える
+	w := serve(r, http.MethodDelete, "/api/v1/brains/alice", bearer("write-key")...)
+	problem := decodeProblem(t, w, http.StatusForbidden)
+	if want := "This operation requires the 'admin' scope (API key has 'write')"; problem.Title != "Insufficient Scope" || problem.Detail != want {
+		t.Errorf("problem = %+v, want Insufficient Scope: %q", problem, want)
+	}
+}
+
+func TestAuthMiddleware_ExemptPaths(t *testing.T) {
+	tests := []struct {
+		name   string
+		exempt []string
+		path   string
+		want   int
+	}{
+		{"ヘルスチェック", []string{"/health"}, "/health", http.StatusOK},
+		{"完全一致のみ", []string{"/health"}, "/healthz", http.StatusUnauthorized},
+		{"Swagger UI を公開", []string{"/health", "/swagger/"}, "/swagger/index.html", http.StatusOK},
+		{"Swagger UI を公開しない", []string{"/health"}, "/swagger/index.html", http.StatusUnauthorized},
+		{"対象外でない API", []string{"/health", "/swagger/"}, "/api/v1/brain-states/current", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testAuthConfig
+			cfg.ExemptPaths = tt.exempt
+			w := serve(newAuthRouter(cfg), http.MethodGet, tt.path)
+			if w.Code != tt.want {
+				t.Errorf("GET %s status = %d, want %d", tt.path, w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_NoKeys(t *testing.T) {
+	// キーが設定されていない場合は認証せず、管理操作も許可する (開発用)
+	r := newAuthRouter(AuthConfig{})
+	if w := serve(r, http.MethodDelete, "/api/v1/brains/alice"); w.Code != http.StatusOK {
+		t.Errorf("DELETE without keys configured: status = %d, want 200", w.Code)
+	}
+}
+
+func TestRequireScope_WithoutAuth(t *testing.T) {
+	// 認証されていない (スコープがない) リクエストは拒否する
+	r := gin.New()
+	r.GET("/admin", RequireScope(config.ScopeRead), func(c *gin.Context) { c.Status(http.StatusOK) })
+	w := serve(r, http.MethodGet, "/admin")
+	problem := decodeProblem(t, w, http.StatusForbidden)
+	if problem.Detail != "This operation requires the 'read' scope" {
+		t.Errorf("Detail = %q", problem.Detail)
+	}
+}
+
+// decodeProblem はステータスと Content-Type を確認して RFC 9457 の問題詳細を読み取る
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder, wantStatus int) models.ProblemDetails {
+	t.Helper()
+	if w.Code != wantStatus {
+		t.Fatalf("status = %d, want %d (body %s)", w.Code, wantStatus, w.Body)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("Content-Type = %q, want application/problem+json", ct)
+	}
+	var problem models.ProblemDetails
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("decode problem: %v (body %s)", err, w.Body)
+	}
+	if problem.Status != wantStatus {
+		t.Errorf("problem.Status = %d, want %d", problem.Status, wantStatus)
+	}
+	return problem
+}
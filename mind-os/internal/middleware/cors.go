@@ -2,31 +2,159 @@ package middleware
 
 import (
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/handlers"
 )
 
 // CORSConfig はCORS設定を保持する構造体
 type CORSConfig struct {
-	AllowOrigins []string
+	// 許可するオリジン
+	// "*" は全て、"https://app.example.com" は完全一致、"https://*.example.com" はサブドメイン (example.com 自体は含まない)
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	MaxAge           int // プリフライトのキャッシュ時間 (秒)。0 の場合は Access-Control-Max-Age を付けない
+}
+
+// originMatcher は許可するオリジンの判定
+type originMatcher struct {
+	any       bool
+	exact     map[string]struct{}
+	wildcards [][2]string // {"https://", ".example.com"} (scheme:// と .domain[:port])
+}
+
+// newOriginMatcher は許可するオリジンのリストから判定器を作成
+func newOriginMatcher(origins []string) originMatcher {
+	m := originMatcher{exact: make(map[string]struct{})}
+	for _, origin := range origins {
+		origin = strings.ToLower(strings.TrimSuffix(origin, "/"))
+		switch {
+		case origin == "*":
+			m.any = true
+		case strings.Contains(origin, "://*."):
+			scheme, domain, _ := strings.Cut(origin, "://*")
+			m.wildcards = append(m.wildcards, [2]string{scheme + "://", domain})
+		default:
+			m.exact[origin] = struct{}{}
+		}
+	}
+	return m
+}
+
+// allows はオリジンが許可されているかを返す
+func (m originMatcher) allows(origin string) bool {
+	if m.any {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if _, ok := m.exact[origin]; ok {
+		return true
+	}
+	for _, w := range m.wildcards {
+		prefix, suffix := w[0], w[1]
+		if !strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+			continue
+		}
+		// サブドメイン部分 (1つ以上のラベル) にポートやパスが紛れていないこと
+		sub := origin[len(prefix) : len(origin)-len(suffix)]
+		if sub != "" && !strings.ContainsAny(sub, ":/@") {
+			return true
+		}
+	}
+	return false
 }
 
 // CORSMiddleware はCORSヘッダーを設定するミドルウェア
-func CORSMiddleware() gin.HandlerFunc {
+// 【処理内容】
+// 1. Origin ヘッダーのないリクエスト (サーバー間通信・curl など) と同一オリジンのリクエストはそのまま通す
+// 2. 許可されていないオリジンからのリクエストは 403 で拒否する
+// 3. プリフライト (OPTIONS + Access-Control-Request-Method) には許可するメソッド・ヘッダーとキャッシュ時間を返す
+// 4. 実際のリクエストには Access-Control-Allow-Origin などを付与する
+func CORSMiddleware(cfg CORSConfig) gin.HandlerFunc {
+	origins := newOriginMatcher(cfg.AllowOrigins)
+	allowMethods := strings.Join(cfg.AllowMethods, ", ")
+	allowHeaders := strings.Join(cfg.AllowHeaders, ", ")
+	exposeHeaders := strings.Join(cfg.ExposeHeaders, ", ")
+	maxAge := ""
+	if cfg.MaxAge > 0 {
+		maxAge = strconv.Itoa(cfg.MaxAge)
+	}
+
 	return func(c *gin.Context) {
-		// 許可するオリジン（開発環境用）
-		// 本番環境では設定ファイル等から読み込むことを推奨
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
-
-		// OPTIONSメソッド（プリフライトリクエスト）の処理
-		if c.Request.Method == "OPTIONS" {
+		origin := c.GetHeader("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		// 応答がオリジンによって変わることをキャッシュに伝える
+		c.Writer.Header().Add("Vary", "Origin")
+		if preflight {
+			c.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+			c.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if origin == "" || (!preflight && isSameOrigin(origin, c.Request.Host)) {
+			c.Next()
+			return
+		}
+
+		if !origins.allows(origin) {
+			handlers.ErrorResponse(c, http.StatusForbidden, "Origin Not Allowed", "Cross-origin requests from '"+origin+"' are not allowed")
+			c.Abort()
+			return
+		}
+
+		if origins.any && !cfg.AllowCredentials {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+		}
+		if cfg.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		// プリフライトリクエストの処理
+		if preflight {
+			method := c.GetHeader("Access-Control-Request-Method")
+			if !slices.ContainsFunc(cfg.AllowMethods, func(m string) bool { return strings.EqualFold(m, method) }) {
+				handlers.ErrorResponse(c, http.StatusForbidden, "Method Not Allowed", "Cross-origin "+method+" requests are not allowed")
+				c.Abort()
+				return
+			}
+			c.Header("Access-Control-Allow-Methods", allowMethods)
+			c.Header("Access-Control-Allow-Headers", allowHeaders)
+			if maxAge != "" {
+				c.Header("Access-Control-Max-Age", maxAge)
+			}
 			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
 
+		if exposeHeaders != "" {
+			c.Header("Access-Control-Expose-Headers", exposeHeaders)
+		}
 		c.Next()
 	}
 }
+
+// OriginChecker は CORSMiddleware と同じ基準でリクエストのオリジンを検証する関数を返す
+// 【用途】WebSocket のハンドシェイク (websocket.Upgrader.CheckOrigin)。
+// Origin ヘッダーのないリクエストと同一オリジンのリクエストは許可し、それ以外は許可リストで判定する
+func OriginChecker(cfg CORSConfig) func(r *http.Request) bool {
+	origins := newOriginMatcher(cfg.AllowOrigins)
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		return origin == "" || isSameOrigin(origin, r.Host) || origins.allows(origin)
+	}
+}
+
+// isSameOrigin は Origin がリクエスト先のホストと同じかを返す (Swagger UI など自身が配信するページからのリクエスト)
+func isSameOrigin(origin, host string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, host)
+}
@@ -0,0 +1,234 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOriginMatcher_Allows(t *testing.T) {
+	m := newOriginMatcher([]string{"https://app.example.org/", "https://*.example.com", "http://*.local.test:8080"})
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		// 完全一致 (末尾の "/" と大文字小文字は区別しない)
+		{"https://app.example.org", true},
+		{"HTTPS://APP.EXAMPLE.ORG", true},
+		{"http://app.example.org", false},
+		{"https://app.example.org:8443", false},
+		// サブドメインのワイルドカード
+		{"https://a.example.com", true},
+		{"https://a.b.example.com", true},
+		{"https://example.com", false},
+		{"https://.example.com", false},
+		{"https://evilexample.com", false},
+		{"https://a.example.com.evil.com", false},
+		{"http://a.example.com", false},
+		{"https://a.example.com:8443", false},
+		{"https://evil.com/.example.com", false},
+		{"https://user@a.example.com", false},
+		// ポート付きのワイルドカード
+		{"http://a.local.test:8080", true},
+		{"http://a.local.test", false},
+		{"http://a.local.test:9090", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			if got := m.allows(tt.origin); got != tt.want {
+				t.Errorf("allows(%q) = %v, want %v", tt.origin, got, tt.want)
+			}
+		})
+	}
+
+	if !newOriginMatcher([]string{"*"}).allows("https://anything.test") {
+		t.Error(`"*" should allow any origin`)
+	}
+}
+
+// testCORSConfig はサブドメインのワイルドカードを許可する CORS 設定
+var testCORSConfig = CORSConfig{
+	AllowOrigins:  []string{"https://*.example.com"},
+	AllowMethods:  []string{"GET", "POST", "DELETE"},
+	AllowHeaders:  []string{"Content-Type", "Authorization"},
+	ExposeHeaders: []string{"ETag"},
+	MaxAge:        600,
+}
+
+func newCORSRouter(cfg CORSConfig) http.Handler {
+	r := gin.New()
+	r.Use(CORSMiddleware(cfg))
+	r.Any("/api/v1/brains", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func TestCORSMiddleware_Preflight(t *testing.T) {
+	r := newCORSRouter(testCORSConfig)
+
+	w := serve(r, http.MethodOptions, "/api/v1/brains",
+		"Origin", "https://a.example.com",
+		"Access-Control-Request-Method", "DELETE",
+		"Access-Control-Request-Headers", "Authorization",
+	)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204 (body %s)", w.Code, w.Body)
+	}
+
+	h := w.Header()
+	want := map[string]string{
+		"Access-Control-Allow-Origin":  "https://a.example.com",
+		"Access-Control-Allow-Methods": "GET, POST, DELETE",
+		"Access-Control-Allow-Headers": "Content-Type, Authorization",
+		"Access-Control-Max-Age":       "600",
+	}
+	for name, value := range want {
+		if got := h.Get(name); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+	if vary := h.Values("Vary"); !slices.Contains(vary, "Origin") || !slices.Contains(vary, "Access-Control-Request-Method") {
+		t.Errorf("Vary = %v, want Origin and Access-Control-Request-Method", vary)
+	}
+	if got := h.Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Access-Control-Allow-Credentials = %q, want unset", got)
+	}
+
+	// 許可していないメソッドのプリフライトは拒否する
+	w = serve(r, http.MethodOptions, "/api/v1/brains",
+		"Origin", "https://a.example.com",
+		"Access-Control-Request-Method", "PUT",
+	)
+	if problem := decodeProblem(t, w, http.StatusForbidden); problem.Title != "Method Not Allowed" {
+		t.Errorf("Title = %q, want Method Not Allowed", problem.Title)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Methods"); got != "" {
+		t.Errorf("Access-Control-Allow-Methods = %q for a disallowed method, want unset", got)
+	}
+
+	// MaxAge が 0 の場合は Access-Control-Max-Age を付けない
+	cfg := testCORSConfig
+	cfg.MaxAge = 0
+	w = serve(newCORSRouter(cfg), http.MethodOptions, "/api/v1/brains",
+		"Origin", "https://a.example.com",
+		"Access-Control-Request-Method", "GET",
+	)
+	if got := w.Header().Get("Access-Control-Max-Age"); w.Code != http.StatusNoContent || got != "" {
+		t.Errorf("status = %d, Access-Control-Max-Age = %q, want 204 and unset", w.Code, got)
+	}
+}
+
+func TestCORSMiddleware_Origins(t *testing.T) {
+	r := newCORSRouter(testCORSConfig)
+
+	tests := []struct {
+		name       string
+		origin     string
+		host       string
+		wantStatus int
+		wantACAO   string
+	}{
+		{"許可したサブドメイン", "https://a.example.com", "api.example.net", http.StatusOK, "https://a.example.com"},
+		{"ドメイン自体", "https://example.com", "api.example.net", http.StatusForbidden, ""},
+		{"似たドメイン", "https://evilexample.com", "api.example.net", http.StatusForbidden, ""},
+		{"後ろに続くドメイン", "https://a.example.com.evil.com", "api.example.net", http.StatusForbidden, ""},
+		{"異なるスキーム", "http://a.example.com", "api.example.net", http.StatusForbidden, ""},
+		{"Origin なし", "", "api.example.net", http.StatusOK, ""},
+		{"同一オリジン", "https://api.example.net", "api.example.net", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var headers []string
+			if tt.origin != "" {
+				headers = []string{"Origin", tt.origin}
+			}
+			w := serveHost(r, http.MethodGet, "/api/v1/brains", tt.host, headers...)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantACAO {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantACAO)
+			}
+			if !slices.Contains(w.Header().Values("Vary"), "Origin") {
+				t.Errorf("Vary = %v, want Origin", w.Header().Values("Vary"))
+			}
+			if tt.wantStatus == http.StatusForbidden {
+				if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+					t.Errorf("Access-Control-Allow-Credentials = %q for a disallowed origin, want unset", got)
+				}
+				decodeProblem(t, w, http.StatusForbidden)
+			}
+		})
+	}
+}
+
+func TestCORSMiddleware_Credentials(t *testing.T) {
+	tests := []struct {
+		name            string
+		origins         []string
+		credentials     bool
+		wantACAO        string
+		wantCredentials string
+	}{
+		// 認証情報を許可しない場合、"*" はそのまま返す
+		{"全て許可", []string{"*"}, false, "*", ""},
+		// 認証情報を許可する場合はオリジンを明示し、"*" と組み合わせない
+		{"全て許可 + 認証情報", []string{"*"}, true, "https://a.example.com", "true"},
+		{"明示したオリジン + 認証情報", []string{"https://a.example.com"}, true, "https://a.example.com", "true"},
+		{"明示したオリジン", []string{"https://a.example.com"}, false, "https://a.example.com", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testCORSConfig
+			cfg.AllowOrigins, cfg.AllowCredentials = tt.origins, tt.credentials
+			w := serve(newCORSRouter(cfg), http.MethodGet, "/api/v1/brains", "Origin", "https://a.example.com")
+
+			h := w.Header()
+			if got := h.Get("Access-Control-Allow-Origin"); got != tt.wantACAO {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantACAO)
+			}
+			if got := h.Get("Access-Control-Allow-Credentials"); got != tt.wantCredentials {
+				t.Errorf("Access-Control-Allow-Credentials = %q, want %q", got, tt.wantCredentials)
+			}
+			if got := h.Get("Access-Control-Expose-Headers"); got != "ETag" {
+				t.Errorf("Access-Control-Expose-Headers = %q, want ETag", got)
+			}
+		})
+	}
+}
+
+func TestOriginChecker(t *testing.T) {
+	check := OriginChecker(testCORSConfig)
+
+	tests := []struct {
+		name   string
+		origin string
+		host   string
+		want   bool
+	}{
+		{"許可したサブドメイン", "https://a.example.com", "api.example.net", true},
+		{"ドメイン自体", "https://example.com", "api.example.net", false},
+		{"後ろに続くドメイン", "https://a.example.com.evil.com", "api.example.net", false},
+		{"異なるスキーム", "http://a.example.com", "api.example.net", false},
+		{"Origin なし", "", "api.example.net", true},
+		{"同一オリジン", "https://api.example.net", "api.example.net", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/api/v1/conversations/ws", nil)
+			req.Host = tt.host
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if got := check(req); got != tt.want {
+				t.Errorf("OriginChecker(%q, host %q) = %v, want %v", tt.origin, tt.host, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,16 +2,31 @@ package middleware
 
 import (
 	"log/slog"
-	"os"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
+// httpRequestDuration はHTTPリクエストの処理時間
+// route はパスのテンプレート (/api/v1/brains/:brainId/...) で、脳IDやUUIDごとに系列が増えないようにする
+var httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "mindos_http_request_duration_seconds",
+	Help:    "HTTP request latency, by method, route template and status code.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"method", "route", "status"})
+
+// RegisterMetrics はHTTPのメトリクスを登録する
+func RegisterMetrics(reg prometheus.Registerer) error {
+	return reg.Register(httpRequestDuration)
+}
+
 // LoggerMiddleware はリクエスト情報を構造化ログ（slog）として出力するミドルウェア
+// 処理時間はメトリクス (mindos_http_request_duration_seconds) にも記録する
 func LoggerMiddleware() gin.HandlerFunc {
-	// JSONハンドラーを使用（実運用ではログ収集基盤に合わせて調整）
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	// アプリケーション全体のロガー (JSON形式, LOG_LEVEL を反映) を使用
+	logger := slog.Default()
 
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -29,6 +44,12 @@ func LoggerMiddleware() gin.HandlerFunc {
 		statusCode := c.Writer.Status()
 		errorMessage := c.Errors.ByType(gin.ErrorTypePrivate).String()
 
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched" // ルートが存在しないパス (404) は1つの系列にまとめる
+		}
+		httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(statusCode)).Observe(latency.Seconds())
+
 		if raw != "" {
 			path = path + "?" + raw
 		}
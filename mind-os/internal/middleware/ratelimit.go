@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/handlers"
+	"github.com/umekku/mind-os/internal/ratelimit"
+)
+
+// RateLimitConfig はレート制限の設定を保持する構造体
+type RateLimitConfig struct {
+	Read  ratelimit.Limiter // 参照系 (GET/HEAD) の予算
+	Write ratelimit.Limiter // 状態を変える操作の予算
+}
+
+// RateLimitMiddleware はクライアントごとにリクエスト数を制限するミドルウェア (AuthMiddleware の後に適用する)
+// 【神経科学的意味】1つのクライアントが入力を浴びせ続けると、コルチゾールが上限に張り付き理性が枯渇して
+// 同じ脳を使う全員に影響するため、入力の頻度を抑える
+// 【処理内容】
+// 1. APIキー (なければクライアントIP) ごとに、参照系と状態を変える操作で別々の予算を消費する
+// 2. RateLimit-Limit / RateLimit-Remaining / RateLimit-Reset ヘッダーで残りの予算を伝える
+// 3. 予算を使い切ったら Retry-After を付けて 429 を返す
+func RateLimitMiddleware(cfg RateLimitConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limiter, budget := cfg.Write, "write"
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			limiter, budget = cfg.Read, "read"
+		}
+
+		client := rateLimitClient(c)
+		c.Set(ratelimit.ClientContextKey, client)
+
+		result := limiter.Allow(client)
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+		if !result.Allowed {
+			retryAfter := ceilSeconds(result.RetryAfter)
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			handlers.ErrorResponse(c, http.StatusTooManyRequests, "Too Many Requests",
+				fmt.Sprintf("Rate limit for %s requests exceeded (%d in a burst). Retry after %d seconds", budget, result.Limit, retryAfter))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitClient はレート制限の対象となるクライアントの識別子を返す
+// 認証済みならAPIキー (同じキーを複数の端末で使っても合算する)、そうでなければクライアントIP
+func rateLimitClient(c *gin.Context) string {
+	if id := c.GetString(AuthKeyIDKey); id != "" {
+		return "key:" + id
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds は時間を秒単位に切り上げる
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/ratelimit"
+)
+
+// newRateLimitRouter は認証とレート制限 (参照・書き込みとも1秒に1件、連続2件まで) を適用したルーター
+// 制限の対象となったクライアントを X-Client で返す
+func newRateLimitRouter() *gin.Engine {
+	policy := ratelimit.Policy{PerMinute: 60, Burst: 2}
+	r := gin.New()
+	r.Use(AuthMiddleware(testAuthConfig))
+	r.Use(RateLimitMiddleware(RateLimitConfig{Read: ratelimit.NewMemory(policy), Write: ratelimit.NewMemory(policy)}))
+
+	ok := func(c *gin.Context) {
+		c.Header("X-Client", c.GetString(ratelimit.ClientContextKey))
+		c.Status(http.StatusOK)
+	}
+	r.GET("/health", ok)
+	r.GET("/api/v1/brain-states/current", ok)
+	r.POST("/api/v1/sensory-inputs", ok)
+	return r
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	r := newRateLimitRouter()
+
+	// 同じルーターに順に送る (予算は前のリクエストの消費を引き継ぐ)
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		headers       []string
+		wantStatus    int
+		wantRemaining int
+		wantReset     int // 秒 (切り上げ)
+	}{
+		{"参照の1件目", http.MethodGet, "/api/v1/brain-states/current", bearer("write-key"), http.StatusOK, 1, 1},
+		{"参照の2件目", http.MethodGet, "/api/v1/brain-states/current", bearer("write-key"), http.StatusOK, 0, 2},
+		{"参照の予算切れ", http.MethodGet, "/api/v1/brain-states/current", bearer("write-key"), http.StatusTooManyRequests, 0, 2},
+		{"書き込みは別の予算", http.MethodPost, "/api/v1/sensory-inputs", bearer("write-key"), http.StatusOK, 1, 1},
+		{"別のキーは別の予算", http.MethodGet, "/api/v1/brain-states/current", bearer("admin-key"), http.StatusOK, 1, 1},
+		{"同じキーは送り方によらず合算", http.MethodGet, "/api/v1/brain-states/current", apiKey("write-key"), http.StatusTooManyRequests, 0, 2},
+		{"認証対象外はIPごと", http.MethodGet, "/health", nil, http.StatusOK, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, tt.method, tt.path, tt.headers...)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body)
+			}
+			headers := map[string]string{
+				"RateLimit-Limit":     "2",
+				"RateLimit-Remaining": strconv.Itoa(tt.wantRemaining),
+				"RateLimit-Reset":     strconv.Itoa(tt.wantReset),
+			}
+			for name, want := range headers {
+				if got := w.Header().Get(name); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+
+			if tt.wantStatus == http.StatusOK {
+				if got := w.Header().Get("Retry-After"); got != "" {
+					t.Errorf("Retry-After = %q on an allowed request, want unset", got)
+				}
+				return
+			}
+			if got := w.Header().Get("Retry-After"); got != "1" {
+				t.Errorf("Retry-After = %q, want 1", got)
+			}
+			problem := decodeProblem(t, w, http.StatusTooManyRequests)
+			if want := "Rate limit for read requests exceeded (2 in a burst). Retry after 1 seconds"; problem.Title != "Too Many Requests" || problem.Detail != want {
+				t.Errorf("problem = %+v, want Too Many Requests: %q", problem, want)
+			}
+		})
+	}
+}
+
+func TestRateLimitMiddleware_Client(t *testing.T) {
+	r := newRateLimitRouter()
+
+	tests := []struct {
+		name    string
+		path    string
+		headers []string
+		want    string
+	}{
+		{"APIキー", "/api/v1/brain-states/current", bearer("read-key"), "key:"},
+		{"認証対象外", "/health", nil, "ip:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, http.MethodGet, tt.path, tt.headers...)
+			if got := w.Header().Get("X-Client"); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("client = %q, want prefix %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Affect は次元的な感情 (VAD: Valence / Arousal / Dominance) を表す構造体
+// 【心理学的意味】ラッセルのコアアフェクト (快-不快 × 覚醒) に、メラビアンの PAD モデルの支配感を加えた連続的な感情空間
+// 各軸は -1.0 〜 1.0 で、原点 (0, 0, 0) は中立
+type Affect struct {
+	Valence   float64 `json:"valence"`   // 感情価: 不快 (-1.0) 〜 快 (1.0)
+	Arousal   float64 `json:"arousal"`   // 覚醒度: 沈静 (-1.0) 〜 興奮 (1.0)
+	Dominance float64 `json:"dominance"` // 支配感: 無力・服従 (-1.0) 〜 統制・支配 (1.0)
+}
+
+// EmotionAffects は EMO v1.1 の感情コードの VAD 空間での代表点 (強度 100 の場合)
+// 【根拠】Mehrabian & Russell の PAD 値 (joyful, surprised, angry, fearful, loved, disgusted, hopeful, sad) に基づく
+// 感情コードと VAD の相互変換 (AffectOf / Affect.Emotion) はこの表を使う
+var EmotionAffects = map[EmotionCode]Affect{
+	EmotionJoy:      {Valence: 0.76, Arousal: 0.48, Dominance: 0.35},
+	EmotionSurprise: {Valence: 0.40, Arousal: 0.67, Dominance: -0.13},
+	EmotionAnger:    {Valence: -0.51, Arousal: 0.59, Dominance: 0.25},
+	EmotionFear:     {Valence: -0.64, Arousal: 0.60, Dominance: -0.43},
+	EmotionLove:     {Valence: 0.87, Arousal: 0.54, Dominance: -0.18},
+	EmotionDisgust:  {Valence: -0.60, Arousal: 0.35, Dominance: 0.11},
+	EmotionHope:     {Valence: 0.51, Arousal: 0.23, Dominance: 0.14},
+	EmotionGrief:    {Valence: -0.63, Arousal: -0.27, Dominance: -0.33},
+	EmotionNeutral:  {},
+}
+
+// neutralAffectThreshold は中立とみなす VAD ベクトルの大きさの上限
+const neutralAffectThreshold = 0.1
+
+// neutralEmotionValue は VAD が中立の場合の感情値 (扁桃体で何も反応しなかった場合と同じ)
+var neutralEmotionValue = EmotionValue{Code: EmotionNeutral, Value: 10}
+
+// AffectOf は感情コードと強度 (0-100) に対応する VAD を返す (代表点を強度で縮小した点)
+func AffectOf(code EmotionCode, value int) Affect {
+	return EmotionAffects[code].Scale(float64(value) / 100.0)
+}
+
+// AffectFromEmotions は感情値のリストを VAD に変換する
+// 【処理内容】各感情の VAD を加算し、各軸を -1.0 〜 1.0 に収める (感情値の加算・上限と同じ扱い)
+func AffectFromEmotions(values []EmotionValue) Affect {
+	var sum Affect
+	for _, v := range values {
+		sum = sum.Add(AffectOf(v.Code, v.Value))
+	}
+	return sum.Clamp()
+}
+
+// Add は2つの VAD を加算する (範囲の制限は行わない)
+func (a Affect) Add(b Affect) Affect {
+	return Affect{
+		Valence:   a.Valence + b.Valence,
+		Arousal:   a.Arousal + b.Arousal,
+		Dominance: a.Dominance + b.Dominance,
+	}
+}
+
+// Scale は VAD の各軸に倍率を掛ける
+func (a Affect) Scale(factor float64) Affect {
+	return Affect{
+		Valence:   a.Valence * factor,
+		Arousal:   a.Arousal * factor,
+		Dominance: a.Dominance * factor,
+	}
+}
+
+// Clamp は VAD の各軸を -1.0 〜 1.0 に収める
+func (a Affect) Clamp() Affect {
+	return Affect{
+		Valence:   clampUnit(a.Valence),
+		Arousal:   clampUnit(a.Arousal),
+		Dominance: clampUnit(a.Dominance),
+	}
+}
+
+// Magnitude は VAD ベクトルの大きさ (原点 = 中立からの距離) を返す
+func (a Affect) Magnitude() float64 {
+	return math.Sqrt(a.dot(a))
+}
+
+// Emotion は VAD に最も近い感情コードと強度を返す
+// 【処理内容】
+// 1. 原点に近い (大きさが閾値未満) 場合は Neutral
+// 2. 方向が最も近い (コサイン類似度が最大の) 代表点の感情コードを選ぶ
+// 3. 代表点への射影の長さを強度 (代表点 = 100) とする
+// 中立とみなされない強度であれば、AffectOf(code, value).Emotion() は元の感情コードと強度に戻る
+func (a Affect) Emotion() EmotionValue {
+	magnitude := a.Magnitude()
+	if magnitude < neutralAffectThreshold {
+		return neutralEmotionValue
+	}
+
+	best := EmotionNeutral
+	bestSimilarity := math.Inf(-1)
+	for _, code := range affectCodes {
+		p := EmotionAffects[code]
+		similarity := a.dot(p) / (magnitude * p.Magnitude())
+		if similarity > bestSimilarity {
+			best, bestSimilarity = code, similarity
+		}
+	}
+
+	p := EmotionAffects[best]
+	value := int(math.Round(100 * a.dot(p) / p.dot(p)))
+	if value < 1 {
+		return neutralEmotionValue
+	}
+	if value > 100 {
+		value = 100
+	}
+	return EmotionValue{Code: best, Value: value}
+}
+
+// Validate は VAD の各軸が -1.0 〜 1.0 の範囲内かチェック
+func (a Affect) Validate() error {
+	var errs []error
+	for _, axis := range []struct {
+		name  string
+		value float64
+	}{
+		{"valence", a.Valence},
+		{"arousal", a.Arousal},
+		{"dominance", a.Dominance},
+	} {
+		if math.IsNaN(axis.value) || axis.value < -1 || axis.value > 1 {
+			errs = append(errs, fmt.Errorf("%s %v must be in [-1, 1]", axis.name, axis.value))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dot は VAD ベクトルの内積
+func (a Affect) dot(b Affect) float64 {
+	return a.Valence*b.Valence + a.Arousal*b.Arousal + a.Dominance*b.Dominance
+}
+
+// affectCodes は VAD から選ぶ感情コード (Neutral は原点のため除く。順序は結果を決定的にするため固定)
+var affectCodes = []EmotionCode{
+	EmotionJoy, EmotionSurprise, EmotionAnger, EmotionFear,
+	EmotionLove, EmotionDisgust, EmotionHope, EmotionGrief,
+}
+
+// clampUnit は値を -1.0 〜 1.0 に収める
+func clampUnit(v float64) float64 {
+	return math.Max(-1, math.Min(1, v))
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+// TestAffect_RoundTrip は感情コード → VAD → 感情コードの変換で元に戻ることをテスト
+func TestAffect_RoundTrip(t *testing.T) {
+	for _, code := range affectCodes {
+		for _, value := range []int{30, 75, 100} {
+			got := AffectOf(code, value).Emotion()
+			if got.Code != code || got.Value != value {
+				t.Errorf("AffectOf(%s, %d).Emotion() = %+v", code, value, got)
+			}
+		}
+	}
+}
+
+// TestAffect_Emotion は VAD 空間の点に最も近い感情コードをテスト
+func TestAffect_Emotion(t *testing.T) {
+	tests := []struct {
+		name   string
+		affect Affect
+		want   EmotionCode
+	}{
+		{"原点", Affect{}, EmotionNeutral},
+		{"閾値未満", Affect{Valence: 0.05, Arousal: 0.05}, EmotionNeutral},
+		{"快・興奮・統制", Affect{Valence: 0.8, Arousal: 0.5, Dominance: 0.4}, EmotionJoy},
+		{"不快・沈静・無力", Affect{Valence: -0.6, Arousal: -0.4, Dominance: -0.3}, EmotionGrief},
+		{"不快・興奮・無力", Affect{Valence: -0.5, Arousal: 0.7, Dominance: -0.6}, EmotionFear},
+		{"不快・興奮・統制", Affect{Valence: -0.5, Arousal: 0.7, Dominance: 0.4}, EmotionAnger},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.affect.Emotion(); got.Code != tt.want {
+				t.Errorf("Emotion() = %+v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAffectFromEmotions は感情値のリストの VAD への合成をテスト
+func TestAffectFromEmotions(t *testing.T) {
+	// 喜びと恐れの混合は快・不快が打ち消し合い、覚醒度は高まる
+	mixed := AffectFromEmotions([]EmotionValue{
+		{Code: EmotionJoy, Value: 80},
+		{Code: EmotionFear, Value: 80},
+	})
+	if math.Abs(mixed.Valence) > 0.15 {
+		t.Errorf("mixed Valence = %v, want near 0", mixed.Valence)
+	}
+	if mixed.Arousal < 0.8 {
+		t.Errorf("mixed Arousal = %v, want > 0.8", mixed.Arousal)
+	}
+
+	// 各軸は -1.0 〜 1.0 に収まる
+	strong := AffectFromEmotions([]EmotionValue{
+		{Code: EmotionLove, Value: 100},
+		{Code: EmotionJoy, Value: 100},
+	})
+	if strong.Valence != 1 || strong.Validate() != nil {
+		t.Errorf("strong = %+v, want Valence clamped to 1", strong)
+	}
+
+	if got := AffectFromEmotions([]EmotionValue{{Code: EmotionNeutral, Value: 10}}); got != (Affect{}) {
+		t.Errorf("neutral = %+v, want origin", got)
+	}
+}
+
+// TestAffect_Validate は VAD の範囲チェックをテスト
+func TestAffect_Validate(t *testing.T) {
+	if err := (Affect{Valence: 1, Arousal: -1, Dominance: 0}).Validate(); err != nil {
+		t.Errorf("boundary values should be valid: %v", err)
+	}
+	if err := (Affect{Valence: 1.5, Dominance: math.NaN()}).Validate(); err == nil {
+		t.Error("out of range values should be invalid")
+	}
+}
@@ -0,0 +1,122 @@
+package models
+
+// Explanation は1回の入力に対する感情の処理の根拠 (API の ?explain=true で返す)
+// 【役割】キャラクターの反応が不自然な時に、どの語・どの脳領域の調整が感情を生んだのかを追跡する
+type Explanation struct {
+	ThalamusGain float64             `json:"thalamusGain"`          // 視床のゲイン (順応・新奇性による感情値の倍率)
+	Assessment   *AssessmentTrace    `json:"assessment,omitempty"`  // 扁桃体の評価 (会話の入力のみ)
+	Empathy      *EmpathyTrace       `json:"empathy,omitempty"`     // ミラーニューロンによる情動伝染 (会話の入力のみ)
+	Sensitivity  *SensitivityTrace   `json:"sensitivity,omitempty"` // ゲイン・気質・概日リズムによる感度の調整 (会話の入力のみ)
+	Arbitration  ArbitrationTrace    `json:"arbitration"`           // 前頭前皮質による調整
+	Emotions     []EmotionValue      `json:"emotions"`              // 最終的な反応感情
+	Stages       []EmotionStageTrace `json:"stages"`                // 処理段階ごとの感情 (段階の順)
+}
+
+// EmotionStageTrace は処理段階の直後の感情
+type EmotionStageTrace struct {
+	Stage    string         `json:"stage"` // "amygdala", "physical", "empathy", "sensitivity", "pfc"
+	Emotions []EmotionValue `json:"emotions"`
+}
+
+// MatchForm は辞書の語が入力のどの形と一致したか
+type MatchForm string
+
+const (
+	MatchSurface  MatchForm = "surface"  // 表層形 (入力中の表記) で一致
+	MatchBaseForm MatchForm = "baseForm" // 基本形 (見出し語) で一致
+)
+
+// AssessmentTrace は扁桃体の評価の根拠
+type AssessmentTrace struct {
+	Language      Language              `json:"language"`
+	Tokens        []TokenTrace          `json:"tokens"`              // 形態素解析の結果 (顔文字などの手がかりを取り除いた後)
+	Matches       []LexiconMatch        `json:"matches"`             // 辞書と一致した語
+	Signals       []AffectSignal        `json:"signals,omitempty"`   // 語彙以外の感情の手がかり
+	Emphasis      float64               `json:"emphasis"`            // 強調表現による倍率 (1 なら強調なし)
+	Contributions []EmotionContribution `json:"contributions"`       // 感情コードごとの内訳
+	Affect        Affect                `json:"affect"`              // 入力全体の VAD
+	Emotions      []EmotionValue        `json:"emotions"`            // 評価結果
+	Character     string                `json:"character,omitempty"` // 参照したキャラクター別の辞書レイヤー
+}
+
+// TokenTrace は形態素 (トークン)
+type TokenTrace struct {
+	Surface  string `json:"surface"`
+	BaseForm string `json:"baseForm"`
+	POS      string `json:"pos"`
+	Modifier bool   `json:"modifier,omitempty"` // 感情語を修飾した語 (語自体の感情は評価しない)
+}
+
+// LexiconMatch は辞書の語との一致と、修飾による強度の変化
+type LexiconMatch struct {
+	Token     int           `json:"token"`               // Tokens の位置
+	Word      string        `json:"word"`                // 辞書の語
+	MatchedBy MatchForm     `json:"matchedBy"`           // 一致した形
+	Entry     EmotionValue  `json:"entry"`               // 辞書の感情コードと強度
+	Modifiers []string      `json:"modifiers,omitempty"` // 修飾に関わった語 (程度副詞・ぼかし表現・否定)
+	Degree    float64       `json:"degree,omitempty"`    // 程度副詞による倍率 (評価しなかった語は省略)
+	Hedge     float64       `json:"hedge,omitempty"`     // ぼかし表現による倍率
+	Negations int           `json:"negations,omitempty"` // 否定の数
+	Negation  string        `json:"negation,omitempty"`  // 否定の強さ ("plain", "emphatic", "partial", 二重否定は "litotes")
+	Result    *EmotionValue `json:"result,omitempty"`    // 修飾後の感情 (打ち消された場合は nil)
+	Skipped   bool          `json:"skipped,omitempty"`   // 他の感情語を修飾したため評価しなかった
+}
+
+// ContributionSource は感情コードへの寄与の出どころ
+type ContributionSource struct {
+	Kind  string `json:"kind"` // "lexicon" または手がかりの種類 (AffectSignalKind)
+	Text  string `json:"text"` // 寄与した語・表記
+	Value int    `json:"value"`
+}
+
+// EmotionContribution は感情コードごとの寄与の内訳
+type EmotionContribution struct {
+	Code    EmotionCode          `json:"code"`
+	Sources []ContributionSource `json:"sources"`
+	Sum     int                  `json:"sum"`   // 寄与の合計 (上限 100)
+	Value   int                  `json:"value"` // 強調表現を反映した値
+}
+
+// EmpathyTrace はミラーニューロンによる情動伝染
+type EmpathyTrace struct {
+	UserEmotion  EmotionValue `json:"userEmotion"`  // 推定したユーザーの感情
+	EmpathyLevel float64      `json:"empathyLevel"` // 共感レベル (0.0-1.0)
+	Contagion    EmotionValue `json:"contagion"`    // 自身の感情に加えた値 (0 なら伝染なし)
+}
+
+// SensitivityTrace は感情値に掛けた感度
+type SensitivityTrace struct {
+	Gain                 float64                 `json:"gain"`                 // 視床のゲイン
+	CircadianSensitivity float64                 `json:"circadianSensitivity"` // 概日リズムの感情感度 (Grief, Love, Fear のみに掛ける)
+	Adjustments          []SensitivityAdjustment `json:"adjustments"`
+}
+
+// SensitivityAdjustment は感情ごとの感度の調整
+type SensitivityAdjustment struct {
+	Code        EmotionCode `json:"code"`
+	Before      int         `json:"before"`
+	Multiplier  float64     `json:"multiplier"`  // ゲイン × 気質 × 概日リズム
+	Temperament float64     `json:"temperament"` // 気質 (プロファイル) の感受性
+	Circadian   bool        `json:"circadian"`   // 概日リズムの感度を掛けたか
+	After       int         `json:"after"`
+}
+
+// ArbitrationTrace は前頭前皮質による感情の調整
+type ArbitrationTrace struct {
+	Sanity          int                     `json:"sanity"`
+	Cortisol        float64                 `json:"cortisol"`
+	Oxytocin        float64                 `json:"oxytocin"`
+	Uncontrolled    bool                    `json:"uncontrolled,omitempty"` // 理性値が低く調整しなかった (暴走)
+	SuppressionRate float64                 `json:"suppressionRate"`        // 理性による抑制率 (コルチゾールで弱まる)
+	Adjustments     []ArbitrationAdjustment `json:"adjustments,omitempty"`
+}
+
+// ArbitrationAdjustment は感情ごとの前頭前皮質の調整
+type ArbitrationAdjustment struct {
+	Before        EmotionValue `json:"before"`
+	CortisolBoost float64      `json:"cortisolBoost,omitempty"` // ストレス過多による不快情動の増幅率 (1.0-1.5)
+	ConvertedTo   EmotionCode  `json:"convertedTo,omitempty"`   // オキシトシンによる変換先 (Anger → Grief)
+	Suppression   float64      `json:"suppression,omitempty"`   // 理性により抑制した量
+	PositiveBoost float64      `json:"positiveBoost,omitempty"` // 快情動を増幅した量
+	After         EmotionValue `json:"after"`
+}
@@ -12,10 +12,12 @@ type SuccessResponse struct {
 
 // DebugInfo はデバッグ情報
 type DebugInfo struct {
-	Cortisol        float64 `json:"cortisol"`
-	Oxytocin        float64 `json:"oxytocin"`
-	PredictedReward float64 `json:"predictedReward"`
-	DaydreamLog     string  `json:"daydreamLog,omitempty"`
+	Cortisol        float64        `json:"cortisol"`
+	Oxytocin        float64        `json:"oxytocin"`
+	PredictedReward float64        `json:"predictedReward"`
+	DaydreamLog     string         `json:"daydreamLog,omitempty"`
+	AffectSignals   []AffectSignal `json:"affectSignals,omitempty"` // 絵文字・顔文字・記号の繰り返しなどの感情の手がかり
+	Explanation     *Explanation   `json:"explanation,omitempty"`   // 感情の処理の根拠 (?explain=true の場合のみ)
 }
 
 // ProblemDetails は RFC 9457 準拠のエラーレスポンス
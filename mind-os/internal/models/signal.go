@@ -0,0 +1,23 @@
+package models
+
+// AffectSignalKind は語彙以外で感情を表す表記 (絵文字・顔文字・記号の繰り返しなど) の種類
+type AffectSignalKind string
+
+const (
+	AffectSignalEmoji       AffectSignalKind = "emoji"       // 絵文字 (😂, ❤️)
+	AffectSignalKaomoji     AffectSignalKind = "kaomoji"     // 顔文字 ((´；ω；`), ^^, :-) )
+	AffectSignalExclamation AffectSignalKind = "exclamation" // 感嘆符の繰り返し (！！！)
+	AffectSignalQuestion    AffectSignalKind = "question"    // 疑問符の繰り返し・感嘆符との組み合わせ (？？, ！？)
+	AffectSignalElongation  AffectSignalKind = "elongation"  // 長音・波線による引き伸ばし (すごーーい, 嬉しい〜〜, sooo)
+	AffectSignalLaughter    AffectSignalKind = "laughter"    // 笑いの表記 (ｗｗｗ, (笑), lol, hahaha)
+)
+
+// AffectSignal は入力テキストから語彙の照合の前に抽出した感情の手がかり
+// 【心理学的意味】文字で書かれた会話では、表情・声の抑揚の代わりに絵文字・顔文字・記号の繰り返しが感情と覚醒を伝える
+type AffectSignal struct {
+	Kind     AffectSignalKind `json:"kind"`
+	Text     string           `json:"text"`               // 入力中の表記
+	Emotion  *EmotionValue    `json:"emotion,omitempty"`  // 感情コードへの寄与 (強調表現は感情コードを持たない)
+	Arousal  float64          `json:"arousal"`            // 覚醒度への寄与 (感情コードの VAD に加える)
+	Emphasis float64          `json:"emphasis,omitempty"` // 入力全体の感情の強度に掛ける倍率 (強調表現のみ)
+}
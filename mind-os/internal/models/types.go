@@ -49,6 +49,17 @@ type RuneMemory struct {
 	LastAccess  time.Time      `json:"lastAccess"`  // 最終アクセス日時
 	RecallCount int            `json:"recallCount"` // 想起回数
 	Tags        []string       `json:"tags"`        // タグ
+	Language    Language       `json:"language"`    // 記憶内容の言語
+}
+
+// RecalledMemory は連想想起された記憶とその関連度
+type RecalledMemory struct {
+	UUID            string         `json:"uuid"`                      // 記憶のUUID
+	Text            string         `json:"text"`                      // 記憶内容
+	Type            MemoryType     `json:"type"`                      // 記憶タイプ
+	Emotions        []EmotionValue `json:"emotions"`                  // 記憶に結びついた感情
+	Score           float64        `json:"score"`                     // 関連度スコア (0.0-1.0)
+	MatchedConcepts []string       `json:"matchedConcepts,omitempty"` // 手がかりと一致した概念
 }
 
 // SignalType は刺激の種類を表す文字列型
@@ -59,11 +70,34 @@ const (
 	SignalPhysical SignalType = "physical" // 物理的刺激（食事、接触、痛み）
 )
 
+// Language は入力・応答・記憶の言語 (ISO 639-1 の言語コード)
+type Language string
+
+const (
+	LanguageJapanese Language = "ja" // 日本語
+	LanguageEnglish  Language = "en" // 英語
+)
+
+// DefaultLanguage は言語を判定できない場合に使う言語
+const DefaultLanguage = LanguageJapanese
+
+// IsValidLanguage は対応している言語かチェック
+func IsValidLanguage(lang Language) bool {
+	switch lang {
+	case LanguageJapanese, LanguageEnglish:
+		return true
+	default:
+		return false
+	}
+}
+
 // SensoryInput は感覚入力を表す構造体
 type SensoryInput struct {
-	Type        SignalType `json:"type" validate:"required,oneof=chat physical"` // 刺激の種類
-	InputText   string     `json:"text" validate:"required,max=500"`             // 記憶用のテキスト記述
-	SignalValue int        `json:"signalValue" validate:"min=-100,max=100"`      // -100(不快/痛み) 〜 +100(快感/報酬)
+	Type        SignalType `json:"type" validate:"required,oneof=chat physical"`        // 刺激の種類
+	InputText   string     `json:"text" validate:"required,max=500"`                    // 記憶用のテキスト記述
+	SignalValue int        `json:"signalValue" validate:"min=-100,max=100"`             // -100(不快/痛み) 〜 +100(快感/報酬)
+	Language    Language   `json:"language,omitempty" validate:"omitempty,oneof=ja en"` // 入力の言語 (省略時はテキストから判定)
+	Explain     bool       `json:"-"`                                                   // 処理の根拠 (Explanation) を記録するか (API の ?explain=true)
 }
 
 // LogValue はslog.Valuerインターフェースの実装
@@ -79,6 +113,7 @@ func (s SensoryInput) LogValue() slog.Value {
 		slog.String("type", string(s.Type)),
 		slog.String("text", maskedText), // マスク済みテキスト
 		slog.Int("signalValue", s.SignalValue),
+		slog.String("language", string(s.Language)),
 	)
 }
 
@@ -89,12 +124,19 @@ type MindStateResponse struct {
 	PersonalityBias []EmotionValue `json:"personalityBias"` // 性格傾向
 	Motivation      float64        `json:"motivation"`      // 意欲 (0.0-1.0)
 	Sanity          float64        `json:"sanity"`          // 理性値 (0.0-1.0)
+	CoreAffect      Affect         `json:"coreAffect"`      // コアアフェクト (脳の現在の VAD)
+	CoreEmotion     EmotionValue   `json:"coreEmotion"`     // コアアフェクトに最も近い感情 (EMO v1.1)
+	Language        Language       `json:"language"`        // 入力の言語 (応答テキストもこの言語で生成する)
 	// デバッグ用フィールド
-	Cortisol        float64 `json:"cortisol"`
-	Oxytocin        float64 `json:"oxytocin"`
-	PredictedReward float64 `json:"predictedReward"`
-	DaydreamLog     string  `json:"daydreamLog,omitempty"` // マインドワンダリングログ
-	ReplyText       string  `json:"replyText,omitempty"`   // 生成された応答テキスト
+	Cortisol        float64        `json:"cortisol"`
+	Oxytocin        float64        `json:"oxytocin"`
+	PredictedReward float64        `json:"predictedReward"`
+	DaydreamLog     string         `json:"daydreamLog,omitempty"`   // マインドワンダリングログ
+	AffectSignals   []AffectSignal `json:"affectSignals,omitempty"` // 扁桃体が語彙以外から拾った感情の手がかり
+	Explanation     *Explanation   `json:"explanation,omitempty"`   // 処理の根拠 (SensoryInput.Explain の場合のみ)
+	ReplyText       string         `json:"replyText,omitempty"`     // 生成された応答テキスト
+
+	RecalledMemories []RecalledMemory `json:"recalledMemories,omitempty"` // 入力を手がかりに想起された記憶
 }
 
 // EmotionMap は感情コードから強度値へのマッピング
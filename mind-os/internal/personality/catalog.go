@@ -0,0 +1,144 @@
+package personality
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ErrNotFound はプロファイルが見つからない場合のエラー
+var ErrNotFound = errors.New("profile not found")
+
+// Catalog は名前付きプロファイルの一覧 (起動時に読み込み、以降は読み取り専用)
+type Catalog struct {
+	profiles map[string]Profile
+}
+
+// NewCatalog は組み込みの default プロファイルのみを持つ Catalog を作成
+func NewCatalog(base Profile) *Catalog {
+	return &Catalog{profiles: map[string]Profile{base.Name: base.Clone()}}
+}
+
+// Load は dir 内のプロファイル (*.json, *.yaml, *.yml) を読み込んだ Catalog を作成
+// 【処理内容】各ファイルは base (組み込みの default) を上書きする形で厳密にデコードし、検証する
+// dir が空の場合は base のみを持つ Catalog を返す
+func Load(dir string, base Profile) (*Catalog, error) {
+	c := NewCatalog(base)
+	if dir == "" {
+		return c, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		p, err := LoadFile(path, base)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := c.profiles[p.Name]; exists {
+			return nil, fmt.Errorf("%s: duplicate profile name %q", path, p.Name)
+		}
+		c.profiles[p.Name] = p
+	}
+
+	return c, nil
+}
+
+// LoadFile は1つのプロファイルファイルを読み込む
+// name を省略した場合はファイル名 (拡張子を除く) を名前とする
+func LoadFile(path string, base Profile) (Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Profile{}, fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	p := base.Clone()
+	p.Name = ""
+	p.Description = ""
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = decodeJSON(data, &p)
+	default:
+		err = decodeYAML(data, &p)
+	}
+	if err != nil {
+		return Profile{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if err := p.Validate(); err != nil {
+		return Profile{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// decodeJSON は未知のフィールドを拒否して JSON をデコードする
+func decodeJSON(data []byte, p *Profile) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(p); err != nil {
+		return fmt.Errorf("invalid JSON profile: %w", err)
+	}
+	if dec.More() {
+		return errors.New("invalid JSON profile: unexpected data after top-level object")
+	}
+	return nil
+}
+
+// decodeYAML は未知のフィールドを拒否して YAML をデコードする
+func decodeYAML(data []byte, p *Profile) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(p); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid YAML profile: %w", err)
+	}
+	return nil
+}
+
+// Get は名前でプロファイルを返す (呼び出し側で変更しても Catalog に影響しない複製)
+func (c *Catalog) Get(name string) (Profile, error) {
+	p, ok := c.profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return p.Clone(), nil
+}
+
+// Names はプロファイル名を昇順で返す
+func (c *Catalog) Names() []string {
+	return slices.Sorted(maps.Keys(c.profiles))
+}
+
+// List はプロファイルを名前順で返す
+func (c *Catalog) List() []Profile {
+	names := c.Names()
+	list := make([]Profile, 0, len(names))
+	for _, name := range names {
+		list = append(list, c.profiles[name].Clone())
+	}
+	return list
+}
@@ -0,0 +1,182 @@
+// Package personality はキャラクターの気質 (パーソナリティプロファイル) を定義・読み込みする
+// 【役割】ホルモンの基準値・減衰率、感情ごとの感受性、報酬学習率、理性の閾値、口調 (応答テンプレート) を
+// キャラクターごとに切り替えられるようにする
+package personality
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/umekku/mind-os/internal/config"
+	"github.com/umekku/mind-os/internal/cortex"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// DefaultName は設定 (環境変数) から作られる組み込みのプロファイル名
+const DefaultName = "default"
+
+// Profile はキャラクターの気質
+// ファイル (YAML/JSON) で指定しなかった項目は組み込みの default プロファイルの値を使う
+type Profile struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	Hormones    HormoneProfile     `json:"hormones" yaml:"hormones"`
+	Motivation  MotivationProfile  `json:"motivation" yaml:"motivation"`
+	Sanity      SanityProfile      `json:"sanity" yaml:"sanity"`
+	Empathy     EmpathyProfile     `json:"empathy" yaml:"empathy"`
+	Habituation HabituationProfile `json:"habituation" yaml:"habituation"`
+
+	// 感情ごとの感受性 (扁桃体・身体信号で生じた感情の強さに掛ける係数, 0-3, 既定 1)
+	EmotionSensitivity map[models.EmotionCode]float64 `json:"emotionSensitivity,omitempty" yaml:"emotionSensitivity,omitempty"`
+
+	// 日本語の応答テンプレート (感情キーごと, 指定した感情のみ既定のテンプレートを差し替える。英語の応答には使わない)
+	Templates map[string][]string `json:"templates,omitempty" yaml:"templates,omitempty"`
+}
+
+// HormoneProfile は視床下部 (ホルモン) の気質
+type HormoneProfile struct {
+	BaselineCortisol float64 `json:"baselineCortisol" yaml:"baselineCortisol"` // 刺激がない時に戻る Cortisol (0-100)
+	BaselineOxytocin float64 `json:"baselineOxytocin" yaml:"baselineOxytocin"` // 刺激がない時に戻る Oxytocin (0-100)
+	DecayRate        float64 `json:"decayRate" yaml:"decayRate"`               // 1時間あたりの減衰量 (>= 0)
+}
+
+// MotivationProfile は大脳基底核 (意欲・報酬学習) の気質
+type MotivationProfile struct {
+	Baseline  float64 `json:"baseline" yaml:"baseline"`   // 刺激がない時に戻る意欲 (0-100)
+	Alpha     float64 `json:"alpha" yaml:"alpha"`         // 報酬予測誤差に対する意欲の感度 (0-1)
+	Beta      float64 `json:"beta" yaml:"beta"`           // 期待報酬値の学習率 (0-1)
+	DecayRate float64 `json:"decayRate" yaml:"decayRate"` // 自然減衰率 (0-1)
+}
+
+// SanityProfile は前頭前皮質 (理性) の気質
+type SanityProfile struct {
+	Initial             int     `json:"initial" yaml:"initial"`                         // 理性値の初期値 (0-100)
+	ControlThreshold    int     `json:"controlThreshold" yaml:"controlThreshold"`       // これを下回ると感情を制御できない (0-100)
+	NegativeSuppression float64 `json:"negativeSuppression" yaml:"negativeSuppression"` // ネガティブ感情の抑制率 (0-1)
+	PositiveBoost       float64 `json:"positiveBoost" yaml:"positiveBoost"`             // ポジティブ感情の増幅率 (0-1)
+}
+
+// EmpathyProfile はミラーニューロン (共感) の気質
+type EmpathyProfile struct {
+	Initial float64 `json:"initial" yaml:"initial"` // 共感の強さの初期値 (0-1)
+	Min     float64 `json:"min" yaml:"min"`         // Oxytocin 0 の時の共感の強さ (0-1)
+	Max     float64 `json:"max" yaml:"max"`         // Oxytocin 100 の時の共感の強さ (0-1)
+}
+
+// HabituationProfile は視床 (順応) の気質
+type HabituationProfile struct {
+	SimilarityThreshold float64 `json:"similarityThreshold" yaml:"similarityThreshold"` // 同じ入力とみなす類似度 (0-1)
+}
+
+// FromConfig は設定 (環境変数) から組み込みの default プロファイルを作成
+func FromConfig(cfg *config.Config) Profile {
+	return Profile{
+		Name:        DefaultName,
+		Description: "Built-in profile from environment settings",
+		Hormones: HormoneProfile{
+			DecayRate: cfg.HormoneDecayRate,
+		},
+		Motivation: MotivationProfile{
+			Baseline:  50,
+			Alpha:     cfg.BasalAlpha,
+			Beta:      cfg.BasalBeta,
+			DecayRate: cfg.BasalDecayRate,
+		},
+		Sanity: SanityProfile{
+			Initial:             cfg.PFCInitialSanity,
+			ControlThreshold:    cfg.PFCControlThreshold,
+			NegativeSuppression: cfg.PFCNegativeSuppression,
+			PositiveBoost:       cfg.PFCPositiveBoost,
+		},
+		Empathy: EmpathyProfile{
+			Initial: 0.5,
+			Min:     0.2,
+			Max:     1.0,
+		},
+		Habituation: HabituationProfile{
+			SimilarityThreshold: 0.8,
+		},
+	}
+}
+
+// Clone はマップ・スライスを含めて複製する
+func (p Profile) Clone() Profile {
+	p.EmotionSensitivity = maps.Clone(p.EmotionSensitivity)
+	if p.Templates != nil {
+		templates := make(map[string][]string, len(p.Templates))
+		for key, phrases := range p.Templates {
+			templates[key] = slices.Clone(phrases)
+		}
+		p.Templates = templates
+	}
+	return p
+}
+
+// Sensitivity は感情の感受性を返す (指定がなければ 1)
+func (p Profile) Sensitivity(code models.EmotionCode) float64 {
+	if s, ok := p.EmotionSensitivity[code]; ok {
+		return s
+	}
+	return 1.0
+}
+
+// Validate は値の範囲を検証し、問題を全て列挙したエラーを返す
+func (p Profile) Validate() error {
+	var errs []string
+
+	if strings.TrimSpace(p.Name) == "" {
+		errs = append(errs, "name is required")
+	}
+
+	inRange := func(name string, value, min, max float64) {
+		if value < min || value > max {
+			errs = append(errs, fmt.Sprintf("%s: %v (must be in [%v, %v])", name, value, min, max))
+		}
+	}
+	inRange("hormones.baselineCortisol", p.Hormones.BaselineCortisol, 0, 100)
+	inRange("hormones.baselineOxytocin", p.Hormones.BaselineOxytocin, 0, 100)
+	if p.Hormones.DecayRate < 0 {
+		errs = append(errs, fmt.Sprintf("hormones.decayRate: %v (must be >= 0)", p.Hormones.DecayRate))
+	}
+	inRange("motivation.baseline", p.Motivation.Baseline, 0, 100)
+	inRange("motivation.alpha", p.Motivation.Alpha, 0, 1)
+	inRange("motivation.beta", p.Motivation.Beta, 0, 1)
+	inRange("motivation.decayRate", p.Motivation.DecayRate, 0, 1)
+	inRange("sanity.initial", float64(p.Sanity.Initial), 0, 100)
+	inRange("sanity.controlThreshold", float64(p.Sanity.ControlThreshold), 0, 100)
+	inRange("sanity.negativeSuppression", p.Sanity.NegativeSuppression, 0, 1)
+	inRange("sanity.positiveBoost", p.Sanity.PositiveBoost, 0, 1)
+	inRange("empathy.initial", p.Empathy.Initial, 0, 1)
+	inRange("empathy.min", p.Empathy.Min, 0, 1)
+	inRange("empathy.max", p.Empathy.Max, 0, 1)
+	if p.Empathy.Min > p.Empathy.Max {
+		errs = append(errs, fmt.Sprintf("empathy.min (%v) must be <= empathy.max (%v)", p.Empathy.Min, p.Empathy.Max))
+	}
+	inRange("habituation.similarityThreshold", p.Habituation.SimilarityThreshold, 0, 1)
+
+	for _, code := range slices.Sorted(maps.Keys(p.EmotionSensitivity)) {
+		if !models.IsValidEmotionCode(code) {
+			errs = append(errs, fmt.Sprintf("emotionSensitivity: unknown emotion code %q", code))
+			continue
+		}
+		inRange("emotionSensitivity."+string(code), p.EmotionSensitivity[code], 0, 3)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(p.Templates)) {
+		if !slices.Contains(cortex.TemplateKeys, key) {
+			errs = append(errs, fmt.Sprintf("templates: unknown emotion key %q (expected one of %s)", key, strings.Join(cortex.TemplateKeys, ", ")))
+			continue
+		}
+		if len(p.Templates[key]) == 0 {
+			errs = append(errs, fmt.Sprintf("templates.%s: at least one phrase is required", key))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid profile %q:\n - %s", p.Name, strings.Join(errs, "\n - "))
+	}
+	return nil
+}
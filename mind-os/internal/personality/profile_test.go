@@ -0,0 +1,150 @@
+package personality
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/umekku/mind-os/internal/config"
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// testBase はテスト用の組み込みプロファイル
+func testBase() Profile {
+	return FromConfig(&config.Config{
+		HormoneDecayRate:       10,
+		PFCInitialSanity:       80,
+		PFCControlThreshold:    30,
+		PFCNegativeSuppression: 0.5,
+		PFCPositiveBoost:       0.1,
+		BasalAlpha:             0.5,
+		BasalBeta:              0.3,
+		BasalDecayRate:         0.95,
+	})
+}
+
+// writeFile は dir にファイルを作成する
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestFromConfig_IsValid(t *testing.T) {
+	p := testBase()
+	if p.Name != DefaultName {
+		t.Errorf("Name = %q, want %q", p.Name, DefaultName)
+	}
+	if err := p.Validate(); err != nil {
+		t.Errorf("built-in profile should be valid: %v", err)
+	}
+	if s := p.Sensitivity(models.EmotionJoy); s != 1.0 {
+		t.Errorf("default sensitivity = %v, want 1", s)
+	}
+}
+
+func TestValidate_ReportsAllErrors(t *testing.T) {
+	p := testBase()
+	p.Hormones.BaselineCortisol = 120
+	p.Motivation.Alpha = 2
+	p.Empathy.Min = 0.9
+	p.Empathy.Max = 0.1
+	p.EmotionSensitivity = map[models.EmotionCode]float64{"X": 1, models.EmotionFear: 5}
+	p.Templates = map[string][]string{"happy": {"やった"}, "joy": {}}
+
+	err := p.Validate()
+	if err == nil {
+		t.Fatal("Validate() should fail")
+	}
+	for _, want := range []string{
+		"hormones.baselineCortisol",
+		"motivation.alpha",
+		"empathy.min",
+		`unknown emotion code "X"`,
+		"emotionSensitivity.F",
+		`unknown emotion key "happy"`,
+		"templates.joy",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should mention %s: %v", want, err)
+		}
+	}
+}
+
+func TestLoadFile_YAMLOverridesBase(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "anxious.yaml", `
+description: 心配性
+hormones:
+  baselineCortisol: 40
+emotionSensitivity:
+  F: 1.5
+templates:
+  fear:
+    - "ど、どうしよう…"
+`)
+
+	p, err := LoadFile(path, testBase())
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	if p.Name != "anxious" {
+		t.Errorf("Name = %q, want file base name", p.Name)
+	}
+	if p.Hormones.BaselineCortisol != 40 {
+		t.Errorf("BaselineCortisol = %v, want 40", p.Hormones.BaselineCortisol)
+	}
+	if p.Hormones.DecayRate != 10 || p.Sanity.Initial != 80 {
+		t.Errorf("unspecified fields should keep base values: %+v", p)
+	}
+	if s := p.Sensitivity(models.EmotionFear); s != 1.5 {
+		t.Errorf("Sensitivity(F) = %v, want 1.5", s)
+	}
+	if len(p.Templates["fear"]) != 1 {
+		t.Errorf("Templates[fear] = %v", p.Templates["fear"])
+	}
+}
+
+func TestLoadFile_RejectsUnknownFields(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := writeFile(t, dir, "typo.yaml", "hormones:\n  baselineCortizol: 40\n")
+	jsonPath := writeFile(t, dir, "typo.json", `{"sanity": {"inital": 50}}`)
+
+	for _, path := range []string{yamlPath, jsonPath} {
+		if _, err := LoadFile(path, testBase()); err == nil {
+			t.Errorf("LoadFile(%s) should reject unknown fields", filepath.Base(path))
+		}
+	}
+}
+
+func TestLoad_Catalog(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "calm.json", `{"name": "calm", "sanity": {"initial": 95}}`)
+	writeFile(t, dir, "README.md", "ignored")
+
+	c, err := Load(dir, testBase())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := strings.Join(c.Names(), ","); got != "calm,default" {
+		t.Errorf("Names() = %s, want calm,default", got)
+	}
+	calm, err := c.Get("calm")
+	if err != nil || calm.Sanity.Initial != 95 {
+		t.Errorf("Get(calm) = %+v, %v", calm, err)
+	}
+	if _, err := c.Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+	}
+
+	// 同じ名前のプロファイルはエラー
+	writeFile(t, dir, "calm2.yaml", "name: calm\n")
+	if _, err := Load(dir, testBase()); err == nil || !strings.Contains(err.Error(), "duplicate") {
+		t.Errorf("Load with duplicate names error = %v", err)
+	}
+}
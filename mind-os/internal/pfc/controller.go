@@ -17,30 +17,100 @@ type PrefrontalCortex struct {
 	minSanity int     // 最小理性値
 	maxSanity int     // 最大理性値
 	decayRate float64 // ストレスによる減衰率
+
+	// 感情調整のパラメータ
+	initialSanity       int     // 初期値 (Reset で戻る値)
+	controlThreshold    int     // この理性値を下回ると感情を制御できない (暴走)
+	negativeSuppression float64 // 理性が最大の時にネガティブ感情を抑制する割合
+	positiveBoost       float64 // 理性が最大の時にポジティブ感情を増幅する割合
+}
+
+// 感情調整のパラメータの既定値
+const (
+	DefaultInitialSanity       = 80
+	DefaultControlThreshold    = 30
+	DefaultNegativeSuppression = 0.5
+	DefaultPositiveBoost       = 0.1
+)
+
+// Option は PrefrontalCortex の設定を変更する関数
+type Option func(*PrefrontalCortex)
+
+// WithInitialSanity は理性値の初期値 (0-100) を設定する
+func WithInitialSanity(sanity int) Option {
+	return func(pfc *PrefrontalCortex) {
+		pfc.initialSanity = sanity
+		pfc.Sanity = sanity
+	}
+}
+
+// WithControlThreshold は感情を制御できる理性値の下限 (0-100) を設定する
+func WithControlThreshold(threshold int) Option {
+	return func(pfc *PrefrontalCortex) {
+		pfc.controlThreshold = threshold
+	}
+}
+
+// WithSuppressionFactors は理性が最大の時のネガティブ感情の抑制率とポジティブ感情の増幅率 (0-1) を設定する
+func WithSuppressionFactors(negativeSuppression, positiveBoost float64) Option {
+	return func(pfc *PrefrontalCortex) {
+		pfc.negativeSuppression = negativeSuppression
+		pfc.positiveBoost = positiveBoost
+	}
 }
 
 // New は新しい PrefrontalCortex インスタンスを作成
-func New() *PrefrontalCortex {
+func New(opts ...Option) *PrefrontalCortex {
 	// 乱数初期化（簡易的）
 	rand.Seed(time.Now().UnixNano())
 
-	return &PrefrontalCortex{
-		Sanity:    80,   // 初期値: 高め
-		minSanity: 0,    // 最小値
-		maxSanity: 100,  // 最大値
-		decayRate: 0.98, // ストレス減衰率
+	pfc := &PrefrontalCortex{
+		Sanity:    DefaultInitialSanity, // 初期値: 高め
+		minSanity: 0,                    // 最小値
+		maxSanity: 100,                  // 最大値
+		decayRate: 0.98,                 // ストレス減衰率
+
+		initialSanity:       DefaultInitialSanity,
+		controlThreshold:    DefaultControlThreshold,
+		negativeSuppression: DefaultNegativeSuppression,
+		positiveBoost:       DefaultPositiveBoost,
+	}
+	for _, opt := range opts {
+		opt(pfc)
 	}
+	pfc.clampSanity()
+	return pfc
 }
 
 // Arbitrate は理性とホルモンバランスによる感情の調整を行う
 // cortisol: ストレスホルモン (0-100)
 // oxytocin: 愛着ホルモン (0-100)
 func (pfc *PrefrontalCortex) Arbitrate(raw []models.EmotionValue, cortisol float64, oxytocin float64) []models.EmotionValue {
+	return pfc.arbitrate(raw, cortisol, oxytocin, nil)
+}
+
+// ArbitrateExplained は Arbitrate と同じ調整を行い、感情ごとの調整の内訳を返す
+// (コルチゾールによる増幅、オキシトシンによる Anger → Grief の変換、理性による抑制量)
+func (pfc *PrefrontalCortex) ArbitrateExplained(raw []models.EmotionValue, cortisol float64, oxytocin float64) ([]models.EmotionValue, models.ArbitrationTrace) {
+	var trace models.ArbitrationTrace
+	arbitrated := pfc.arbitrate(raw, cortisol, oxytocin, &trace)
+	return arbitrated, trace
+}
+
+// arbitrate は感情の調整の本体 (trace が nil でなければ調整の内訳を記録する)
+func (pfc *PrefrontalCortex) arbitrate(raw []models.EmotionValue, cortisol float64, oxytocin float64, trace *models.ArbitrationTrace) []models.EmotionValue {
 	pfc.mu.RLock()
 	defer pfc.mu.RUnlock()
 
+	if trace != nil {
+		trace.Sanity, trace.Cortisol, trace.Oxytocin = pfc.Sanity, cortisol, oxytocin
+	}
+
 	// Sanityが極端に低い場合はそのまま通す（暴走）
-	if pfc.Sanity < 30 {
+	if pfc.Sanity < pfc.controlThreshold {
+		if trace != nil {
+			trace.Uncontrolled = true
+		}
 		return raw
 	}
 
@@ -60,9 +130,14 @@ func (pfc *PrefrontalCortex) Arbitrate(raw []models.EmotionValue, cortisol float
 		weakeningFactor := 1.0 - ((cortisol - 50.0) / 100.0)
 		suppressionRate *= weakeningFactor
 	}
+	if trace != nil {
+		trace.SuppressionRate = suppressionRate
+	}
 
 	// 各感情を調整
 	for i, emotion := range arbitrated {
+		adjustment := models.ArbitrationAdjustment{Before: emotion}
+
 		switch emotion.Code {
 		case models.EmotionAnger, models.EmotionFear, models.EmotionDisgust, models.EmotionGrief:
 			// 値の補正用変数
@@ -74,6 +149,7 @@ func (pfc *PrefrontalCortex) Arbitrate(raw []models.EmotionValue, cortisol float
 				// Cortisol 50 -> 1.0x, Cortisol 100 -> 1.5x
 				boost := 1.0 + ((cortisol - 50.0) / 100.0)
 				currentValue *= boost
+				adjustment.CortisolBoost = boost
 			}
 
 			// 2. 愛着過多 (Oxytocin > 50) による感情変換
@@ -83,6 +159,7 @@ func (pfc *PrefrontalCortex) Arbitrate(raw []models.EmotionValue, cortisol float
 				prob := (oxytocin - 50.0) / 100.0
 				if rand.Float64() < prob {
 					arbitrated[i].Code = models.EmotionGrief
+					adjustment.ConvertedTo = models.EmotionGrief
 					// 変換時の少し強度を抑える（怒りよりはマイルドに）
 					currentValue *= 0.9
 				}
@@ -90,8 +167,9 @@ func (pfc *PrefrontalCortex) Arbitrate(raw []models.EmotionValue, cortisol float
 
 			// 3. 理性による抑制
 			// ネガティブ感情を削減
-			reduction := currentValue * suppressionRate * 0.5
+			reduction := currentValue * suppressionRate * pfc.negativeSuppression
 			newValue := int(currentValue - reduction)
+			adjustment.Suppression = reduction
 
 			// 閾値制限
 			if newValue < 0 {
@@ -104,8 +182,9 @@ func (pfc *PrefrontalCortex) Arbitrate(raw []models.EmotionValue, cortisol float
 
 		case models.EmotionJoy, models.EmotionLove, models.EmotionHope:
 			// ポジティブ感情も軽く増幅
-			boost := float64(emotion.Value) * suppressionRate * 0.1
+			boost := float64(emotion.Value) * suppressionRate * pfc.positiveBoost
 			newValue := emotion.Value + int(boost)
+			adjustment.PositiveBoost = boost
 			if newValue > 100 {
 				newValue = 100
 			}
@@ -114,6 +193,11 @@ func (pfc *PrefrontalCortex) Arbitrate(raw []models.EmotionValue, cortisol float
 		case models.EmotionSurprise, models.EmotionNeutral:
 			// 中立的な感情はそのまま
 		}
+
+		if trace != nil {
+			adjustment.After = arbitrated[i]
+			trace.Adjustments = append(trace.Adjustments, adjustment)
+		}
 	}
 
 	return arbitrated
@@ -187,7 +271,7 @@ func (pfc *PrefrontalCortex) GetSanityLevel() string {
 func (pfc *PrefrontalCortex) CanControlEmotions() bool {
 	pfc.mu.RLock()
 	defer pfc.mu.RUnlock()
-	return pfc.Sanity >= 30
+	return pfc.Sanity >= pfc.controlThreshold
 }
 
 // GetSuppressionRate は抑制率を返す (0.0-1.0)
@@ -195,7 +279,12 @@ func (pfc *PrefrontalCortex) GetSuppressionRate() float64 {
 	pfc.mu.RLock()
 	defer pfc.mu.RUnlock()
 
-	if pfc.Sanity < 30 {
+	return pfc.suppressionRateLocked()
+}
+
+// suppressionRateLocked は抑制率を返す (呼び出し側でロックを保持していること)
+func (pfc *PrefrontalCortex) suppressionRateLocked() float64 {
+	if pfc.Sanity < pfc.controlThreshold {
 		return 0.0
 	}
 	return float64(pfc.Sanity) / 100.0
@@ -220,8 +309,8 @@ func (pfc *PrefrontalCortex) CalculateEmotionalImpact(emotions []models.EmotionV
 	}
 
 	// 理性値が高いほど影響が小さい
-	suppressionRate := pfc.GetSuppressionRate()
-	impact := int(float64(negativeTotal) * (1.0 - suppressionRate*0.5))
+	suppressionRate := pfc.suppressionRateLocked()
+	impact := int(float64(negativeTotal) * (1.0 - suppressionRate*pfc.negativeSuppression))
 
 	return impact
 }
@@ -230,7 +319,7 @@ func (pfc *PrefrontalCortex) CalculateEmotionalImpact(emotions []models.EmotionV
 func (pfc *PrefrontalCortex) Reset() {
 	pfc.mu.Lock()
 	defer pfc.mu.Unlock()
-	pfc.Sanity = 80
+	pfc.Sanity = pfc.initialSanity
 }
 
 // clampSanity は理性値を範囲内に制限（内部用）
@@ -18,6 +18,37 @@ func TestNew(t *testing.T) {
 	}
 }
 
+// TestNew_WithOptions は設定による理性の初期値・抑制率の変更をテスト
+func TestNew_WithOptions(t *testing.T) {
+	pfc := New(
+		WithInitialSanity(50),
+		WithControlThreshold(60),
+		WithSuppressionFactors(0, 0),
+	)
+
+	if pfc.GetSanity() != 50 {
+		t.Errorf("Initial Sanity = %d, want 50", pfc.GetSanity())
+	}
+	// 閾値 60 を下回るため制御できない
+	if pfc.CanControlEmotions() {
+		t.Error("CanControlEmotions() = true, want false below the control threshold")
+	}
+
+	// 抑制率 0 ではネガティブ感情も抑制されない
+	pfc.SetSanity(100)
+	raw := []models.EmotionValue{{Code: models.EmotionAnger, Value: 60}, {Code: models.EmotionJoy, Value: 40}}
+	result := pfc.Arbitrate(raw, 0, 0)
+	if result[0].Value != 60 || result[1].Value != 40 {
+		t.Errorf("Arbitrate with zero factors = %v, want values unchanged", result)
+	}
+
+	// Reset は設定した初期値に戻す
+	pfc.Reset()
+	if pfc.GetSanity() != 50 {
+		t.Errorf("Sanity after Reset = %d, want 50", pfc.GetSanity())
+	}
+}
+
 // TestGetSanity は理性値取得をテスト
 func TestGetSanity(t *testing.T) {
 	pfc := New()
@@ -187,6 +218,65 @@ func TestArbitrate_HighOxytocin(t *testing.T) {
 	}
 }
 
+// TestArbitrateExplained は調整の内訳をテスト
+func TestArbitrateExplained(t *testing.T) {
+	pfc := New()
+	pfc.SetSanity(80)
+
+	raw := []models.EmotionValue{
+		{Code: models.EmotionAnger, Value: 50},
+		{Code: models.EmotionJoy, Value: 50},
+		{Code: models.EmotionSurprise, Value: 50},
+	}
+
+	// TestArbitrate_HighCortisol と同じ条件: 抑制率 0.4, 増幅 1.5, 抑制量 75 * 0.4 * 0.5 = 15
+	arbitrated, trace := pfc.ArbitrateExplained(raw, 100.0, 0.0)
+
+	if trace.Sanity != 80 || trace.Cortisol != 100 || trace.Uncontrolled {
+		t.Errorf("trace = %+v, want sanity 80, cortisol 100, controlled", trace)
+	}
+	if trace.SuppressionRate != 0.4 {
+		t.Errorf("SuppressionRate = %v, want 0.4", trace.SuppressionRate)
+	}
+	if len(trace.Adjustments) != len(raw) {
+		t.Fatalf("len(Adjustments) = %d, want %d", len(trace.Adjustments), len(raw))
+	}
+
+	anger := trace.Adjustments[0]
+	if anger.CortisolBoost != 1.5 || anger.Suppression != 15 || anger.After != arbitrated[0] || anger.After.Value != 60 {
+		t.Errorf("anger adjustment = %+v, want boost 1.5, suppression 15, after 60", anger)
+	}
+	joy := trace.Adjustments[1]
+	if joy.PositiveBoost <= 0 || joy.CortisolBoost != 0 || joy.After != arbitrated[1] {
+		t.Errorf("joy adjustment = %+v, want positive boost only", joy)
+	}
+	surprise := trace.Adjustments[2]
+	if surprise.Before != surprise.After {
+		t.Errorf("surprise adjustment = %+v, want unchanged", surprise)
+	}
+
+	// オキシトシンによる変換は変換先を記録する
+	converted := false
+	for i := 0; i < 100 && !converted; i++ {
+		res, tr := pfc.ArbitrateExplained([]models.EmotionValue{{Code: models.EmotionAnger, Value: 80}}, 0.0, 100.0)
+		if res[0].Code == models.EmotionGrief {
+			converted = tr.Adjustments[0].ConvertedTo == models.EmotionGrief
+			if !converted {
+				t.Fatalf("conversion not recorded: %+v", tr.Adjustments[0])
+			}
+		}
+	}
+	if !converted {
+		t.Error("Anger was never converted to Grief with high oxytocin")
+	}
+
+	// 理性値が低い場合は調整しない
+	pfc.SetSanity(10)
+	if _, trace := pfc.ArbitrateExplained(raw, 0, 0); !trace.Uncontrolled || len(trace.Adjustments) != 0 {
+		t.Errorf("low sanity trace = %+v, want uncontrolled without adjustments", trace)
+	}
+}
+
 // TestUpdateSanity は理性値更新をテスト
 func TestUpdateSanity(t *testing.T) {
 	pfc := New()
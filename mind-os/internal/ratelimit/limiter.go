@@ -0,0 +1,124 @@
+// Package ratelimit はクライアントごとのリクエスト数の制限 (トークンバケット) を提供する
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// ClientContextKey はレート制限の対象となるクライアントの識別子を gin.Context に保存するキー
+// (HTTP の後で WebSocket のメッセージにも同じ予算を適用するために使う)
+const ClientContextKey = "ratelimit.client"
+
+// sweepInterval は使われなくなったバケットを掃除する間隔
+const sweepInterval = time.Minute
+
+// Limiter はクライアントごとのリクエスト数を制限する
+// 複数インスタンスで予算を共有する場合は、共有ストアを使う実装に差し替える
+type Limiter interface {
+	// Allow はクライアント key のリクエストを1件消費できるかを判定する
+	Allow(key string) Result
+}
+
+// Result はレート制限の判定結果
+type Result struct {
+	Allowed    bool
+	Limit      int           // バケットの容量 (連続して受け付けるリクエスト数)
+	Remaining  int           // 残りのリクエスト数
+	Reset      time.Duration // バケットが満杯に戻るまでの時間
+	RetryAfter time.Duration // 拒否された場合、次のリクエストが受け付けられるまでの時間
+}
+
+// Policy はトークンバケットの設定
+type Policy struct {
+	PerMinute int // 1分あたりに補充されるリクエスト数
+	Burst     int // バケットの容量 (連続して受け付けるリクエスト数)
+}
+
+// rate は1秒あたりの補充量を返す
+func (p Policy) rate() float64 {
+	return float64(p.PerMinute) / 60
+}
+
+// bucket はクライアントごとのトークンバケット
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Memory はメモリ上のトークンバケットによる Limiter
+// 【処理内容】クライアントごとに容量 Burst のバケットを持ち、PerMinute の速度で補充する。
+// リクエストごとに1トークンを消費し、空なら拒否する。満杯まで補充されたバケットは定期的に破棄してメモリを解放する
+type Memory struct {
+	policy Policy
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemory は新しい Memory を作成
+func NewMemory(policy Policy) *Memory {
+	return &Memory{
+		policy:    policy,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Allow はクライアント key のリクエストを1件消費できるかを判定する
+func (m *Memory) Allow(key string) Result {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweepLocked(now)
+
+	burst := float64(m.policy.Burst)
+	rate := m.policy.rate()
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		m.buckets[key] = b
+	}
+
+	// 経過時間分を補充
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	result := Result{Limit: m.policy.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / rate)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = secondsToDuration((burst - b.tokens) / rate)
+
+	return result
+}
+
+// sweepLocked は満杯まで補充されたバケット (しばらく使われていないクライアント) を破棄する
+func (m *Memory) sweepLocked(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	refill := secondsToDuration(float64(m.policy.Burst) / m.policy.rate())
+	for key, b := range m.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(m.buckets, key)
+		}
+	}
+}
+
+// secondsToDuration は秒数を time.Duration に変換
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestMemory は時刻を操作できる Memory を作成
+func newTestMemory(policy Policy) (*Memory, *time.Time) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMemory(policy)
+	m.now = func() time.Time { return now }
+	m.lastSweep = now
+	return m, &now
+}
+
+func TestMemory_Burst(t *testing.T) {
+	m, _ := newTestMemory(Policy{PerMinute: 60, Burst: 3})
+
+	for i := range 3 {
+		r := m.Allow("client")
+		if !r.Allowed {
+			t.Fatalf("request %d should be allowed", i+1)
+		}
+		if r.Remaining != 2-i {
+			t.Errorf("request %d: Remaining = %d, want %d", i+1, r.Remaining, 2-i)
+		}
+	}
+
+	r := m.Allow("client")
+	if r.Allowed {
+		t.Fatal("request beyond burst should be rejected")
+	}
+	if r.RetryAfter != time.Second {
+		t.Errorf("RetryAfter = %v, want 1s", r.RetryAfter)
+	}
+	if r.Limit != 3 || r.Remaining != 0 {
+		t.Errorf("Limit/Remaining = %d/%d, want 3/0", r.Limit, r.Remaining)
+	}
+
+	// 他のクライアントの予算には影響しない
+	if !m.Allow("other").Allowed {
+		t.Error("other client should be allowed")
+	}
+}
+
+func TestMemory_Refill(t *testing.T) {
+	m, now := newTestMemory(Policy{PerMinute: 30, Burst: 2})
+
+	m.Allow("client")
+	m.Allow("client")
+	if m.Allow("client").Allowed {
+		t.Fatal("bucket should be empty")
+	}
+
+	// 30回/分 = 2秒で1回分
+	*now = now.Add(2 * time.Second)
+	r := m.Allow("client")
+	if !r.Allowed {
+		t.Fatal("request should be allowed after refill")
+	}
+	if r.Reset != 4*time.Second {
+		t.Errorf("Reset = %v, want 4s", r.Reset)
+	}
+
+	// 満杯以上には補充されない
+	*now = now.Add(time.Hour)
+	if r := m.Allow("client"); r.Remaining != 1 {
+		t.Errorf("Remaining after long idle = %d, want 1", r.Remaining)
+	}
+}
+
+func TestMemory_SweepIdleBuckets(t *testing.T) {
+	m, now := newTestMemory(Policy{PerMinute: 60, Burst: 5})
+
+	m.Allow("idle")
+	*now = now.Add(2 * time.Minute)
+	m.Allow("active")
+
+	if _, ok := m.buckets["idle"]; ok {
+		t.Error("refilled bucket should be swept")
+	}
+	if _, ok := m.buckets["active"]; !ok {
+		t.Error("active bucket should remain")
+	}
+}
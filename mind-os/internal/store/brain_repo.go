@@ -0,0 +1,78 @@
+package store
+
+import (
+	"time"
+)
+
+// BrainRecord は登録済みの脳（テナント）を表すレコード
+type BrainRecord struct {
+	ID        string
+	CreatedAt time.Time
+	Profile   string // パーソナリティプロファイル名
+}
+
+// CreateBrain は脳をプロファイル名とともに登録する (既に存在する場合は何もしない)
+func (d *DB) CreateBrain(id, profile string) error {
+	_, err := d.Exec(
+		"INSERT OR IGNORE INTO brains (id, created_at, profile) VALUES (?, ?, ?)",
+		id,
+		time.Now(),
+		profile,
+	)
+	return err
+}
+
+// BrainProfile は脳のプロファイル名を取得 (未登録の場合は sql.ErrNoRows)
+func (d *DB) BrainProfile(id string) (string, error) {
+	var profile string
+	err := d.QueryRow("SELECT profile FROM brains WHERE id = ?", id).Scan(&profile)
+	return profile, err
+}
+
+// BrainExists は脳が登録済みかを判定
+func (d *DB) BrainExists(id string) (bool, error) {
+	var count int
+	err := d.QueryRow("SELECT COUNT(*) FROM brains WHERE id = ?", id).Scan(&count)
+	return count > 0, err
+}
+
+// ListBrains は登録済みの脳を作成日時順に取得
+func (d *DB) ListBrains() ([]BrainRecord, error) {
+	rows, err := d.Query("SELECT id, created_at, profile FROM brains ORDER BY created_at ASC, id ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var brains []BrainRecord
+	for rows.Next() {
+		var b BrainRecord
+		if err := rows.Scan(&b.ID, &b.CreatedAt, &b.Profile); err != nil {
+			return nil, err
+		}
+		brains = append(brains, b)
+	}
+
+	return brains, rows.Err()
+}
+
+// DeleteBrain は脳の登録と、その脳に属する全ての記憶・状態を削除
+func (d *DB) DeleteBrain(id string) error {
+	tx, err := d.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM memories WHERE brain_id = ?", id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM brain_states WHERE brain_id = ?", id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM brains WHERE id = ?", id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
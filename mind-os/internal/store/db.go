@@ -2,15 +2,24 @@ package store
 
 import (
 	"database/sql"
+	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	_ "modernc.org/sqlite" // Pure Go SQLite driver
 )
 
+// DefaultBrainID は脳IDが指定されない場合に使用される既定の脳ID
+const DefaultBrainID = "default"
+
 // DB はデータベース接続を管理
+// 記憶は脳ID (brainID) ごとに分離され、ForBrain で特定の脳に絞り込んだビューを取得できる
 type DB struct {
 	*sql.DB
+
+	brainID string // 操作対象の脳ID
+	scoped  bool   // ForBrain で生成されたビューか (接続の所有権を持たない)
 }
 
 // NewDB は新しいデータベース接続を作成・初期化
@@ -21,7 +30,11 @@ func NewDB(dbPath string) (*DB, error) {
 		return nil, err
 	}
 
-	db, err := sql.Open("sqlite", dbPath)
+	// 日時は SQLite の日付関数で扱える形式で保存する
+	// 複数の脳が同じファイルへ同時に書き込むため、WAL モードで読み書きを並行させ、
+	// 書き込みの競合はロックが解放されるまで待つ (即座に SQLITE_BUSY を返さない)
+	db, err := sql.Open("sqlite", dbPath+"?_time_format=sqlite"+
+		"&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +43,7 @@ func NewDB(dbPath string) (*DB, error) {
 		return nil, err
 	}
 
-	store := &DB{db}
+	store := &DB{DB: db, brainID: DefaultBrainID}
 	if err := store.initSchema(); err != nil {
 		db.Close()
 		return nil, err
@@ -39,30 +52,245 @@ func NewDB(dbPath string) (*DB, error) {
 	return store, nil
 }
 
+// ForBrain は指定した脳IDに絞り込んだビューを返す
+// 接続プールは共有されるため、返されたビューの Close は何もしない
+func (d *DB) ForBrain(brainID string) *DB {
+	return &DB{DB: d.DB, brainID: brainID, scoped: true}
+}
+
+// BrainID はこのビューが操作対象とする脳IDを返す
+func (d *DB) BrainID() string {
+	return d.brainID
+}
+
 // initSchema はテーブルスキーマを初期化
 func (d *DB) initSchema() error {
 	schema := `
 	CREATE TABLE IF NOT EXISTS memories (
 		uuid TEXT PRIMARY KEY,
+		brain_id TEXT NOT NULL DEFAULT 'default',
 		text TEXT NOT NULL,
 		emotions TEXT NOT NULL, -- JSON string
 		weight REAL NOT NULL,
 		type TEXT NOT NULL,
 		created_at DATETIME NOT NULL,
 		last_access DATETIME NOT NULL,
-		tags TEXT NOT NULL -- JSON string
+		tags TEXT NOT NULL, -- JSON string
+		recall_count INTEGER NOT NULL DEFAULT 0,
+		faded_at DATETIME, -- 最後に忘却曲線を適用した日時
+		language TEXT NOT NULL DEFAULT 'ja' -- 記憶内容の言語
 	);
-	
+
+	CREATE TABLE IF NOT EXISTS brains (
+		id TEXT PRIMARY KEY,
+		created_at DATETIME NOT NULL,
+		profile TEXT NOT NULL DEFAULT 'default' -- パーソナリティプロファイル名
+	);
+
+	CREATE TABLE IF NOT EXISTS brain_states (
+		brain_id TEXT PRIMARY KEY,
+		cortisol REAL NOT NULL,
+		oxytocin REAL NOT NULL,
+		melatonin REAL NOT NULL,
+		serotonin REAL NOT NULL,
+		hormones_updated_at DATETIME NOT NULL,
+		motivation REAL NOT NULL,
+		predicted_reward REAL NOT NULL,
+		sanity INTEGER NOT NULL,
+		last_input_text TEXT NOT NULL,
+		repetition_count INTEGER NOT NULL,
+		satiation_level REAL NOT NULL,
+		empathy_level REAL NOT NULL,
+		stm TEXT NOT NULL, -- JSON string
+		core_valence REAL NOT NULL DEFAULT 0, -- コアアフェクト (VAD)
+		core_arousal REAL NOT NULL DEFAULT 0,
+		core_dominance REAL NOT NULL DEFAULT 0,
+		saved_at DATETIME NOT NULL
+	);
+	`
+
+	if _, err := d.Exec(schema); err != nil {
+		return err
+	}
+
+	// 既存DBのスキーマを最新に追従させる
+	if err := d.migrate(); err != nil {
+		return err
+	}
+
+	indexes := `
 	CREATE INDEX IF NOT EXISTS idx_memories_type ON memories(type);
 	CREATE INDEX IF NOT EXISTS idx_memories_created_at ON memories(created_at);
 	CREATE INDEX IF NOT EXISTS idx_memories_weight ON memories(weight);
+	CREATE INDEX IF NOT EXISTS idx_memories_brain_id ON memories(brain_id);
+	`
+
+	if _, err := d.Exec(indexes); err != nil {
+		return err
+	}
+
+	return d.initFullTextSearch()
+}
+
+// initFullTextSearch は記憶本文とタグの全文検索インデックス (FTS5) を初期化する
+// 日本語は単語境界がないため trigram トークナイザを使用する
+// memories を外部コンテンツとし、トリガーで同期する
+func (d *DB) initFullTextSearch() error {
+	var exists int
+	if err := d.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'memories_fts'").Scan(&exists); err != nil {
+		return err
+	}
+
+	fts := `
+	CREATE VIRTUAL TABLE IF NOT EXISTS memories_fts USING fts5(
+		text,
+		tags,
+		content = 'memories',
+		content_rowid = 'rowid',
+		tokenize = 'trigram'
+	);
+
+	CREATE TRIGGER IF NOT EXISTS memories_fts_insert AFTER INSERT ON memories BEGIN
+		INSERT INTO memories_fts (rowid, text, tags) VALUES (new.rowid, new.text, new.tags);
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS memories_fts_delete AFTER DELETE ON memories BEGIN
+		INSERT INTO memories_fts (memories_fts, rowid, text, tags) VALUES ('delete', old.rowid, old.text, old.tags);
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS memories_fts_update AFTER UPDATE OF text, tags ON memories BEGIN
+		INSERT INTO memories_fts (memories_fts, rowid, text, tags) VALUES ('delete', old.rowid, old.text, old.tags);
+		INSERT INTO memories_fts (rowid, text, tags) VALUES (new.rowid, new.text, new.tags);
+	END;
 	`
+	if _, err := d.Exec(fts); err != nil {
+		return fmt.Errorf("init full-text search: %w", err)
+	}
+
+	// 既存DBに初めてインデックスを作成した場合は既存の記憶から再構築する
+	if exists == 0 {
+		if _, err := d.Exec("INSERT INTO memories_fts (memories_fts) VALUES ('rebuild')"); err != nil {
+			return fmt.Errorf("rebuild full-text search: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// columnMigrations は旧バージョンのスキーマに追加するカラムの一覧 (適用順)
+var columnMigrations = []struct {
+	table      string
+	column     string
+	definition string
+}{
+	{"memories", "brain_id", "TEXT NOT NULL DEFAULT 'default'"},
+	{"memories", "recall_count", "INTEGER NOT NULL DEFAULT 0"},
+	{"memories", "faded_at", "DATETIME"},
+	{"brains", "profile", "TEXT NOT NULL DEFAULT 'default'"},
+	{"brain_states", "core_valence", "REAL NOT NULL DEFAULT 0"},
+	{"brain_states", "core_arousal", "REAL NOT NULL DEFAULT 0"},
+	{"brain_states", "core_dominance", "REAL NOT NULL DEFAULT 0"},
+	{"memories", "language", "TEXT NOT NULL DEFAULT 'ja'"},
+}
+
+// migrate は旧バージョンのスキーマに不足しているカラムを追加する
+func (d *DB) migrate() error {
+	for _, m := range columnMigrations {
+		if err := d.addColumnIfMissing(m.table, m.column, m.definition); err != nil {
+			return fmt.Errorf("migrate %s.%s: %w", m.table, m.column, err)
+		}
+	}
+
+	if err := d.normalizeTimestamps(); err != nil {
+		return fmt.Errorf("migrate timestamps: %w", err)
+	}
+	return nil
+}
+
+// normalizeTimestamps は旧バージョンで Go の time.String() 形式で保存された記憶の日時を
+// SQLite の日付関数 (julianday など) で比較できる形式に書き換える
+func (d *DB) normalizeTimestamps() error {
+	rows, err := d.Query(`
+	SELECT rowid, created_at, last_access, faded_at
+	FROM memories
+	WHERE julianday(created_at) IS NULL
+	   OR julianday(last_access) IS NULL
+	   OR (faded_at IS NOT NULL AND julianday(faded_at) IS NULL)
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
-	_, err := d.Exec(schema)
+	type timestamps struct {
+		rowid      int64
+		createdAt  time.Time
+		lastAccess time.Time
+		fadedAt    sql.NullTime
+	}
+	var legacy []timestamps
+	for rows.Next() {
+		var ts timestamps
+		if err := rows.Scan(&ts.rowid, &ts.createdAt, &ts.lastAccess, &ts.fadedAt); err != nil {
+			return err
+		}
+		legacy = append(legacy, ts)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for _, ts := range legacy {
+		_, err := d.Exec(
+			"UPDATE memories SET created_at = ?, last_access = ?, faded_at = ? WHERE rowid = ?",
+			ts.createdAt, ts.lastAccess, ts.fadedAt, ts.rowid,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addColumnIfMissing はカラムが存在しない場合のみ ALTER TABLE で追加する
+func (d *DB) addColumnIfMissing(table, column, definition string) error {
+	rows, err := d.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = d.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
 	return err
 }
 
 // Close はデータベース接続を閉じる
+// ForBrain で生成されたビューの場合は共有接続を閉じない
 func (d *DB) Close() error {
+	if d.scoped {
+		return nil
+	}
 	return d.DB.Close()
 }
@@ -1,7 +1,11 @@
 package store
 
 import (
+	"database/sql"
+	"fmt"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -30,6 +34,7 @@ func TestDB(t *testing.T) {
 		CreatedAt:  time.Now(),
 		LastAccess: time.Now(),
 		Tags:       []string{"test"},
+		Language:   models.LanguageEnglish,
 	}
 
 	if err := db.SaveMemory(memo); err != nil {
@@ -68,6 +73,9 @@ func TestDB(t *testing.T) {
 	if fetched.Weight != 0.8 {
 		t.Errorf("Weight mismatch: %f", fetched.Weight)
 	}
+	if fetched.Language != models.LanguageEnglish {
+		t.Errorf("Language mismatch: %q", fetched.Language)
+	}
 
 	// 5. DeleteOldMemories
 	// 追加でいくつか保存
@@ -90,3 +98,440 @@ func TestDB(t *testing.T) {
 		t.Errorf("Expected 3 memories after delete, got %d", newCount)
 	}
 }
+
+func TestDB_BrainIsolation(t *testing.T) {
+	dbPath := "test_mind_brains.db"
+	defer os.Remove(dbPath)
+
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to init DB: %v", err)
+	}
+	defer db.Close()
+
+	alice := db.ForBrain("alice")
+	bob := db.ForBrain("bob")
+
+	memo := models.RuneMemory{
+		UUID:       "alice-memory",
+		Text:       "Alice Memory",
+		Emotions:   []models.EmotionValue{{Code: models.EmotionJoy, Value: 80}},
+		Weight:     0.8,
+		Type:       models.MemoryLTM,
+		CreatedAt:  time.Now(),
+		LastAccess: time.Now(),
+		Tags:       []string{"J"},
+	}
+	if err := alice.SaveMemory(memo); err != nil {
+		t.Fatalf("SaveMemory failed: %v", err)
+	}
+
+	// 他の脳からは見えない
+	if count, _ := bob.GetLTMCount(); count != 0 {
+		t.Errorf("bob should not see alice's memories, got %d", count)
+	}
+	if m, _ := bob.GetMemoryByUUID("alice-memory"); m != nil {
+		t.Error("bob should not fetch alice's memory by UUID")
+	}
+	if count, _ := alice.GetLTMCount(); count != 1 {
+		t.Errorf("alice should have 1 memory, got %d", count)
+	}
+
+	// ビューのCloseは共有接続を閉じない
+	if err := alice.Close(); err != nil {
+		t.Errorf("scoped Close failed: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		t.Errorf("shared connection closed by scoped view: %v", err)
+	}
+
+	// 脳の登録と削除
+	if err := db.CreateBrain("alice", "calm"); err != nil {
+		t.Fatalf("CreateBrain failed: %v", err)
+	}
+	if exists, _ := db.BrainExists("alice"); !exists {
+		t.Error("alice should exist")
+	}
+	brains, err := db.ListBrains()
+	if err != nil || len(brains) != 1 || brains[0].ID != "alice" || brains[0].Profile != "calm" {
+		t.Errorf("ListBrains = %v, %v", brains, err)
+	}
+	if profile, err := db.BrainProfile("alice"); err != nil || profile != "calm" {
+		t.Errorf("BrainProfile = %q, %v, want calm", profile, err)
+	}
+
+	if err := db.DeleteBrain("alice"); err != nil {
+		t.Fatalf("DeleteBrain failed: %v", err)
+	}
+	if exists, _ := db.BrainExists("alice"); exists {
+		t.Error("alice should be deleted")
+	}
+	if count, _ := alice.GetLTMCount(); count != 0 {
+		t.Errorf("alice's memories should be deleted, got %d", count)
+	}
+}
+
+func TestDB_BrainState(t *testing.T) {
+	dbPath := "test_mind_state.db"
+	defer os.Remove(dbPath)
+
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to init DB: %v", err)
+	}
+	defer db.Close()
+
+	alice := db.ForBrain("alice")
+
+	// 未保存の場合は nil
+	if s, err := alice.LoadBrainState(); err != nil || s != nil {
+		t.Fatalf("LoadBrainState before save = %v, %v; want nil, nil", s, err)
+	}
+
+	snapshot := BrainStateSnapshot{
+		Cortisol:          70,
+		Oxytocin:          20,
+		Melatonin:         10,
+		Serotonin:         60,
+		HormonesUpdatedAt: time.Now(),
+		Motivation:        33.5,
+		PredictedReward:   41.25,
+		Sanity:            45,
+		LastInputText:     "またか",
+		RepetitionCount:   3,
+		SatiationLevel:    0.3,
+		EmpathyLevel:      0.7,
+		STM: []models.RuneMemory{
+			{UUID: "stm-1", Text: "短期記憶", Type: models.MemorySTM, Weight: 0.4},
+		},
+		CoreAffect: models.Affect{Valence: -0.4, Arousal: 0.3, Dominance: -0.2},
+		SavedAt:    time.Now(),
+	}
+	if err := alice.SaveBrainState(snapshot); err != nil {
+		t.Fatalf("SaveBrainState failed: %v", err)
+	}
+
+	// 上書き保存
+	snapshot.Sanity = 50
+	if err := alice.SaveBrainState(snapshot); err != nil {
+		t.Fatalf("SaveBrainState (update) failed: %v", err)
+	}
+
+	loaded, err := alice.LoadBrainState()
+	if err != nil || loaded == nil {
+		t.Fatalf("LoadBrainState failed: %v", err)
+	}
+	if loaded.Cortisol != 70 || loaded.Motivation != 33.5 || loaded.Sanity != 50 {
+		t.Errorf("Loaded state mismatch: %+v", loaded)
+	}
+	if loaded.LastInputText != "またか" || loaded.RepetitionCount != 3 {
+		t.Errorf("Thalamus state mismatch: %+v", loaded)
+	}
+	if len(loaded.STM) != 1 || loaded.STM[0].UUID != "stm-1" {
+		t.Errorf("STM mismatch: %+v", loaded.STM)
+	}
+	if loaded.CoreAffect != snapshot.CoreAffect {
+		t.Errorf("CoreAffect = %+v, want %+v", loaded.CoreAffect, snapshot.CoreAffect)
+	}
+
+	// 他の脳からは見えない
+	if s, _ := db.ForBrain("bob").LoadBrainState(); s != nil {
+		t.Error("bob should not load alice's state")
+	}
+}
+
+func TestDB_ConcurrentBrains(t *testing.T) {
+	// 複数の脳が同じデータベースへ同時に書き込んでも SQLITE_BUSY で失敗しない
+	db, err := NewDB(filepath.Join(t.TempDir(), "test_mind_concurrent.db"))
+	if err != nil {
+		t.Fatalf("Failed to init DB: %v", err)
+	}
+	defer db.Close()
+
+	const brains, writes = 8, 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, brains*writes*2)
+	for i := 0; i < brains; i++ {
+		wg.Add(1)
+		go func(brainID string) {
+			defer wg.Done()
+			view := db.ForBrain(brainID)
+			for j := 0; j < writes; j++ {
+				now := time.Now()
+				errs <- view.SaveMemory(models.RuneMemory{
+					UUID:       fmt.Sprintf("%s-%d", brainID, j),
+					Text:       "同時書き込み",
+					Emotions:   []models.EmotionValue{{Code: models.EmotionJoy, Value: 50}},
+					Weight:     0.5,
+					Type:       models.MemoryLTM,
+					CreatedAt:  now,
+					LastAccess: now,
+					Tags:       []string{"J"},
+				})
+				errs <- view.SaveBrainState(BrainStateSnapshot{Sanity: j, SavedAt: now})
+			}
+		}(fmt.Sprintf("brain-%d", i))
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent write failed: %v", err)
+		}
+	}
+	for i := 0; i < brains; i++ {
+		view := db.ForBrain(fmt.Sprintf("brain-%d", i))
+		if count, err := view.GetLTMCount(); err != nil || count != writes {
+			t.Errorf("brain-%d: GetLTMCount = %d, %v, want %d", i, count, err, writes)
+		}
+		if s, err := view.LoadBrainState(); err != nil || s == nil || s.Sanity != writes-1 {
+			t.Errorf("brain-%d: LoadBrainState = %+v, %v, want Sanity %d", i, s, err, writes-1)
+		}
+	}
+}
+
+func TestDB_UpdateMemory(t *testing.T) {
+	dbPath := "test_mind_update.db"
+	defer os.Remove(dbPath)
+
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to init DB: %v", err)
+	}
+	defer db.Close()
+
+	created := time.Now().Add(-time.Hour)
+	memo := models.RuneMemory{
+		UUID:       "update-uuid",
+		Text:       "想起される記憶",
+		Emotions:   []models.EmotionValue{{Code: models.EmotionJoy, Value: 80}},
+		Weight:     0.6,
+		Type:       models.MemoryLTM,
+		CreatedAt:  created,
+		LastAccess: created,
+		Tags:       []string{"positive"},
+	}
+	if err := db.SaveMemory(memo); err != nil {
+		t.Fatalf("SaveMemory failed: %v", err)
+	}
+
+	memo.Emotions = []models.EmotionValue{{Code: models.EmotionJoy, Value: 75}}
+	memo.Weight = 0.7
+	memo.RecallCount = 2
+	memo.LastAccess = time.Now()
+	if err := db.UpdateMemory(memo); err != nil {
+		t.Fatalf("UpdateMemory failed: %v", err)
+	}
+
+	got, err := db.GetMemoryByUUID("update-uuid")
+	if err != nil || got == nil {
+		t.Fatalf("GetMemoryByUUID failed: %v", err)
+	}
+	if got.RecallCount != 2 || got.Weight != 0.7 || got.Emotions[0].Value != 75 {
+		t.Errorf("Updated memory mismatch: %+v", got)
+	}
+	if !got.LastAccess.After(created) {
+		t.Errorf("LastAccess not updated: %v", got.LastAccess)
+	}
+
+	// 他の脳の記憶は更新できない
+	if err := db.ForBrain("other").UpdateMemory(memo); err != ErrMemoryNotFound {
+		t.Errorf("UpdateMemory from other brain = %v, want ErrMemoryNotFound", err)
+	}
+	if err := db.UpdateMemory(models.RuneMemory{UUID: "missing"}); err != ErrMemoryNotFound {
+		t.Errorf("UpdateMemory missing = %v, want ErrMemoryNotFound", err)
+	}
+}
+
+func TestDB_MigrateLegacySchema(t *testing.T) {
+	dbPath := "test_mind_legacy.db"
+	os.Remove(dbPath)
+	defer os.Remove(dbPath)
+
+	// brain_id / recall_count を持たない旧スキーマを作成
+	legacy, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open legacy DB: %v", err)
+	}
+	_, err = legacy.Exec(`
+	CREATE TABLE memories (
+		uuid TEXT PRIMARY KEY,
+		text TEXT NOT NULL,
+		emotions TEXT NOT NULL,
+		weight REAL NOT NULL,
+		type TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		last_access DATETIME NOT NULL,
+		tags TEXT NOT NULL
+	);
+	INSERT INTO memories VALUES ('legacy-uuid', '古い記憶', '[]', 0.9, 'LTM', '2024-01-01T00:00:00Z', '2024-01-01T00:00:00Z', '[]');
+	CREATE TABLE brains (
+		id TEXT PRIMARY KEY,
+		created_at DATETIME NOT NULL
+	);
+	INSERT INTO brains VALUES ('legacy-brain', '2024-01-01T00:00:00Z');
+	CREATE TABLE brain_states (
+		brain_id TEXT PRIMARY KEY,
+		cortisol REAL NOT NULL,
+		oxytocin REAL NOT NULL,
+		melatonin REAL NOT NULL,
+		serotonin REAL NOT NULL,
+		hormones_updated_at DATETIME NOT NULL,
+		motivation REAL NOT NULL,
+		predicted_reward REAL NOT NULL,
+		sanity INTEGER NOT NULL,
+		last_input_text TEXT NOT NULL,
+		repetition_count INTEGER NOT NULL,
+		satiation_level REAL NOT NULL,
+		empathy_level REAL NOT NULL,
+		stm TEXT NOT NULL,
+		saved_at DATETIME NOT NULL
+	);
+	INSERT INTO brain_states VALUES ('legacy-brain', 30, 40, 0, 50, '2024-01-01T00:00:00Z', 50, 50, 80, '', 0, 0, 0.5, '[]', '2024-01-01T00:00:00Z');
+	`)
+	legacy.Close()
+	if err != nil {
+		t.Fatalf("Failed to create legacy schema: %v", err)
+	}
+
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to migrate legacy DB: %v", err)
+	}
+	defer db.Close()
+
+	got, err := db.GetMemoryByUUID("legacy-uuid")
+	if err != nil || got == nil {
+		t.Fatalf("Legacy memory not readable after migration: %v", err)
+	}
+	if got.RecallCount != 0 {
+		t.Errorf("RecallCount = %d, want 0", got.RecallCount)
+	}
+	if got.Language != models.LanguageJapanese {
+		t.Errorf("Language = %q, want ja", got.Language)
+	}
+	if profile, err := db.BrainProfile("legacy-brain"); err != nil || profile != "default" {
+		t.Errorf("legacy brain profile = %q, %v, want default", profile, err)
+	}
+	state, err := db.ForBrain("legacy-brain").LoadBrainState()
+	if err != nil || state == nil {
+		t.Fatalf("legacy brain state not readable after migration: %v", err)
+	}
+	if state.Cortisol != 30 || state.CoreAffect != (models.Affect{}) {
+		t.Errorf("legacy brain state = %+v, want cortisol 30 and neutral core affect", state)
+	}
+}
+
+func TestDB_FadeMemories(t *testing.T) {
+	dbPath := "test_mind_fade.db"
+	os.Remove(dbPath)
+	defer os.Remove(dbPath)
+
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to init DB: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	twoDaysAgo := now.Add(-48 * time.Hour)
+	memories := []models.RuneMemory{
+		// 感情なし・想起なし: S=24h, 48h後に 0.9×e^-2 ≈ 0.12
+		{UUID: "plain", Weight: 0.9, LastAccess: twoDaysAgo},
+		// 強い感情・3回想起: S=24h×4×3=288h, ほとんど減衰しない
+		{UUID: "vivid", Weight: 0.9, LastAccess: twoDaysAgo, RecallCount: 3,
+			Emotions: []models.EmotionValue{{Code: models.EmotionFear, Value: 100}}},
+		// 重みが小さく下限を下回る
+		{UUID: "faint", Weight: 0.2, LastAccess: twoDaysAgo},
+	}
+	for _, m := range memories {
+		m.Text = m.UUID
+		m.Type = models.MemoryLTM
+		m.CreatedAt = twoDaysAgo
+		if err := db.SaveMemory(m); err != nil {
+			t.Fatalf("SaveMemory failed: %v", err)
+		}
+	}
+
+	curve := DefaultForgettingCurve()
+	result, err := db.FadeMemories(curve, now)
+	if err != nil {
+		t.Fatalf("FadeMemories failed: %v", err)
+	}
+	if result.Faded != 2 || result.Removed != 1 {
+		t.Errorf("FadeResult = %+v, want {Faded:2 Removed:1}", result)
+	}
+
+	plain, _ := db.GetMemoryByUUID("plain")
+	vivid, _ := db.GetMemoryByUUID("vivid")
+	if plain == nil || vivid == nil {
+		t.Fatal("Faded memories should remain")
+	}
+	if plain.Weight > 0.13 || plain.Weight < 0.11 {
+		t.Errorf("plain weight = %v, want ~0.12", plain.Weight)
+	}
+	if vivid.Weight < 0.75 {
+		t.Errorf("vivid weight = %v, want > 0.75", vivid.Weight)
+	}
+	if faint, _ := db.GetMemoryByUUID("faint"); faint != nil {
+		t.Error("faint memory should be removed")
+	}
+
+	// 同時刻に再適用しても二重に減衰しない
+	weight := plain.Weight
+	if _, err := db.FadeMemories(curve, now); err != nil {
+		t.Fatalf("FadeMemories (again) failed: %v", err)
+	}
+	plain, _ = db.GetMemoryByUUID("plain")
+	if plain.Weight != weight {
+		t.Errorf("plain weight after re-fade = %v, want %v", plain.Weight, weight)
+	}
+}
+
+func TestDB_FindMemoriesByTags(t *testing.T) {
+	dbPath := "test_mind_tags.db"
+	os.Remove(dbPath)
+	defer os.Remove(dbPath)
+
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to init DB: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	memories := []models.RuneMemory{
+		{UUID: "cat", Weight: 0.5, Tags: []string{"Jo", "猫"}},
+		{UUID: "cat-cafe", Weight: 0.9, Tags: []string{"猫", "カフェ"}},
+		{UUID: "rain", Weight: 0.7, Tags: []string{"雨"}},
+	}
+	for _, m := range memories {
+		m.Text = m.UUID
+		m.Type = models.MemoryLTM
+		m.CreatedAt = now
+		m.LastAccess = now
+		if err := db.SaveMemory(m); err != nil {
+			t.Fatalf("SaveMemory failed: %v", err)
+		}
+	}
+
+	found, err := db.FindMemoriesByTags([]string{"猫", "カフェ"}, 10)
+	if err != nil {
+		t.Fatalf("FindMemoriesByTags failed: %v", err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("Found %d memories, want 2", len(found))
+	}
+	if found[0].UUID != "cat-cafe" {
+		t.Errorf("First result = %v, want cat-cafe (highest weight)", found[0].UUID)
+	}
+
+	if found, _ := db.ForBrain("other").FindMemoriesByTags([]string{"猫"}, 10); len(found) != 0 {
+		t.Errorf("Other brain found %d memories, want 0", len(found))
+	}
+	if found, _ := db.FindMemoriesByTags(nil, 10); len(found) != 0 {
+		t.Errorf("Empty tags found %d memories, want 0", len(found))
+	}
+}
@@ -0,0 +1,182 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// ForgettingCurve はエビングハウスの忘却曲線のパラメータ
+// 【神経科学的意味】
+// 保持率 R = exp(-t/S) に従って記憶痕跡は指数的に減衰する。
+// 安定度 S は想起(間隔反復)と情動的喚起(扁桃体による固定化の増強)で大きくなる。
+type ForgettingCurve struct {
+	BaseStability time.Duration // 想起0回・感情強度0の記憶の安定度 S0
+	RecallFactor  float64       // 想起1回あたりの安定度の増加率
+	EmotionFactor float64       // 感情強度 (0.0-1.0) による安定度の増加率
+	Floor         float64       // この重みを下回った記憶は削除される
+}
+
+// DefaultForgettingCurve は既定の忘却曲線パラメータを返す
+func DefaultForgettingCurve() ForgettingCurve {
+	return ForgettingCurve{
+		BaseStability: 24 * time.Hour,
+		RecallFactor:  1.0,
+		EmotionFactor: 2.0,
+		Floor:         0.05,
+	}
+}
+
+// Stability は想起回数と感情強度から記憶の安定度 S を計算
+// S = S0 × (1 + RecallFactor × 想起回数) × (1 + EmotionFactor × 感情強度)
+func (fc ForgettingCurve) Stability(recallCount int, intensity float64) time.Duration {
+	s := float64(fc.BaseStability) *
+		(1 + fc.RecallFactor*float64(recallCount)) *
+		(1 + fc.EmotionFactor*intensity)
+	return time.Duration(s)
+}
+
+// Retention は経過時間 elapsed 後の保持率 exp(-t/S) を計算
+func (fc ForgettingCurve) Retention(elapsed time.Duration, recallCount int, intensity float64) float64 {
+	if elapsed <= 0 {
+		return 1.0
+	}
+	s := fc.Stability(recallCount, intensity)
+	if s <= 0 {
+		return 0.0
+	}
+	return math.Exp(-float64(elapsed) / float64(s))
+}
+
+// FadeResult は忘却処理の結果
+type FadeResult struct {
+	Faded   int // 重みが減衰した記憶数
+	Removed int // 下限を下回り削除された記憶数
+}
+
+// fadeCandidate は減衰計算に必要な記憶の列
+type fadeCandidate struct {
+	uuid        string
+	weight      float64
+	recallCount int
+	intensity   float64
+	since       time.Time // 重みが最後に確定した時刻
+}
+
+// FadeMemories は長期記憶全体に忘却曲線を一括適用する
+// 【処理内容】
+// 1. 重みが最後に確定した時刻 (最終アクセスと前回の減衰処理の新しい方) からの経過時間で保持率を計算
+// 2. 重みを更新し、faded_at に now を記録 (次回は差分のみ減衰させる)
+// 3. 重みが Floor を下回った記憶を削除
+// 全ての更新は1つのトランザクション内で行われる
+func (d *DB) FadeMemories(curve ForgettingCurve, now time.Time) (FadeResult, error) {
+	var result FadeResult
+
+	candidates, err := d.fadeCandidates()
+	if err != nil {
+		return result, err
+	}
+	if len(candidates) == 0 {
+		return result, nil
+	}
+
+	tx, err := d.Begin()
+	if err != nil {
+		return result, err
+	}
+	defer tx.Rollback()
+
+	update, err := tx.Prepare("UPDATE memories SET weight = ?, faded_at = ? WHERE uuid = ? AND brain_id = ?")
+	if err != nil {
+		return result, err
+	}
+	defer update.Close()
+
+	remove, err := tx.Prepare("DELETE FROM memories WHERE uuid = ? AND brain_id = ?")
+	if err != nil {
+		return result, err
+	}
+	defer remove.Close()
+
+	for _, c := range candidates {
+		retention := curve.Retention(now.Sub(c.since), c.recallCount, c.intensity)
+		if retention >= 1.0 {
+			continue
+		}
+
+		weight := c.weight * retention
+		if weight < curve.Floor {
+			if _, err := remove.Exec(c.uuid, d.brainID); err != nil {
+				return result, err
+			}
+			result.Removed++
+			continue
+		}
+
+		if _, err := update.Exec(weight, now, c.uuid, d.brainID); err != nil {
+			return result, err
+		}
+		result.Faded++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return FadeResult{}, err
+	}
+	return result, nil
+}
+
+// fadeCandidates は減衰対象となる長期記憶を取得
+func (d *DB) fadeCandidates() ([]fadeCandidate, error) {
+	rows, err := d.Query(`
+	SELECT uuid, weight, recall_count, emotions, last_access, faded_at
+	FROM memories
+	WHERE brain_id = ? AND type = ?
+	`, d.brainID, models.MemoryLTM)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []fadeCandidate
+	for rows.Next() {
+		var c fadeCandidate
+		var emotionsJSON string
+		var lastAccess time.Time
+		var fadedAt sql.NullTime
+		if err := rows.Scan(&c.uuid, &c.weight, &c.recallCount, &emotionsJSON, &lastAccess, &fadedAt); err != nil {
+			return nil, err
+		}
+
+		var emotions []models.EmotionValue
+		if err := json.Unmarshal([]byte(emotionsJSON), &emotions); err != nil {
+			return nil, err
+		}
+		c.intensity = emotionalIntensity(emotions)
+
+		c.since = lastAccess
+		if fadedAt.Valid && fadedAt.Time.After(lastAccess) {
+			c.since = fadedAt.Time
+		}
+
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
+
+// emotionalIntensity は最も強い感情の値を 0.0-1.0 に正規化して返す
+func emotionalIntensity(emotions []models.EmotionValue) float64 {
+	peak := 0
+	for _, e := range emotions {
+		if e.Value > peak {
+			peak = e.Value
+		}
+	}
+	if peak > 100 {
+		peak = 100
+	}
+	return float64(peak) / 100.0
+}
@@ -0,0 +1,189 @@
+package store
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// MemorySort は記憶一覧の並び順の基準
+type MemorySort string
+
+const (
+	SortByLastAccess MemorySort = "lastAccess" // 最終アクセス日時
+	SortByCreatedAt  MemorySort = "createdAt"  // 作成日時
+	SortByWeight     MemorySort = "weight"     // 重み
+)
+
+// MemoryCursor はキーセットページングの位置 (直前のページの最後の記憶)
+// Sort に応じて Time または Weight のどちらかが使われ、同値の場合は UUID で順序を決める
+type MemoryCursor struct {
+	Time   time.Time
+	Weight float64
+	UUID   string
+}
+
+// MemoryListQuery は記憶一覧の取得条件
+// ゼロ値のフィルタは条件として使用されない
+type MemoryListQuery struct {
+	Type      models.MemoryType  // 記憶タイプ (STM/LTM)
+	Tag       string             // 指定したタグを含む記憶に限定
+	Emotion   models.EmotionCode // 指定した感情を含む記憶に限定
+	Sort      MemorySort         // 並び順の基準 (既定: SortByLastAccess)
+	Ascending bool               // 昇順 (既定は降順)
+	After     *MemoryCursor      // この位置より後の記憶を取得
+	Limit     int                // 取得件数 (0 の場合は DefaultSearchLimit)
+}
+
+// sortKey は並び順の基準に対応するカラム式を返す
+// 日時は文字列として保存されているため julianday で数値化して比較する
+func (q MemoryListQuery) sortKey() string {
+	switch q.Sort {
+	case SortByCreatedAt:
+		return "julianday(m.created_at)"
+	case SortByWeight:
+		return "m.weight"
+	default:
+		return "julianday(m.last_access)"
+	}
+}
+
+// sortTime は記憶の日時ソートキーを返す
+// SQLite の julianday はミリ秒精度のため、Go 側の比較も同じ精度に揃える
+func (q MemoryListQuery) sortTime(m models.RuneMemory) time.Time {
+	if q.Sort == SortByCreatedAt {
+		return m.CreatedAt.Round(time.Millisecond)
+	}
+	return m.LastAccess.Round(time.Millisecond)
+}
+
+// compare は並び順における a と b の前後関係を返す (a が先なら負)
+func (q MemoryListQuery) compare(a, b models.RuneMemory) int {
+	var c int
+	if q.Sort == SortByWeight {
+		switch {
+		case a.Weight < b.Weight:
+			c = -1
+		case a.Weight > b.Weight:
+			c = 1
+		}
+	} else {
+		c = q.sortTime(a).Compare(q.sortTime(b))
+	}
+	if c == 0 {
+		c = strings.Compare(a.UUID, b.UUID)
+	}
+	if !q.Ascending {
+		c = -c
+	}
+	return c
+}
+
+// Less は並び順で a が b より前にあるかを判定する
+func (q MemoryListQuery) Less(a, b models.RuneMemory) bool {
+	return q.compare(a, b) < 0
+}
+
+// CursorFor は記憶の位置を表すカーソルを返す
+func (q MemoryListQuery) CursorFor(m models.RuneMemory) MemoryCursor {
+	cursor := MemoryCursor{Weight: m.Weight, UUID: m.UUID}
+	if q.Sort != SortByWeight {
+		cursor.Time = q.sortTime(m)
+	}
+	return cursor
+}
+
+// Matches は記憶がフィルタ条件を満たし、カーソルより後にあるかを判定する
+// 【用途】DBに存在しない短期記憶を同じ条件で絞り込む
+func (q MemoryListQuery) Matches(m models.RuneMemory) bool {
+	if q.Type != "" && m.Type != q.Type {
+		return false
+	}
+	if q.Tag != "" && !slices.Contains(m.Tags, q.Tag) {
+		return false
+	}
+	if q.Emotion != "" && !slices.ContainsFunc(m.Emotions, func(e models.EmotionValue) bool {
+		return e.Code == q.Emotion
+	}) {
+		return false
+	}
+	if q.After != nil {
+		pivot := models.RuneMemory{
+			UUID:       q.After.UUID,
+			Weight:     q.After.Weight,
+			CreatedAt:  q.After.Time,
+			LastAccess: q.After.Time,
+		}
+		if q.compare(m, pivot) <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ListMemories はフィルタ・並び順・カーソルを指定して記憶の一覧を取得する
+func (d *DB) ListMemories(q MemoryListQuery) ([]models.RuneMemory, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	where := []string{"m.brain_id = ?"}
+	args := []any{d.brainID}
+
+	if q.Type != "" {
+		where = append(where, "m.type = ?")
+		args = append(args, string(q.Type))
+	}
+	if q.Tag != "" {
+		where = append(where, "EXISTS (SELECT 1 FROM json_each(m.tags) WHERE json_each.value = ?)")
+		args = append(args, q.Tag)
+	}
+	if q.Emotion != "" {
+		where = append(where, "EXISTS (SELECT 1 FROM json_each(m.emotions) WHERE json_extract(json_each.value, '$.code') = ?)")
+		args = append(args, string(q.Emotion))
+	}
+
+	key := q.sortKey()
+	op, direction := "<", "DESC"
+	if q.Ascending {
+		op, direction = ">", "ASC"
+	}
+
+	if q.After != nil {
+		var value any = q.After.Weight
+		if q.Sort != SortByWeight {
+			where = append(where, "("+key+" "+op+" julianday(?) OR ("+key+" = julianday(?) AND m.uuid "+op+" ?))")
+			value = q.After.Time.Format(time.RFC3339Nano)
+		} else {
+			where = append(where, "("+key+" "+op+" ? OR ("+key+" = ? AND m.uuid "+op+" ?))")
+		}
+		args = append(args, value, value, q.After.UUID)
+	}
+
+	query := `
+	SELECT ` + prefixColumns("m", memoryColumns) + `
+	FROM memories m
+	WHERE ` + strings.Join(where, " AND ") + `
+	ORDER BY ` + key + ` ` + direction + `, m.uuid ` + direction + `
+	LIMIT ?
+	`
+	rows, err := d.Query(query, append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var memories []models.RuneMemory
+	for rows.Next() {
+		m, err := scanMemory(rows)
+		if err != nil {
+			return nil, err
+		}
+		memories = append(memories, m)
+	}
+
+	return memories, rows.Err()
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+func TestListMemories(t *testing.T) {
+	db := setupSearchDB(t, "test_mind_list.db")
+
+	tests := []struct {
+		name  string
+		query MemoryListQuery
+		want  []string
+	}{
+		{"既定 (最終アクセスの新しい順)", MemoryListQuery{}, []string{"rain", "cat-sick", "cat-cafe"}},
+		{"作成日時の昇順", MemoryListQuery{Sort: SortByCreatedAt, Ascending: true}, []string{"cat-cafe", "cat-sick", "rain"}},
+		{"重みの降順", MemoryListQuery{Sort: SortByWeight}, []string{"cat-cafe", "cat-sick", "rain"}},
+		{"タグ", MemoryListQuery{Tag: "猫"}, []string{"cat-sick", "cat-cafe"}},
+		{"感情", MemoryListQuery{Emotion: models.EmotionGrief}, []string{"rain", "cat-sick"}},
+		{"記憶タイプ", MemoryListQuery{Type: models.MemorySTM}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memories, err := db.ListMemories(tt.query)
+			if err != nil {
+				t.Fatalf("ListMemories failed: %v", err)
+			}
+			if got := uuids(memories); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListMemories_Cursor(t *testing.T) {
+	db := setupSearchDB(t, "test_mind_list_cursor.db")
+
+	// 同じ重みの記憶を追加し、UUIDによる順序付けを確認する
+	now := time.Now()
+	for _, uuid := range []string{"tie-a", "tie-b"} {
+		if err := db.SaveMemory(models.RuneMemory{
+			UUID: uuid, Text: uuid, Type: models.MemoryLTM, Weight: 0.7, CreatedAt: now, LastAccess: now,
+		}); err != nil {
+			t.Fatalf("SaveMemory failed: %v", err)
+		}
+	}
+
+	for _, sort := range []MemorySort{SortByLastAccess, SortByCreatedAt, SortByWeight} {
+		t.Run(string(sort), func(t *testing.T) {
+			all, err := db.ListMemories(MemoryListQuery{Sort: sort})
+			if err != nil {
+				t.Fatalf("ListMemories failed: %v", err)
+			}
+
+			// 2件ずつ辿った結果が一括取得と一致すること
+			var paged []models.RuneMemory
+			q := MemoryListQuery{Sort: sort, Limit: 2}
+			for range len(all) {
+				page, err := db.ListMemories(q)
+				if err != nil {
+					t.Fatalf("ListMemories failed: %v", err)
+				}
+				if len(page) == 0 {
+					break
+				}
+				paged = append(paged, page...)
+				cursor := q.CursorFor(page[len(page)-1])
+				q.After = &cursor
+			}
+
+			if got, want := uuids(paged), uuids(all); !slices.Equal(got, want) {
+				t.Errorf("paged %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDB_DeleteMemory(t *testing.T) {
+	dbPath := "test_mind_delete.db"
+	os.Remove(dbPath)
+	defer os.Remove(dbPath)
+
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to init DB: %v", err)
+	}
+	defer db.Close()
+
+	for _, uuid := range []string{"m1", "m2", "m3"} {
+		if err := db.SaveMemory(models.RuneMemory{UUID: uuid, Text: uuid, Type: models.MemoryLTM, CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("SaveMemory failed: %v", err)
+		}
+	}
+
+	if err := db.DeleteMemory("m1"); err != nil {
+		t.Fatalf("DeleteMemory failed: %v", err)
+	}
+	if err := db.DeleteMemory("m1"); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("DeleteMemory(deleted) error = %v, want ErrMemoryNotFound", err)
+	}
+
+	// 他の脳の記憶は削除できない
+	other := db.ForBrain("other")
+	if err := other.DeleteMemory("m2"); !errors.Is(err, ErrMemoryNotFound) {
+		t.Errorf("DeleteMemory(other brain) error = %v, want ErrMemoryNotFound", err)
+	}
+
+	deleted, err := db.DeleteMemories([]string{"m2", "m3", "missing"})
+	if err != nil {
+		t.Fatalf("DeleteMemories failed: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("DeleteMemories deleted = %d, want 2", deleted)
+	}
+
+	if count, _ := db.GetLTMCount(); count != 0 {
+		t.Errorf("GetLTMCount = %d, want 0", count)
+	}
+}
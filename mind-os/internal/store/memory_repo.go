@@ -3,10 +3,58 @@ package store
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"strings"
 
 	"github.com/umekku/mind-os/internal/models"
 )
 
+// ErrMemoryNotFound は対象の記憶が存在しない場合のエラー
+var ErrMemoryNotFound = errors.New("memory not found")
+
+// memoryColumns は記憶の取得時に SELECT するカラム (scanMemory と順序を一致させること)
+const memoryColumns = "uuid, text, emotions, weight, type, created_at, last_access, tags, recall_count, language"
+
+// rowScanner は *sql.Row と *sql.Rows の共通インターフェース
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanMemory は1行分の記憶をスキャンし、JSONカラムをデコードする
+func scanMemory(row rowScanner) (models.RuneMemory, error) {
+	var m models.RuneMemory
+	var emotionsJSON, tagsJSON string
+	var typeStr, langStr string
+
+	err := row.Scan(
+		&m.UUID,
+		&m.Text,
+		&emotionsJSON,
+		&m.Weight,
+		&typeStr,
+		&m.CreatedAt,
+		&m.LastAccess,
+		&tagsJSON,
+		&m.RecallCount,
+		&langStr,
+	)
+	if err != nil {
+		return m, err
+	}
+
+	m.Type = models.MemoryType(typeStr)
+	m.Language = models.Language(langStr)
+
+	if err := json.Unmarshal([]byte(emotionsJSON), &m.Emotions); err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal([]byte(tagsJSON), &m.Tags); err != nil {
+		return m, err
+	}
+
+	return m, nil
+}
+
 // SaveMemory は記憶を保存または更新
 func (d *DB) SaveMemory(m models.RuneMemory) error {
 	emotionsJSON, err := json.Marshal(m.Emotions)
@@ -19,13 +67,32 @@ func (d *DB) SaveMemory(m models.RuneMemory) error {
 		return err
 	}
 
+	lang := m.Language
+	if lang == "" {
+		lang = models.DefaultLanguage
+	}
+
+	// INSERT OR REPLACE は行の削除を伴い全文検索インデックスの削除トリガーが発火しないため、UPSERTで更新する
 	query := `
-	INSERT OR REPLACE INTO memories (uuid, text, emotions, weight, type, created_at, last_access, tags)
-	VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	INSERT INTO memories (uuid, brain_id, text, emotions, weight, type, created_at, last_access, tags, recall_count, language)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(uuid) DO UPDATE SET
+		text = excluded.text,
+		emotions = excluded.emotions,
+		weight = excluded.weight,
+		type = excluded.type,
+		created_at = excluded.created_at,
+		last_access = excluded.last_access,
+		tags = excluded.tags,
+		recall_count = excluded.recall_count,
+		language = excluded.language,
+		faded_at = NULL
+	WHERE memories.brain_id = excluded.brain_id
 	`
 
 	_, err = d.Exec(query,
 		m.UUID,
+		d.brainID,
 		m.Text,
 		string(emotionsJSON),
 		m.Weight,
@@ -33,21 +100,125 @@ func (d *DB) SaveMemory(m models.RuneMemory) error {
 		m.CreatedAt,
 		m.LastAccess,
 		string(tagsJSON),
+		m.RecallCount,
+		string(lang),
 	)
 
 	return err
 }
 
+// UpdateMemory は想起（再固定化）や編集によって変化した記憶の可変フィールドを更新
+// 【対象】感情、重み、最終アクセス日時、想起回数、タグ
+// 記憶が存在しない場合は ErrMemoryNotFound を返す
+func (d *DB) UpdateMemory(m models.RuneMemory) error {
+	emotionsJSON, err := json.Marshal(m.Emotions)
+	if err != nil {
+		return err
+	}
+
+	tagsJSON, err := json.Marshal(m.Tags)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	UPDATE memories
+	SET emotions = ?, weight = ?, last_access = ?, recall_count = ?, tags = ?
+	WHERE uuid = ? AND brain_id = ?
+	`
+
+	result, err := d.Exec(query,
+		string(emotionsJSON),
+		m.Weight,
+		m.LastAccess,
+		m.RecallCount,
+		string(tagsJSON),
+		m.UUID,
+		d.brainID,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrMemoryNotFound
+	}
+
+	return nil
+}
+
+// DeleteMemory は記憶を削除する
+// 記憶が存在しない場合は ErrMemoryNotFound を返す
+func (d *DB) DeleteMemory(uuid string) error {
+	result, err := d.Exec("DELETE FROM memories WHERE uuid = ? AND brain_id = ?", uuid, d.brainID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrMemoryNotFound
+	}
+
+	return nil
+}
+
+// DeleteMemories は複数の記憶を1トランザクションで削除し、削除した件数を返す
+// 存在しないUUIDは無視される
+func (d *DB) DeleteMemories(uuids []string) (int, error) {
+	if len(uuids) == 0 {
+		return 0, nil
+	}
+
+	tx, err := d.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare("DELETE FROM memories WHERE uuid = ? AND brain_id = ?")
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	deleted := 0
+	for _, uuid := range uuids {
+		result, err := stmt.Exec(uuid, d.brainID)
+		if err != nil {
+			return 0, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		deleted += int(affected)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
 // GetRecentMemories は直近の記憶を取得
 func (d *DB) GetRecentMemories(limit int) ([]models.RuneMemory, error) {
 	query := `
-	SELECT uuid, text, emotions, weight, type, created_at, last_access, tags
+	SELECT ` + memoryColumns + `
 	FROM memories
+	WHERE brain_id = ?
 	ORDER BY last_access DESC
 	LIMIT ?
 	`
 
-	rows, err := d.Query(query, limit)
+	rows, err := d.Query(query, d.brainID, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -55,43 +226,20 @@ func (d *DB) GetRecentMemories(limit int) ([]models.RuneMemory, error) {
 
 	var memories []models.RuneMemory
 	for rows.Next() {
-		var m models.RuneMemory
-		var emotionsJSON, tagsJSON string
-		var typeStr string
-
-		err := rows.Scan(
-			&m.UUID,
-			&m.Text,
-			&emotionsJSON,
-			&m.Weight,
-			&typeStr,
-			&m.CreatedAt,
-			&m.LastAccess,
-			&tagsJSON,
-		)
+		m, err := scanMemory(rows)
 		if err != nil {
 			return nil, err
 		}
-
-		m.Type = models.MemoryType(typeStr)
-
-		if err := json.Unmarshal([]byte(emotionsJSON), &m.Emotions); err != nil {
-			return nil, err
-		}
-		if err := json.Unmarshal([]byte(tagsJSON), &m.Tags); err != nil {
-			return nil, err
-		}
-
 		memories = append(memories, m)
 	}
 
-	return memories, nil
+	return memories, rows.Err()
 }
 
 // GetLTMCount は長期記憶の数を取得
 func (d *DB) GetLTMCount() (int, error) {
 	var count int
-	err := d.QueryRow("SELECT COUNT(*) FROM memories WHERE type = ?", models.MemoryLTM).Scan(&count)
+	err := d.QueryRow("SELECT COUNT(*) FROM memories WHERE brain_id = ? AND type = ?", d.brainID, models.MemoryLTM).Scan(&count)
 	return count, err
 }
 
@@ -115,52 +263,76 @@ func (d *DB) DeleteOldMemories(keepCount int) error {
 	// 削除対象: weight ASC (低い順), last_access ASC (古い順)
 	// 重要でなく、最近使われていないものから削除
 	query := `
-	DELETE FROM memories 
+	DELETE FROM memories
 	WHERE uuid IN (
-		SELECT uuid FROM memories 
-		WHERE type = ? 
-		ORDER BY weight ASC, last_access ASC 
+		SELECT uuid FROM memories
+		WHERE brain_id = ? AND type = ?
+		ORDER BY weight ASC, last_access ASC
 		LIMIT ?
 	)
 	`
 
-	_, err = d.Exec(query, models.MemoryLTM, deleteCount)
+	_, err = d.Exec(query, d.brainID, models.MemoryLTM, deleteCount)
 	return err
 }
 
 // GetMemoryByUUID はUUIDで記憶を検索
 func (d *DB) GetMemoryByUUID(uuid string) (*models.RuneMemory, error) {
 	query := `
-	SELECT uuid, text, emotions, weight, type, created_at, last_access, tags
+	SELECT ` + memoryColumns + `
 	FROM memories
-	WHERE uuid = ?
+	WHERE uuid = ? AND brain_id = ?
 	`
 
-	var m models.RuneMemory
-	var emotionsJSON, tagsJSON string
-	var typeStr string
+	m, err := scanMemory(d.QueryRow(query, uuid, d.brainID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	err := d.QueryRow(query, uuid).Scan(
-		&m.UUID,
-		&m.Text,
-		&emotionsJSON,
-		&m.Weight,
-		&typeStr,
-		&m.CreatedAt,
-		&m.LastAccess,
-		&tagsJSON,
-	)
+	return &m, nil
+}
 
-	if err == sql.ErrNoRows {
+// FindMemoriesByTags はいずれかのタグを含む記憶を重みの高い順に取得
+// 【用途】海馬の連想想起における候補の絞り込み
+func (d *DB) FindMemoriesByTags(tags []string, limit int) ([]models.RuneMemory, error) {
+	if len(tags) == 0 {
 		return nil, nil
 	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(tags)), ", ")
+	query := `
+	SELECT ` + memoryColumns + `
+	FROM memories
+	WHERE brain_id = ?
+	  AND EXISTS (SELECT 1 FROM json_each(memories.tags) WHERE json_each.value IN (` + placeholders + `))
+	ORDER BY weight DESC, last_access DESC
+	LIMIT ?
+	`
+
+	args := make([]any, 0, len(tags)+2)
+	args = append(args, d.brainID)
+	for _, tag := range tags {
+		args = append(args, tag)
+	}
+	args = append(args, limit)
+
+	rows, err := d.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	m.Type = models.MemoryType(typeStr)
-	json.Unmarshal([]byte(emotionsJSON), &m.Emotions)
-	json.Unmarshal([]byte(tagsJSON), &m.Tags)
+	var memories []models.RuneMemory
+	for rows.Next() {
+		m, err := scanMemory(rows)
+		if err != nil {
+			return nil, err
+		}
+		memories = append(memories, m)
+	}
 
-	return &m, nil
+	return memories, rows.Err()
 }
@@ -0,0 +1,194 @@
+package store
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// DefaultSearchLimit は検索件数が指定されない場合の既定値
+const DefaultSearchLimit = 20
+
+// trigramMinLength は FTS5 (trigram) で検索できる最小文字数
+// これより短いキーワードは LIKE による部分一致で検索する
+const trigramMinLength = 3
+
+// MemorySearchQuery は記憶検索の条件
+// ゼロ値のフィールドは条件として使用されない
+type MemorySearchQuery struct {
+	Text        string             // 本文・タグに対するキーワード (部分一致)
+	Emotion     models.EmotionCode // 指定した感情を含む記憶に限定
+	Type        models.MemoryType  // 記憶タイプ (STM/LTM)
+	MinWeight   *float64           // 重みの下限 (含む)
+	MaxWeight   *float64           // 重みの上限 (含む)
+	CreatedFrom *time.Time         // 作成日時の下限 (含む)
+	CreatedTo   *time.Time         // 作成日時の上限 (含む)
+	Limit       int                // 取得件数 (0 の場合は DefaultSearchLimit)
+	Offset      int                // 取得開始位置
+}
+
+// MemorySearchResult は記憶検索の結果
+type MemorySearchResult struct {
+	Memories []models.RuneMemory // 検索結果 (関連度順)
+	Total    int                 // ページングを考慮しない総件数
+}
+
+// Matches は記憶が検索条件を満たすかを判定する
+// 【用途】DBに存在しない短期記憶を同じ条件で絞り込む
+func (q MemorySearchQuery) Matches(m models.RuneMemory) bool {
+	if q.Text != "" {
+		keyword := strings.ToLower(q.Text)
+		found := strings.Contains(strings.ToLower(m.Text), keyword)
+		for _, tag := range m.Tags {
+			if found {
+				break
+			}
+			found = strings.Contains(strings.ToLower(tag), keyword)
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if q.Emotion != "" {
+		found := false
+		for _, e := range m.Emotions {
+			if e.Code == q.Emotion {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if q.Type != "" && m.Type != q.Type {
+		return false
+	}
+	if q.MinWeight != nil && m.Weight < *q.MinWeight {
+		return false
+	}
+	if q.MaxWeight != nil && m.Weight > *q.MaxWeight {
+		return false
+	}
+	if q.CreatedFrom != nil && m.CreatedAt.Before(*q.CreatedFrom) {
+		return false
+	}
+	if q.CreatedTo != nil && m.CreatedAt.After(*q.CreatedTo) {
+		return false
+	}
+
+	return true
+}
+
+// SearchMemories は全文検索とフィルタで記憶を検索する
+// 【処理内容】
+// - キーワードが3文字以上: FTS5 (trigram) で検索し、BM25 の関連度順に並べる
+// - キーワードが3文字未満: 本文・タグの部分一致 (LIKE) で検索する
+// - 同順位は重みの高い順
+func (d *DB) SearchMemories(q MemorySearchQuery) (MemorySearchResult, error) {
+	var result MemorySearchResult
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	from := "memories m"
+	orderBy := "m.weight DESC, m.uuid ASC"
+	where := []string{"m.brain_id = ?"}
+	args := []any{d.brainID}
+
+	if q.Text != "" {
+		if utf8.RuneCountInString(q.Text) >= trigramMinLength {
+			from = "memories m JOIN memories_fts ON memories_fts.rowid = m.rowid"
+			orderBy = "bm25(memories_fts), " + orderBy
+			where = append(where, "memories_fts MATCH ?")
+			args = append(args, ftsPhrase(q.Text))
+		} else {
+			pattern := "%" + escapeLike(q.Text) + "%"
+			where = append(where, `(m.text LIKE ? ESCAPE '\' OR m.tags LIKE ? ESCAPE '\')`)
+			args = append(args, pattern, pattern)
+		}
+	}
+	if q.Emotion != "" {
+		where = append(where, "EXISTS (SELECT 1 FROM json_each(m.emotions) WHERE json_extract(json_each.value, '$.code') = ?)")
+		args = append(args, string(q.Emotion))
+	}
+	if q.Type != "" {
+		where = append(where, "m.type = ?")
+		args = append(args, string(q.Type))
+	}
+	if q.MinWeight != nil {
+		where = append(where, "m.weight >= ?")
+		args = append(args, *q.MinWeight)
+	}
+	if q.MaxWeight != nil {
+		where = append(where, "m.weight <= ?")
+		args = append(args, *q.MaxWeight)
+	}
+	// 日時は文字列として保存されているため、タイムゾーン差を吸収するよう julianday で比較する
+	if q.CreatedFrom != nil {
+		where = append(where, "julianday(m.created_at) >= julianday(?)")
+		args = append(args, q.CreatedFrom.Format(time.RFC3339Nano))
+	}
+	if q.CreatedTo != nil {
+		where = append(where, "julianday(m.created_at) <= julianday(?)")
+		args = append(args, q.CreatedTo.Format(time.RFC3339Nano))
+	}
+
+	condition := strings.Join(where, " AND ")
+
+	if err := d.QueryRow("SELECT COUNT(*) FROM "+from+" WHERE "+condition, args...).Scan(&result.Total); err != nil {
+		return result, err
+	}
+	if result.Total == 0 || q.Offset >= result.Total {
+		return result, nil
+	}
+
+	query := `
+	SELECT ` + prefixColumns("m", memoryColumns) + `
+	FROM ` + from + `
+	WHERE ` + condition + `
+	ORDER BY ` + orderBy + `
+	LIMIT ? OFFSET ?
+	`
+	rows, err := d.Query(query, append(args, limit, q.Offset)...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		m, err := scanMemory(rows)
+		if err != nil {
+			return result, err
+		}
+		result.Memories = append(result.Memories, m)
+	}
+
+	return result, rows.Err()
+}
+
+// ftsPhrase はキーワードを FTS5 のフレーズ文字列としてクォートする
+// (演算子や記号を検索構文として解釈させない)
+func ftsPhrase(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+}
+
+// escapeLike は LIKE のワイルドカード文字をエスケープする
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+}
+
+// prefixColumns はカンマ区切りのカラム一覧にテーブル別名を付与する
+func prefixColumns(alias, columns string) string {
+	cols := strings.Split(columns, ",")
+	for i, c := range cols {
+		cols[i] = alias + "." + strings.TrimSpace(c)
+	}
+	return strings.Join(cols, ", ")
+}
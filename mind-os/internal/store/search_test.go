@@ -0,0 +1,194 @@
+package store
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+func setupSearchDB(t *testing.T, dbPath string) *DB {
+	t.Helper()
+	os.Remove(dbPath)
+	t.Cleanup(func() { os.Remove(dbPath) })
+
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to init DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	now := time.Now()
+	memories := []models.RuneMemory{
+		{UUID: "cat-cafe", Text: "猫カフェで猫と遊んだ", Weight: 0.9, CreatedAt: now.Add(-48 * time.Hour),
+			Emotions: []models.EmotionValue{{Code: models.EmotionJoy, Value: 80}}, Tags: []string{"J", "猫", "カフェ"}},
+		{UUID: "cat-sick", Text: "猫が病気になった", Weight: 0.7, CreatedAt: now.Add(-24 * time.Hour),
+			Emotions: []models.EmotionValue{{Code: models.EmotionGrief, Value: 70}}, Tags: []string{"G", "猫", "病気"}},
+		{UUID: "rain", Text: "雨の日は憂鬱", Weight: 0.4, CreatedAt: now,
+			Emotions: []models.EmotionValue{{Code: models.EmotionGrief, Value: 40}}, Tags: []string{"G", "雨"}},
+	}
+	for _, m := range memories {
+		m.Type = models.MemoryLTM
+		m.LastAccess = m.CreatedAt
+		if err := db.SaveMemory(m); err != nil {
+			t.Fatalf("SaveMemory failed: %v", err)
+		}
+	}
+	return db
+}
+
+func uuids(memories []models.RuneMemory) []string {
+	ids := make([]string, len(memories))
+	for i, m := range memories {
+		ids[i] = m.UUID
+	}
+	return ids
+}
+
+func TestSearchMemories(t *testing.T) {
+	db := setupSearchDB(t, "test_mind_search.db")
+
+	floatPtr := func(f float64) *float64 { return &f }
+	timePtr := func(t time.Time) *time.Time { return &t }
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		query MemorySearchQuery
+		want  []string
+	}{
+		{"FTS (3文字以上)", MemorySearchQuery{Text: "猫カフェ"}, []string{"cat-cafe"}},
+		{"部分一致 (3文字未満)", MemorySearchQuery{Text: "猫"}, []string{"cat-cafe", "cat-sick"}},
+		{"タグ", MemorySearchQuery{Text: "病気"}, []string{"cat-sick"}},
+		{"感情フィルタ", MemorySearchQuery{Text: "猫", Emotion: models.EmotionGrief}, []string{"cat-sick"}},
+		{"重み範囲", MemorySearchQuery{MinWeight: floatPtr(0.5), MaxWeight: floatPtr(0.8)}, []string{"cat-sick"}},
+		{"作成日時範囲", MemorySearchQuery{CreatedFrom: timePtr(now.Add(-36 * time.Hour)), CreatedTo: timePtr(now.Add(-time.Hour))}, []string{"cat-sick"}},
+		{"記憶タイプ", MemorySearchQuery{Text: "猫", Type: models.MemorySTM}, []string{}},
+		{"該当なし", MemorySearchQuery{Text: "存在しない話題"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := db.SearchMemories(tt.query)
+			if err != nil {
+				t.Fatalf("SearchMemories failed: %v", err)
+			}
+			got := uuids(result.Memories)
+			if len(got) != len(tt.want) || result.Total != len(tt.want) {
+				t.Fatalf("got %v (total %d), want %v", got, result.Total, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchMemories_Pagination(t *testing.T) {
+	db := setupSearchDB(t, "test_mind_search_page.db")
+
+	page, err := db.SearchMemories(MemorySearchQuery{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("SearchMemories failed: %v", err)
+	}
+	if page.Total != 3 {
+		t.Errorf("Total = %d, want 3", page.Total)
+	}
+	if got := uuids(page.Memories); len(got) != 2 || got[0] != "cat-sick" || got[1] != "rain" {
+		t.Errorf("Page = %v, want [cat-sick rain]", got)
+	}
+}
+
+func TestSearchMemories_IndexSync(t *testing.T) {
+	db := setupSearchDB(t, "test_mind_search_sync.db")
+
+	// 本文の更新がインデックスに反映される
+	memory, _ := db.GetMemoryByUUID("rain")
+	memory.Text = "晴れた日の散歩"
+	if err := db.SaveMemory(*memory); err != nil {
+		t.Fatalf("SaveMemory failed: %v", err)
+	}
+	if r, _ := db.SearchMemories(MemorySearchQuery{Text: "雨の日は"}); r.Total != 0 {
+		t.Errorf("Old text still indexed: %v", uuids(r.Memories))
+	}
+	if r, _ := db.SearchMemories(MemorySearchQuery{Text: "晴れた日"}); r.Total != 1 {
+		t.Errorf("New text not indexed: total %d", r.Total)
+	}
+
+	// 削除がインデックスに反映される
+	if err := db.DeleteBrain(DefaultBrainID); err != nil {
+		t.Fatalf("DeleteBrain failed: %v", err)
+	}
+	if r, _ := db.SearchMemories(MemorySearchQuery{Text: "猫カフェ"}); r.Total != 0 {
+		t.Errorf("Deleted memory still indexed: %v", uuids(r.Memories))
+	}
+
+	// 他の脳の記憶は検索されない
+	if r, _ := db.ForBrain("other").SearchMemories(MemorySearchQuery{Text: "晴れた日"}); r.Total != 0 {
+		t.Errorf("Other brain found %d memories", r.Total)
+	}
+}
+
+func TestSearchMemories_LegacyIndex(t *testing.T) {
+	dbPath := "test_mind_search_legacy.db"
+	os.Remove(dbPath)
+	defer os.Remove(dbPath)
+
+	// 全文検索インデックスがなく、日時が time.String() 形式の旧DB
+	legacy, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("Failed to open legacy DB: %v", err)
+	}
+	_, err = legacy.Exec(`
+	CREATE TABLE memories (
+		uuid TEXT PRIMARY KEY, text TEXT NOT NULL, emotions TEXT NOT NULL, weight REAL NOT NULL,
+		type TEXT NOT NULL, created_at DATETIME NOT NULL, last_access DATETIME NOT NULL, tags TEXT NOT NULL
+	)`)
+	if err == nil {
+		_, err = legacy.Exec("INSERT INTO memories VALUES ('legacy', '昔飼っていた猫の思い出', '[]', 0.8, 'LTM', ?, ?, '[]')",
+			time.Now(), time.Now())
+	}
+	legacy.Close()
+	if err != nil {
+		t.Fatalf("Failed to create legacy DB: %v", err)
+	}
+
+	db, err := NewDB(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to migrate legacy DB: %v", err)
+	}
+	defer db.Close()
+
+	from := time.Now().Add(-time.Hour)
+	result, err := db.SearchMemories(MemorySearchQuery{Text: "飼っていた", CreatedFrom: &from})
+	if err != nil {
+		t.Fatalf("SearchMemories failed: %v", err)
+	}
+	if result.Total != 1 {
+		t.Errorf("Legacy memory not found after migration: total %d", result.Total)
+	}
+}
+
+func TestMemorySearchQuery_Matches(t *testing.T) {
+	memory := models.RuneMemory{
+		Text:     "Cat Cafe",
+		Emotions: []models.EmotionValue{{Code: models.EmotionJoy, Value: 80}},
+		Weight:   0.6,
+		Type:     models.MemorySTM,
+		Tags:     []string{"J", "猫"},
+	}
+
+	if !(MemorySearchQuery{Text: "cat"}).Matches(memory) {
+		t.Error("Case-insensitive text should match")
+	}
+	if !(MemorySearchQuery{Text: "猫", Emotion: models.EmotionJoy}).Matches(memory) {
+		t.Error("Tag and emotion should match")
+	}
+	if (MemorySearchQuery{Type: models.MemoryLTM}).Matches(memory) {
+		t.Error("Different type should not match")
+	}
+}
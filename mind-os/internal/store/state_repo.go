@@ -0,0 +1,132 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/umekku/mind-os/internal/models"
+)
+
+// BrainStateSnapshot は脳の神経化学的状態のスナップショット
+// 再起動後もキャラクターの機嫌を維持するため、記憶以外の内部状態を保存する
+type BrainStateSnapshot struct {
+	// 視床下部 (Homeostasis)
+	Cortisol          float64
+	Oxytocin          float64
+	Melatonin         float64
+	Serotonin         float64
+	HormonesUpdatedAt time.Time
+
+	// 大脳基底核 (BasalGanglia)
+	Motivation      float64
+	PredictedReward float64
+
+	// 前頭前皮質 (PFC)
+	Sanity int
+
+	// 視床 (Thalamus) の順応状態
+	LastInputText   string
+	RepetitionCount int
+	SatiationLevel  float64
+
+	// ミラーニューロン (SocialCognition)
+	EmpathyLevel float64
+
+	// 海馬の短期記憶 (STM)
+	STM []models.RuneMemory
+
+	// コアアフェクト (VAD)
+	CoreAffect models.Affect
+
+	SavedAt time.Time
+}
+
+// SaveBrainState は脳の状態スナップショットを保存または更新
+func (d *DB) SaveBrainState(s BrainStateSnapshot) error {
+	stmJSON, err := json.Marshal(s.STM)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	INSERT OR REPLACE INTO brain_states (
+		brain_id, cortisol, oxytocin, melatonin, serotonin, hormones_updated_at,
+		motivation, predicted_reward, sanity,
+		last_input_text, repetition_count, satiation_level,
+		empathy_level, stm, core_valence, core_arousal, core_dominance, saved_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = d.Exec(query,
+		d.brainID,
+		s.Cortisol,
+		s.Oxytocin,
+		s.Melatonin,
+		s.Serotonin,
+		s.HormonesUpdatedAt,
+		s.Motivation,
+		s.PredictedReward,
+		s.Sanity,
+		s.LastInputText,
+		s.RepetitionCount,
+		s.SatiationLevel,
+		s.EmpathyLevel,
+		string(stmJSON),
+		s.CoreAffect.Valence,
+		s.CoreAffect.Arousal,
+		s.CoreAffect.Dominance,
+		s.SavedAt,
+	)
+
+	return err
+}
+
+// LoadBrainState は保存された脳の状態スナップショットを取得
+// 保存されていない場合は nil を返す
+func (d *DB) LoadBrainState() (*BrainStateSnapshot, error) {
+	query := `
+	SELECT cortisol, oxytocin, melatonin, serotonin, hormones_updated_at,
+		motivation, predicted_reward, sanity,
+		last_input_text, repetition_count, satiation_level,
+		empathy_level, stm, core_valence, core_arousal, core_dominance, saved_at
+	FROM brain_states
+	WHERE brain_id = ?
+	`
+
+	var s BrainStateSnapshot
+	var stmJSON string
+
+	err := d.QueryRow(query, d.brainID).Scan(
+		&s.Cortisol,
+		&s.Oxytocin,
+		&s.Melatonin,
+		&s.Serotonin,
+		&s.HormonesUpdatedAt,
+		&s.Motivation,
+		&s.PredictedReward,
+		&s.Sanity,
+		&s.LastInputText,
+		&s.RepetitionCount,
+		&s.SatiationLevel,
+		&s.EmpathyLevel,
+		&stmJSON,
+		&s.CoreAffect.Valence,
+		&s.CoreAffect.Arousal,
+		&s.CoreAffect.Dominance,
+		&s.SavedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(stmJSON), &s.STM); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
@@ -21,19 +21,42 @@ type Thalamus struct {
 	similarityThreshold float64 // 類似判定の閾値 (0.0-1.0)
 }
 
+// Option は Thalamus の設定を変更する関数
+type Option func(*Thalamus)
+
+// WithSimilarityThreshold は「同じ入力」とみなす類似度の閾値 (0-1) を設定する
+// 低いほど言い回しが違っても同じ刺激とみなし、早く飽きる
+func WithSimilarityThreshold(threshold float64) Option {
+	return func(t *Thalamus) {
+		t.similarityThreshold = threshold
+	}
+}
+
 // New は新しい Thalamus インスタンスを作成
-func New() *Thalamus {
-	return &Thalamus{
+func New(opts ...Option) *Thalamus {
+	t := &Thalamus{
 		LastInputText:       "",
 		RepetitionCount:     0,
 		SatiationLevel:      0.5, // 初期値: 中立
 		similarityThreshold: 0.8, // 80%以上の類似度で「同じ」と判定
 	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
 }
 
 // Filter は入力信号の強度係数 (Gain) を計算
 // 繰り返し入力に対して順応（慣れ）を適用し、反応を減衰させる
 func (t *Thalamus) Filter(input models.SensoryInput) (float64, error) {
+	return t.FilterInContext(input, nil)
+}
+
+// FilterInContext は会話の履歴を考慮して入力信号の強度係数 (Gain) を計算
+// 【神経科学的意味】会話の流れの中で同じ話を繰り返されると、間に別の相手との会話が挟まっても慣れが生じる
+// 【処理内容】直前の入力との比較に加え、会話の直近の発話 (古い順) のうち末尾から連続して類似するものを
+// 繰り返しとして数え、多い方を順応に用いる。history が空の場合は Filter と同じ
+func (t *Thalamus) FilterInContext(input models.SensoryInput, history []string) (float64, error) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
@@ -51,15 +74,21 @@ func (t *Thalamus) Filter(input models.SensoryInput) (float64, error) {
 		t.LastInputText = currentText
 	}
 
+	// 1.5. 会話の中での繰り返し
+	repetition := t.RepetitionCount
+	if n := t.countTrailingRepetitions(currentText, history); n > repetition {
+		repetition = n
+	}
+
 	// 2. ゲイン計算（順応による減衰）
 	// 基本ゲイン: 1.0
 	// 繰り返しが多いほど減衰: Gain = 1.0 / (1.0 + 0.5 * RepetitionCount)
 	// 例: 0回目 -> 1.0, 1回目 -> 0.67, 2回目 -> 0.5, 3回目 -> 0.4
-	gain := 1.0 / (1.0 + 0.5*float64(t.RepetitionCount))
+	gain := 1.0 / (1.0 + 0.5*float64(repetition))
 
 	// 3. 飽和度の更新
 	// 繰り返しが多いと飽和度が上がる（新しい刺激を求める）
-	t.SatiationLevel = float64(t.RepetitionCount) / 10.0
+	t.SatiationLevel = float64(repetition) / 10.0
 	if t.SatiationLevel > 1.0 {
 		t.SatiationLevel = 1.0
 	}
@@ -67,6 +96,18 @@ func (t *Thalamus) Filter(input models.SensoryInput) (float64, error) {
 	return gain, nil
 }
 
+// countTrailingRepetitions は履歴の末尾から連続して text と類似する発話の数を返す
+func (t *Thalamus) countTrailingRepetitions(text string, history []string) int {
+	count := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		if !t.checkSimilarity(text, history[i]) {
+			break
+		}
+		count++
+	}
+	return count
+}
+
 // checkSimilarity は2つのテキストの類似度を判定
 // 簡易実装: 完全一致または高い部分一致で true
 func (t *Thalamus) checkSimilarity(text1, text2 string) bool {
@@ -118,6 +159,33 @@ func (t *Thalamus) GetRepetitionCount() int {
 	return t.RepetitionCount
 }
 
+// AdaptationState は順応状態の永続化用スナップショット
+type AdaptationState struct {
+	LastInputText   string
+	RepetitionCount int
+	SatiationLevel  float64
+}
+
+// Snapshot は現在の順応状態を返す
+func (t *Thalamus) Snapshot() AdaptationState {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return AdaptationState{
+		LastInputText:   t.LastInputText,
+		RepetitionCount: t.RepetitionCount,
+		SatiationLevel:  t.SatiationLevel,
+	}
+}
+
+// Restore はスナップショットから順応状態を復元する
+func (t *Thalamus) Restore(s AdaptationState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.LastInputText = s.LastInputText
+	t.RepetitionCount = s.RepetitionCount
+	t.SatiationLevel = s.SatiationLevel
+}
+
 // Reset は状態をリセット
 func (t *Thalamus) Reset() {
 	t.mu.Lock()
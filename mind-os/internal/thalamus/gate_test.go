@@ -59,6 +59,29 @@ func TestFilter_RepeatedInput(t *testing.T) {
 	}
 }
 
+// TestFilterInContext は会話の履歴による順応をテスト
+func TestFilterInContext(t *testing.T) {
+	th := New()
+	input := models.SensoryInput{InputText: "ねえ聞いて"}
+
+	// 別の会話の入力が挟まっていても、この会話の中での繰り返しとして減衰する
+	th.Filter(models.SensoryInput{InputText: "別の話題"})
+	gain, err := th.FilterInContext(input, []string{"おはよう", "ねえ聞いて", "ねえ聞いて"})
+	if err != nil {
+		t.Fatalf("FilterInContext error: %v", err)
+	}
+	if want := 1.0 / (1.0 + 0.5*2); gain != want {
+		t.Errorf("Gain = %f, want %f", gain, want)
+	}
+
+	// 末尾が別の発話なら繰り返しとはみなさない
+	th = New()
+	gain, _ = th.FilterInContext(input, []string{"ねえ聞いて", "おはよう"})
+	if gain != 1.0 {
+		t.Errorf("Gain for interrupted repetition = %f, want 1.0", gain)
+	}
+}
+
 // TestCheckSimilarity は類似性判定をテスト
 func TestCheckSimilarity(t *testing.T) {
 	th := New()
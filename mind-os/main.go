@@ -1,22 +1,35 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	_ "github.com/umekku/mind-os/docs" // Swagger docs
+	"github.com/umekku/mind-os/internal/amygdala"
 	"github.com/umekku/mind-os/internal/config"
+	"github.com/umekku/mind-os/internal/core"
 	"github.com/umekku/mind-os/internal/handlers"
 	"github.com/umekku/mind-os/internal/middleware"
+	"github.com/umekku/mind-os/internal/personality"
+	"github.com/umekku/mind-os/internal/ratelimit"
 )
 
+// legacyAPISunset は旧API (/api/memory, /api/motivation) の廃止予定日
+const legacyAPISunset = "2027-04-30"
+
 // @title           Mind-OS API
 // @version         1.0
 // @description     Emotional Intelligence & Brain OS Microservice.
@@ -33,32 +46,47 @@ import (
 // @BasePath        /
 // @schemes         http
 
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+// @description                 `Authorization: Bearer <key>` も使用可能。スコープ: read (参照) / write (状態を変える操作) / admin (管理操作)
+
 func main() {
 	// 構造化ロガーの初期化 (JSON形式)
-	// 本番用ではログレベルを環境変数から制御する
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
-	slog.SetDefault(logger)
+	// 設定の読み込みまでは info レベルで出力する
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
 
 	// .envファイルを読み込み（存在しない場合はスキップ）
-	if err := godotenv.Load(); err != nil {
-		slog.Info(".env file not found, using environment variables or defaults")
-	}
+	envErr := godotenv.Load()
 
 	// 設定読み込み (エラーがある場合は内部でos.Exit(1))
 	cfg := config.LoadConfig()
 
+	// ログレベルを設定から反映 (LOG_LEVEL, DEBUG_MODE)
+	slog.SetDefault(newLogger(cfg))
+	if envErr != nil {
+		slog.Info(".env file not found, using environment variables or defaults")
+	}
+
 	// Ginモード設定
 	gin.SetMode(cfg.Mode)
 
 	// Ginルーターの初期化 (DefaultではなくNewを使用してカスタムミドルウェアを適用)
 	r := gin.New()
 
+	// X-Forwarded-For は信頼するプロキシからのものだけを使う (クライアントIPの詐称によるレート制限の回避を防ぐ)
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		slog.Error("Invalid TRUSTED_PROXIES", "error", err)
+		os.Exit(1)
+	}
+
 	// ミドルウェア適用
 	isDev := cfg.Mode != "release"
-	r.Use(gin.Recovery())                       // パニック回復
-	r.Use(middleware.LoggerMiddleware())        // カスタムロガー
-	r.Use(middleware.SecurityMiddleware(isDev)) // セキュリティヘッダー (HSTS, etc.)
-	r.Use(middleware.CORSMiddleware())          // CORS設定
+	r.Use(gin.Recovery())                                // パニック回復
+	r.Use(middleware.LoggerMiddleware())                 // カスタムロガー
+	r.Use(middleware.SecurityMiddleware(isDev))          // セキュリティヘッダー (HSTS, etc.)
+	r.Use(middleware.CORSMiddleware(newCORSConfig(cfg))) // CORS設定 (許可していないオリジンは拒否)
+	r.Use(middleware.AuthMiddleware(newAuthConfig(cfg))) // APIキー認証 (/health などは対象外)
 
 	// Swagger エンドポイント (Dev/Debugモードのみが望ましいが、要件に従い常時有効化)
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -86,20 +114,102 @@ func main() {
 		})
 	})
 
+	// 扁桃体の感情辞書 (基本・分野別・キャラクター別のレイヤー。全ての脳と感情分析APIで共有する)
+	lexicon, err := amygdala.LoadLexicon(amygdala.LexiconSources{
+		Base:          cfg.LexiconBase,
+		Domain:        cfg.LexiconDomain,
+		CharactersDir: cfg.LexiconCharactersDir,
+	})
+	if err != nil {
+		slog.Error("Failed to load emotion lexicon", "error", err)
+		os.Exit(1)
+	}
+	lexiconHandler := handlers.NewLexiconHandler(lexicon)
+
 	// 感情分析API
-	emotionHandler := handlers.NewEmotionHandler()
-	memoryHandler := handlers.NewMemoryHandler()
-	motivationHandler := handlers.NewMotivationHandler()
-	brainHandler := handlers.NewBrainHandler(cfg)
+	emotionHandler := handlers.NewEmotionHandler(lexicon)
+
+	// パーソナリティプロファイル (PROFILES_DIR のファイル + 設定から作る組み込みの default)
+	profiles, err := personality.Load(cfg.ProfilesDir, personality.FromConfig(cfg))
+	if err != nil {
+		slog.Error("Failed to load personality profiles", "error", err)
+		os.Exit(1)
+	}
+	if _, err := profiles.Get(cfg.DefaultProfile); err != nil {
+		slog.Error("Invalid DEFAULT_PROFILE", "error", err, "available", profiles.Names())
+		os.Exit(1)
+	}
+	slog.Info("Personality profiles loaded", "profiles", profiles.Names(), "default", cfg.DefaultProfile)
+
+	// 脳IDごとに独立した Brain を管理するレジストリ
+	// 旧API (/api/memory, /api/motivation) も同じ Brain を操作する
+	registry := core.NewRegistry(cfg, profiles, lexicon)
+	// レート制限 (APIキー、キーがなければクライアントIPごと)
+	// 会話 (WebSocket) のメッセージも入力と同じ予算を消費する
+	var rateLimit *middleware.RateLimitConfig
+	if cfg.RateLimitEnabled {
+		rateLimit = &middleware.RateLimitConfig{
+			Read:  ratelimit.NewMemory(ratelimit.Policy{PerMinute: cfg.RateLimitReadPerMinute, Burst: cfg.RateLimitReadBurst}),
+			Write: ratelimit.NewMemory(ratelimit.Policy{PerMinute: cfg.RateLimitWritePerMinute, Burst: cfg.RateLimitWriteBurst}),
+		}
+	}
+
+	var conversationLimiter ratelimit.Limiter
+	if rateLimit != nil {
+		conversationLimiter = rateLimit.Write
+	}
+	// 会話 (WebSocket) のハンドシェイクも CORS と同じ許可リストでオリジンを検証する
+	brainHandler := handlers.NewBrainHandler(registry, conversationLimiter, middleware.OriginChecker(newCORSConfig(cfg)))
+
+	// Prometheus メトリクス (脳の状態・活動とHTTPのレイテンシ)
+	// 【用途】キャラクターの気分の推移をグラフ化する
+	// /api の外にあるためレート制限の対象外 (スクレイプ間隔はサーバー側で決まる)
+	if cfg.MetricsEnabled {
+		// 既定のレジストリには Go ランタイムとプロセスのメトリクスも含まれる
+		if err := errors.Join(
+			core.RegisterMetrics(prometheus.DefaultRegisterer, registry),
+			middleware.RegisterMetrics(prometheus.DefaultRegisterer),
+		); err != nil {
+			slog.Error("Failed to register metrics", "error", err)
+			os.Exit(1)
+		}
+
+		// @Summary      Prometheus Metrics
+		// @Description  脳ごとのホルモン・意欲・理性・共感・記憶数のゲージ、入力・感情・睡眠のカウンター、HTTPレイテンシのヒストグラム、Go ランタイムのメトリクスを Prometheus のテキスト形式で返す
+		// @Tags         system
+		// @Produce      plain
+		// @Success      200  {string}  string  "Prometheus text exposition format"
+		// @Security     ApiKeyAuth
+		// @Router       /metrics [get]
+		r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	}
+	memoryHandler := handlers.NewMemoryHandler(registry)
+	motivationHandler := handlers.NewMotivationHandler(registry)
+
+	// SIGINT/SIGTERM で終了処理を開始する
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// 無刺激時の減衰・睡眠・マインドワンダリングを自律的に実行するスケジューラ
+	scheduler := core.NewScheduler(registry, core.NewSchedulerConfig(cfg))
+	scheduler.Start(ctx)
+	schedulerHandler := handlers.NewSchedulerHandler(scheduler)
+
+	// 管理操作 (脳の作成・削除、記憶の削除、意欲のリセット、感情辞書の変更) には admin スコープが必要
+	admin := middleware.RequireScope(config.ScopeAdmin)
 
 	api := r.Group("/api")
+	if rateLimit != nil {
+		api.Use(middleware.RateLimitMiddleware(*rateLimit))
+	}
 	{
 		emotion := api.Group("/emotion")
 		{
 			emotion.POST("/assess", emotionHandler.Assess)
 		}
 
-		memory := api.Group("/memory")
+		// 旧API (非推奨): /api/v1 への移行を促すため Sunset ヘッダーを付与
+		memory := api.Group("/memory", middleware.DeprecationMiddleware(legacyAPISunset))
 		{
 			memory.POST("/add", memoryHandler.AddMemory)
 			memory.GET("/recent", memoryHandler.GetRecentMemories)
@@ -107,33 +217,43 @@ func main() {
 			memory.POST("/sleep", memoryHandler.Sleep)
 		}
 
-		motivation := api.Group("/motivation")
+		motivation := api.Group("/motivation", middleware.DeprecationMiddleware(legacyAPISunset))
 		{
 			motivation.GET("", motivationHandler.GetMotivation)
 			motivation.POST("/feedback", motivationHandler.UpdateMotivation)
 			motivation.POST("/emotion-reward", motivationHandler.RewardFromEmotion)
 			motivation.POST("/decay", motivationHandler.ApplyDecay)
-			motivation.POST("/reset", motivationHandler.Reset)
+			motivation.POST("/reset", admin, motivationHandler.Reset)
 		}
 
 		// 統合Brain API (v1)
 		v1 := api.Group("/v1")
 		{
-			// リソースベースのエンドポイント定義
-			v1.POST("/sensory-inputs", brainHandler.ProcessSensory)
-			v1.POST("/sleep-cycles", brainHandler.Sleep)
-			v1.GET("/brain-states/current", brainHandler.GetState)
-			v1.POST("/daydreams", brainHandler.Daydream)
-
-			// 既存パスのエイリアス/維持(または移行期間)
-			// v1.POST("/sensory", brainHandler.ProcessSensory) // Deprecated
-			// v1.POST("/sleep", brainHandler.Sleep) // Deprecated
-
-			// その他のエンドポイント (Grouping pending instructions, keeping as is or grouping logically)
-			v1.POST("/feedback", brainHandler.Feedback)
-			v1.POST("/stress", brainHandler.ApplyStress)
-			v1.POST("/rest", brainHandler.Rest) // sleep-cycles/rest?
-			v1.GET("/memories", brainHandler.GetRecentMemories)
+			// 脳の管理 (マルチテナント)
+			v1.GET("/brains", brainHandler.ListBrains)
+			v1.POST("/brains", admin, brainHandler.CreateBrain)
+			v1.DELETE("/brains/:brainId", admin, brainHandler.DeleteBrain)
+			v1.POST("/brains/:brainId/evictions", admin, brainHandler.EvictBrain)
+
+			// パーソナリティプロファイル
+			v1.GET("/profiles", brainHandler.ListProfiles)
+			v1.GET("/profiles/:name", brainHandler.GetProfile)
+
+			// 扁桃体の感情辞書 (変更・再読み込みは全ての脳に即座に反映される)
+			v1.GET("/lexicon", lexiconHandler.GetLexicon)
+			v1.GET("/lexicon/entries", lexiconHandler.ListEntries)
+			v1.POST("/lexicon/entries", admin, lexiconHandler.AddEntry)
+			v1.DELETE("/lexicon/entries/:word", admin, lexiconHandler.DeleteEntry)
+			v1.POST("/lexicon/reloads", admin, lexiconHandler.Reload)
+
+			// 自律活動スケジューラ
+			v1.GET("/scheduler", schedulerHandler.GetStatus)
+
+			// 脳IDは X-Brain-ID ヘッダーで指定 (省略時は既定の脳)
+			registerBrainRoutes(v1, brainHandler)
+
+			// 脳IDをパスで指定する形式: /api/v1/brains/{brainId}/sensory-inputs など
+			registerBrainRoutes(v1.Group("/brains/:brainId"), brainHandler)
 		}
 	}
 
@@ -150,9 +270,130 @@ func main() {
 		IdleTimeout:       15 * time.Second,
 		ReadHeaderTimeout: 2 * time.Second,
 	}
+	// SSE ストリームと会話 (WebSocket) は終了処理の開始時に閉じる (Shutdown が長時間接続を待ち続けないように)
+	srv.RegisterOnShutdown(brainHandler.CloseStreams)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
 
-	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		slog.Error("Server failed to start", "error", err)
+	// サーバーが異常終了した場合も、スケジューラを止めて脳を固定化・保存してから終了する
+	failed := false
+	select {
+	case err := <-serverErr:
+		if err != nil {
+			slog.Error("Server failed", "error", err)
+			failed = true
+		}
+	case <-ctx.Done():
+	}
+	stop()
+
+	if !shutdown(srv, scheduler, registry, time.Duration(cfg.ShutdownTimeoutSeconds)*time.Second) || failed {
 		os.Exit(1)
 	}
 }
+
+// shutdown はサーバーを安全に停止する
+// 【処理内容】
+// 1. 新規リクエストの受付を停止し、処理中のリクエストの完了を待つ
+// 2. 自律活動スケジューラを停止する
+// 3. 全ての脳を睡眠させて短期記憶を長期記憶へ固定化し、状態を保存してDBを閉じる
+// 全体を timeout 以内に行い、正常に完了したかを返す
+func shutdown(srv *http.Server, scheduler *core.Scheduler, registry *core.Registry, timeout time.Duration) bool {
+	slog.Info("Shutting down server", "timeout", timeout.String())
+	started := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	ok := true
+	if err := srv.Shutdown(ctx); err != nil {
+		slog.Error("Failed to drain in-flight requests", "error", err)
+		ok = false
+	}
+
+	scheduler.Stop()
+
+	if err := registry.Shutdown(ctx); err != nil {
+		slog.Error("Failed to consolidate and close brains", "error", err)
+		ok = false
+	}
+
+	if ok {
+		slog.Info("Server stopped gracefully", "elapsed", time.Since(started).Round(time.Millisecond).String())
+	} else {
+		slog.Error("Server stopped with errors", "elapsed", time.Since(started).Round(time.Millisecond).String())
+	}
+	return ok
+}
+
+// newCORSConfig は設定からCORSの設定を生成する
+func newCORSConfig(cfg *config.Config) middleware.CORSConfig {
+	return middleware.CORSConfig{
+		AllowOrigins:     cfg.CORSAllowedOrigins,
+		AllowMethods:     cfg.CORSAllowedMethods,
+		AllowHeaders:     cfg.CORSAllowedHeaders,
+		ExposeHeaders:    cfg.CORSExposedHeaders,
+		AllowCredentials: cfg.CORSAllowCredentials,
+		MaxAge:           cfg.CORSMaxAgeSeconds,
+	}
+}
+
+// newLogger は設定に基づく構造化ロガー (JSON形式) を作成する
+// DEBUG_MODE の場合は debug レベルで出力し、出力元のソース位置を付ける
+func newLogger(cfg *config.Config) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		Level:     cfg.SlogLevel(),
+		AddSource: cfg.DebugMode,
+	}))
+}
+
+// newAuthConfig は設定からAPIキー認証の設定を生成する
+// /health は常に、Swagger UI は SWAGGER_PUBLIC=true の場合に認証の対象外とする
+func newAuthConfig(cfg *config.Config) middleware.AuthConfig {
+	authCfg := middleware.AuthConfig{ExemptPaths: []string{"/health"}}
+	if cfg.SwaggerPublic {
+		authCfg.ExemptPaths = append(authCfg.ExemptPaths, "/swagger/")
+	}
+	if cfg.MetricsPublic {
+		authCfg.ExemptPaths = append(authCfg.ExemptPaths, "/metrics")
+	}
+	authCfg.Keys = cfg.APIKeyGrants()
+	return authCfg
+}
+
+// registerBrainRoutes は単一の脳に対する操作エンドポイントを登録する
+// 【用途】ヘッダー指定 (/api/v1/...) とパス指定 (/api/v1/brains/{brainId}/...) の両方で同じルートを提供
+func registerBrainRoutes(g *gin.RouterGroup, brainHandler *handlers.BrainHandler) {
+	admin := middleware.RequireScope(config.ScopeAdmin)
+
+	// リソースベースのエンドポイント定義
+	g.POST("/sensory-inputs", brainHandler.ProcessSensory)
+	g.POST("/sleep-cycles", brainHandler.Sleep)
+	g.GET("/brain-states/current", brainHandler.GetState)
+	g.GET("/brain-states/stream", brainHandler.StreamState)
+	g.POST("/daydreams", brainHandler.Daydream)
+	g.GET("/conversations/ws", middleware.RequireScope(config.ScopeWrite), brainHandler.Converse) // GET だが入力を送るため write
+
+	// 既存パスのエイリアス/維持(または移行期間)
+	// g.POST("/sensory", brainHandler.ProcessSensory) // Deprecated
+	// g.POST("/sleep", brainHandler.Sleep) // Deprecated
+
+	// その他のエンドポイント (Grouping pending instructions, keeping as is or grouping logically)
+	g.POST("/feedback", brainHandler.Feedback)
+	g.POST("/stress", brainHandler.ApplyStress)
+	g.POST("/rest", brainHandler.Rest) // sleep-cycles/rest?
+
+	// 記憶リソース
+	g.GET("/memories", brainHandler.ListMemories)
+	g.GET("/memories/search", brainHandler.SearchMemories)
+	g.POST("/memories/deletions", admin, brainHandler.ForgetMemories)
+	g.GET("/memories/:uuid", brainHandler.GetMemory)
+	g.PATCH("/memories/:uuid", brainHandler.PatchMemory)
+	g.DELETE("/memories/:uuid", admin, brainHandler.DeleteMemory)
+}
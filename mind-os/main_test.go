@@ -0,0 +1,59 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/umekku/mind-os/internal/config"
+	"github.com/umekku/mind-os/internal/middleware"
+)
+
+func TestNewAuthConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(cfg *config.Config) *gin.Engine {
+		r := gin.New()
+		r.Use(middleware.AuthMiddleware(newAuthConfig(cfg)))
+		ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+		r.GET("/health", ok)
+		r.GET("/swagger/*any", ok)
+		r.DELETE("/api/v1/brains/:id", middleware.RequireScope(config.ScopeAdmin), ok)
+		return r
+	}
+	serve := func(r http.Handler, method, path, key string) int {
+		req := httptest.NewRequest(method, path, nil)
+		if key != "" {
+			req.Header.Set(middleware.APIKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	tests := []struct {
+		name   string
+		cfg    *config.Config
+		method string
+		path   string
+		key    string
+		want   int
+	}{
+		// API_KEY は admin スコープのキーとして扱う
+		{"API_KEY は admin", &config.Config{APIKey: "legacy"}, http.MethodDelete, "/api/v1/brains/alice", "legacy", http.StatusOK},
+		{"API_KEYS の read", &config.Config{APIKey: "legacy", APIKeys: []config.APIKeyGrant{{Key: "viewer", Scope: config.ScopeRead}}}, http.MethodDelete, "/api/v1/brains/alice", "viewer", http.StatusForbidden},
+		// /health は常に、Swagger UI は SWAGGER_PUBLIC=true の場合に認証の対象外
+		{"ヘルスチェック", &config.Config{APIKey: "legacy"}, http.MethodGet, "/health", "", http.StatusOK},
+		{"Swagger UI を公開", &config.Config{APIKey: "legacy", SwaggerPublic: true}, http.MethodGet, "/swagger/index.html", "", http.StatusOK},
+		{"Swagger UI を公開しない", &config.Config{APIKey: "legacy"}, http.MethodGet, "/swagger/index.html", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serve(newRouter(tt.cfg), tt.method, tt.path, tt.key); got != tt.want {
+				t.Errorf("%s %s status = %d, want %d", tt.method, tt.path, got, tt.want)
+			}
+		})
+	}
+}